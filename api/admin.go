@@ -0,0 +1,23 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReloadConfig reloads the SigmaDAO program registry, the webhooks and the API keys
+// from the configuration of the daemon.
+// (POST /v2/admin/reload)
+func (si *ServerImplementation) ReloadConfig(ctx echo.Context) error {
+	if si.opts.Reload == nil {
+		return notServed(ctx)
+	}
+
+	err := si.opts.Reload("admin request")
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedReloading, err))
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+
+	generated "github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/util"
+)
+
+//////////////////////////////////////////////////////////////////////
+// String decoding helpers (with 'errorArr' helper to reduce noise) //
+//////////////////////////////////////////////////////////////////////
+
+// decodeAddress decodes the address in `str`, nil if `str` is nil. On failure an
+// error message is appended to `errorArr`.
+func decodeAddress(str *string, field string, errorArr []string) ([]byte, []string) {
+	if str == nil {
+		return nil, errorArr
+	}
+	addr, err := basics.UnmarshalChecksumAddress(*str)
+	if err != nil {
+		return nil, append(errorArr, fmt.Sprintf("%s '%s': %v", errUnableToParseAddress, field, err))
+	}
+	return addr[:], errorArr
+}
+
+// decodeNextID decodes the `next` token of endpoints paginated by id, 0 if `next` is
+// nil.
+func decodeNextID(next *string) (uint64, error) {
+	if next == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(*next, 10, 64)
+}
+
+// exceedsInt64 returns true if one of `values` does not fit in a postgres bigint.
+func exceedsInt64(values ...*uint64) bool {
+	for _, v := range values {
+		if (v != nil) && (*v > math.MaxInt64) {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////
+// Helpers for optional arguments //
+////////////////////////////////////
+
+func uintOrDefault(x *uint64) uint64 {
+	if x != nil {
+		return *x
+	}
+	return 0
+}
+
+func uintOrDefaultValue(x *uint64, value uint64) uint64 {
+	if x != nil {
+		return *x
+	}
+	return value
+}
+
+func strOrDefault(str *string) string {
+	if str != nil {
+		return *str
+	}
+	return ""
+}
+
+func boolOrDefault(b *bool) bool {
+	if b != nil {
+		return *b
+	}
+	return false
+}
+
+func min(x, y uint64) uint64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// limitOrDefault returns the requested `limit`, `defaultLimit` if it is not set,
+// capped at `maxLimit`.
+func limitOrDefault(limit *uint64, defaultLimit uint64, maxLimit uint64) uint64 {
+	return min(uintOrDefaultValue(limit, defaultLimit), maxLimit)
+}
+
+///////////////////////////////
+// Helpers for the responses //
+///////////////////////////////
+
+func strPtr(str string) *string {
+	if len(str) == 0 {
+		return nil
+	}
+	return &str
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func byteSlicePtr(x []byte) *[]byte {
+	if len(x) == 0 {
+		return nil
+	}
+
+	xx := make([]byte, len(x))
+	copy(xx, x)
+	return &xx
+}
+
+func byteSliceOmitZeroPtr(x []byte) *[]byte {
+	for _, b := range x {
+		if b != 0 {
+			return byteSlicePtr(x)
+		}
+	}
+	return nil
+}
+
+func addrPtr(addr basics.Address) *string {
+	if addr.IsZero() {
+		return nil
+	}
+	return strPtr(addr.String())
+}
+
+// deletedFilter returns the deleted status filter of the SigmaDAO queries, nil if
+// `includeAll` is set.
+func deletedFilter(includeAll *bool) *bool {
+	if boolOrDefault(includeAll) {
+		return nil
+	}
+	return boolPtr(false)
+}
+
+// setExcludeQueryOptions turns off the account resources listed in `exclude`.
+func setExcludeQueryOptions(exclude []string, opts *idb.AccountQueryOptions) error {
+	for _, e := range exclude {
+		switch e {
+		case "all":
+			opts.IncludeAssetHoldings = false
+			opts.IncludeAssetParams = false
+			opts.IncludeAppLocalState = false
+			opts.IncludeAppParams = false
+		case "assets":
+			opts.IncludeAssetHoldings = false
+		case "created-assets":
+			opts.IncludeAssetParams = false
+		case "apps-local-state":
+			opts.IncludeAppLocalState = false
+		case "created-apps":
+			opts.IncludeAppParams = false
+		case "none":
+		default:
+			return fmt.Errorf(`%s: "%s"`, errUnknownExclude, e)
+		}
+	}
+	return nil
+}
+
+// assetRowToAsset converts an asset query row to the API model.
+func assetRowToAsset(row *idb.AssetRow) generated.Asset {
+	var creator basics.Address
+	copy(creator[:], row.Creator)
+	params := &row.Params
+
+	return generated.Asset{
+		Index:   row.AssetID,
+		Deleted: row.Deleted,
+		Params: generated.AssetParams{
+			Creator:       creator.String(),
+			Name:          strPtr(util.PrintableUTF8OrEmpty(params.AssetName)),
+			NameB64:       byteSlicePtr([]byte(params.AssetName)),
+			UnitName:      strPtr(util.PrintableUTF8OrEmpty(params.UnitName)),
+			UnitNameB64:   byteSlicePtr([]byte(params.UnitName)),
+			Url:           strPtr(util.PrintableUTF8OrEmpty(params.URL)),
+			UrlB64:        byteSlicePtr([]byte(params.URL)),
+			Total:         params.Total,
+			Decimals:      uint64(params.Decimals),
+			DefaultFrozen: boolPtr(params.DefaultFrozen),
+			MetadataHash:  byteSliceOmitZeroPtr(params.MetadataHash[:]),
+			Manager:       addrPtr(params.Manager),
+			Reserve:       addrPtr(params.Reserve),
+			Freeze:        addrPtr(params.Freeze),
+			Clawback:      addrPtr(params.Clawback),
+		},
+	}
+}
+
+// daoToModel converts a SigmaDAO app to the API model.
+func daoToModel(dao *idb.DAO) generated.DAO {
+	return generated.DAO{
+		Id:             dao.AppID,
+		Creator:        dao.Creator.String(),
+		Version:        strPtr(dao.Version),
+		Name:           dao.Name,
+		Url:            dao.URL,
+		GovTokenId:     dao.GovTokenID,
+		Deposit:        dao.Deposit,
+		MinSupport:     dao.MinSupport,
+		MinDuration:    dao.MinDuration,
+		MaxDuration:    dao.MaxDuration,
+		Deleted:        boolPtr(dao.Deleted),
+		DeletedAtRound: dao.ClosedRound,
+	}
+}
+
+// optionalAddrPtr returns the string form of `addr`, nil if `addr` is nil.
+func optionalAddrPtr(addr *basics.Address) *string {
+	if addr == nil {
+		return nil
+	}
+	res := addr.String()
+	return &res
+}
+
+// proposalToModel converts a SigmaDAO proposal to the API model.
+func proposalToModel(proposal *idb.Proposal) generated.Proposal {
+	return generated.Proposal{
+		AppId:            proposal.AppID,
+		Address:          proposal.Address.String(),
+		ProposalId:       proposal.ProposalID,
+		Name:             proposal.Name,
+		Url:              proposal.URL,
+		UrlHash:          byteSlicePtr(proposal.URLHash),
+		HashAlgo:         proposal.HashAlgo,
+		VotingStart:      proposal.VotingStart,
+		VotingEnd:        proposal.VotingEnd,
+		ExecuteBefore:    proposal.ExecuteBefore,
+		Type:             proposal.Type,
+		From:             optionalAddrPtr(proposal.From),
+		Recipient:        optionalAddrPtr(proposal.Recipient),
+		AsaId:            proposal.AsaID,
+		Amount:           proposal.Amount,
+		Msg:              byteSlicePtr(proposal.Msg),
+		Executed:         proposal.Executed,
+		Yes:              proposal.Yes,
+		No:               proposal.No,
+		Abstain:          proposal.Abstain,
+		Status:           strPtr(string(proposal.Status)),
+		SnapshotRound:    proposal.SnapshotRound,
+		ExecutionRound:   proposal.ExecutionRound,
+		ExecutionResult:  proposal.ExecutionResult,
+		ExecutionDetails: proposal.ExecutionDetails,
+		Deleted:          boolPtr(proposal.Deleted),
+		ClosedAtRound:    proposal.ClosedRound,
+	}
+}
+
+// voteToModel converts a vote on a SigmaDAO proposal to the API model.
+func voteToModel(vote *idb.Vote) generated.Vote {
+	return generated.Vote{
+		AppId:         vote.AppID,
+		Proposal:      vote.Proposal.String(),
+		Voter:         vote.Voter.String(),
+		Option:        vote.Option,
+		Weight:        vote.Weight,
+		Round:         vote.Round,
+		Deleted:       boolPtr(vote.Deleted),
+		ClosedAtRound: vote.ClosedRound,
+	}
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/algorand/websocket"
+	"github.com/labstack/echo/v4"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// daoEventReplayRounds is the number of rounds of SigmaDAO events fetched per
+// database query while a stream catches up.
+const daoEventReplayRounds = 1000
+
+// daoEventKeepAlive is how often an idle stream is kept alive, with an SSE comment
+// or a WebSocket ping.
+const daoEventKeepAlive = 15 * time.Second
+
+// daoEventWriteWait is the time allowed to write a WebSocket message.
+const daoEventWriteWait = 10 * time.Second
+
+// daoEventRetry is the reconnection delay suggested to SSE clients, in milliseconds.
+const daoEventRetry = 1000
+
+// errDAOEventsLagged is returned when a stream misses published rounds and needs to
+// catch up from the database.
+var errDAOEventsLagged = errors.New("dao event stream lagged")
+
+// daoEventUpgrader upgrades WebSocket connections. Any origin is allowed, the stream
+// only serves public chain data.
+var daoEventUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// daoEventBatchModel is a WebSocket message, the events of a round.
+type daoEventBatchModel struct {
+	Round  uint64                `json:"round"`
+	Events []idb.DAOEventPayload `json:"events"`
+}
+
+// daoEventStreamParams are the parameters shared by the SigmaDAO event streams.
+type daoEventStreamParams struct {
+	// appID filters by dao app, zero for all apps.
+	appID uint64
+	// next is the first round to stream.
+	next uint64
+	// resume is false without `min-round` and `Last-Event-ID`, the stream then starts
+	// after the last committed round instead of `next`.
+	resume bool
+}
+
+// parseDAOEventStreamParams parses the `application-id` and `min-round` query
+// parameters. With SSE, the `Last-Event-ID` header of a reconnecting client takes
+// precedence over `min-round`. Without either, past rounds are not replayed.
+func parseDAOEventStreamParams(ctx echo.Context) (daoEventStreamParams, error) {
+	var res daoEventStreamParams
+	var err error
+
+	if str := ctx.QueryParam("application-id"); str != "" {
+		res.appID, err = strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", errUnableToParseDAOEventAppID, err)
+		}
+	}
+	if str := ctx.QueryParam("min-round"); str != "" {
+		res.next, err = strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", errUnableToParseDAOEventMinRound, err)
+		}
+		res.resume = true
+	}
+	if str := ctx.Request().Header.Get("Last-Event-ID"); str != "" {
+		last, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", errUnableToParseLastEventID, err)
+		}
+		res.next = last + 1
+		res.resume = true
+	}
+	if exceedsInt64(&res.appID, &res.next) {
+		return res, errors.New(errValueExceedingInt64)
+	}
+
+	return res, nil
+}
+
+// daoEventSink writes the streamed SigmaDAO events of a round. It is called with
+// the last replayed round and with every live round, even without events, so that
+// clients can resume after it.
+type daoEventSink func(round uint64, events []idb.DAOEventPayload) error
+
+// StreamDAOEvents streams the SigmaDAO events of the committed rounds with
+// Server-Sent Events.
+// (GET /v2/daos/events)
+func (si *ServerImplementation) StreamDAOEvents(ctx echo.Context) error {
+	if si.opts.DAOEventHub == nil {
+		return notServed(ctx)
+	}
+	params, err := parseDAOEventStreamParams(ctx)
+	if err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	fmt.Fprintf(res, "retry: %d\n\n", daoEventRetry)
+	res.Flush()
+
+	// The server stops writing the response after the write timeout, end the stream
+	// before so that the client reconnects from the last event ID.
+	streamCtx := ctx.Request().Context()
+	if si.timeout > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithTimeout(streamCtx, si.timeout)
+		defer cancel()
+	}
+
+	send := func(round uint64, events []idb.DAOEventPayload) error {
+		for i := range events {
+			data, err := json.Marshal(&events[i])
+			if err != nil {
+				return fmt.Errorf("StreamDAOEvents() err: %w", err)
+			}
+			fmt.Fprintf(res, "event: %s\ndata: %s\n", events[i].Kind, data)
+			// The event ID is the round, set once all the events of the round are sent.
+			if i == len(events)-1 {
+				fmt.Fprintf(res, "id: %d\n", round)
+			}
+			fmt.Fprint(res, "\n")
+		}
+		if len(events) == 0 {
+			fmt.Fprintf(res, "id: %d\n\n", round)
+		}
+		res.Flush()
+		return nil
+	}
+	keepAlive := func() error {
+		fmt.Fprint(res, ": keep-alive\n\n")
+		res.Flush()
+		return nil
+	}
+
+	err = si.streamDAOEvents(streamCtx, params, send, keepAlive)
+	if err != nil && streamCtx.Err() == nil {
+		si.log.WithError(err).Warn("dao event stream failed")
+		data, _ := json.Marshal(map[string]string{
+			"message": fmt.Sprintf("%s: %v", errFailedStreamingDAOEvents, err)})
+		fmt.Fprintf(res, "event: error\ndata: %s\n\n", data)
+		res.Flush()
+	}
+	return nil
+}
+
+// StreamDAOEventsWebSocket streams the SigmaDAO events of the committed rounds over
+// a WebSocket. Every round with events is sent as one message.
+// (GET /v2/daos/events/ws)
+func (si *ServerImplementation) StreamDAOEventsWebSocket(ctx echo.Context) error {
+	if si.opts.DAOEventHub == nil {
+		return notServed(ctx)
+	}
+	params, err := parseDAOEventStreamParams(ctx)
+	if err != nil {
+		return badRequest(ctx, err.Error())
+	}
+
+	// The upgrader writes the error response itself.
+	conn, err := daoEventUpgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx.Request().Context())
+	defer cancel()
+
+	// Handle the control messages and stop once the client goes away.
+	conn.SetReadDeadline(time.Now().Add(2 * daoEventKeepAlive))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * daoEventKeepAlive))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(round uint64, events []idb.DAOEventPayload) error {
+		if len(events) == 0 {
+			return nil
+		}
+		conn.SetWriteDeadline(time.Now().Add(daoEventWriteWait))
+		return conn.WriteJSON(daoEventBatchModel{Round: round, Events: events})
+	}
+	keepAlive := func() error {
+		return conn.WriteControl(
+			websocket.PingMessage, nil, time.Now().Add(daoEventWriteWait))
+	}
+
+	err = si.streamDAOEvents(streamCtx, params, send, keepAlive)
+	if err != nil && streamCtx.Err() == nil {
+		si.log.WithError(err).Warn("dao event stream failed")
+		conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, errFailedStreamingDAOEvents),
+			time.Now().Add(daoEventWriteWait))
+	}
+	return nil
+}
+
+// streamDAOEvents replays the SigmaDAO events from `params.next` out of the database,
+// then forwards the live events published by the hub until `ctx` is done. Without a
+// resume point, it starts at the round after the last committed one. A stream that
+// falls behind the hub catches up from the database again.
+func (si *ServerImplementation) streamDAOEvents(ctx context.Context, params daoEventStreamParams, send daoEventSink, keepAlive func() error) error {
+	next := params.next
+	resume := params.resume
+	for {
+		// Subscribe before querying the database so that no round is missed.
+		sub := si.opts.DAOEventHub.Subscribe()
+
+		var err error
+		if !resume {
+			next, err = si.nextDAOEventRound()
+			resume = true
+		}
+		if err == nil {
+			next, err = si.replayDAOEvents(ctx, params.appID, next, send)
+		}
+		if err == nil {
+			err = forwardDAOEvents(ctx, sub, params.appID, &next, send, keepAlive)
+		}
+		sub.Close()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !errors.Is(err, errDAOEventsLagged) {
+			return err
+		}
+	}
+}
+
+// nextDAOEventRound returns the round after the last committed one.
+func (si *ServerImplementation) nextDAOEventRound() (uint64, error) {
+	next, err := si.db.GetNextRoundToAccount()
+	if errors.Is(err, idb.ErrorNotInitialized) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("nextDAOEventRound() err: %w", err)
+	}
+	return next, nil
+}
+
+// replayDAOEvents sends the SigmaDAO events of the committed rounds from `next` and
+// returns the next round to stream.
+func (si *ServerImplementation) replayDAOEvents(ctx context.Context, appID uint64, next uint64, send daoEventSink) (uint64, error) {
+	for {
+		filter := idb.DAOEventQuery{
+			ApplicationID: appID,
+			MinRound:      next,
+			MaxRound:      next + daoEventReplayRounds - 1,
+		}
+		events, round, err := si.fetchDAOEvents(ctx, filter)
+		if err != nil {
+			return next, fmt.Errorf("replayDAOEvents() err: %w", err)
+		}
+		if round < next {
+			// Nothing committed yet.
+			return next, nil
+		}
+
+		last := filter.MaxRound
+		if round < last {
+			last = round
+		}
+		for len(events) > 0 {
+			end := 1
+			for end < len(events) && events[end].Round == events[0].Round {
+				end++
+			}
+			if err := send(events[0].Round, events[:end]); err != nil {
+				return next, fmt.Errorf("replayDAOEvents() err: %w", err)
+			}
+			next = events[0].Round + 1
+			events = events[end:]
+		}
+		if next <= last {
+			if err := send(last, nil); err != nil {
+				return next, fmt.Errorf("replayDAOEvents() err: %w", err)
+			}
+			next = last + 1
+		}
+
+		if last == round {
+			return next, nil
+		}
+	}
+}
+
+// forwardDAOEvents sends the batches published to `sub` from round `*next`. It
+// returns errDAOEventsLagged if a round is missed.
+func forwardDAOEvents(ctx context.Context, sub *idb.DAOEventSubscription, appID uint64, next *uint64, send daoEventSink, keepAlive func() error) error {
+	ticker := time.NewTicker(daoEventKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := keepAlive(); err != nil {
+				return fmt.Errorf("forwardDAOEvents() err: %w", err)
+			}
+		case batch, ok := <-sub.C:
+			if !ok {
+				return errDAOEventsLagged
+			}
+			if batch.Round < *next {
+				// Already replayed.
+				continue
+			}
+			if batch.Round > *next {
+				return errDAOEventsLagged
+			}
+
+			events := make([]idb.DAOEventPayload, 0)
+			for i := range batch.Events {
+				if appID == 0 || batch.Events[i].AppID == appID {
+					events = append(events, batch.Events[i].Payload())
+				}
+			}
+			if err := send(batch.Round, events); err != nil {
+				return fmt.Errorf("forwardDAOEvents() err: %w", err)
+			}
+			*next = batch.Round + 1
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/websocket"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/mocks"
+)
+
+func daoEventRows(rows ...idb.DAOEventRow) <-chan idb.DAOEventRow {
+	ch := make(chan idb.DAOEventRow, len(rows))
+	for _, row := range rows {
+		ch <- row
+	}
+	close(ch)
+	return ch
+}
+
+// makeDAOEventStreamDb returns a database replaying a proposal created at round 4
+// up to round 6. Rounds 6 and 7 are published to `hub` once the stream subscribed.
+func makeDAOEventStreamDb(hub *idb.DAOEventHub, filter idb.DAOEventQuery) *mocks.IndexerDb {
+	proposal := basics.Address{1}
+	db := makeTestDb(true)
+	db.On("DAOEvents", mock.Anything, filter).Return(
+		daoEventRows(idb.DAOEventRow{Event: idb.DAOEvent{
+			Kind:     idb.DAOEventProposalCreated,
+			Round:    4,
+			AppID:    5,
+			Proposal: &proposal,
+			Intra:    2,
+			Txid:     "TXID",
+			Method:   idb.DAOMethodAddProposal,
+		}}),
+		uint64(6),
+	).Run(func(mock.Arguments) {
+		publishDAOEvents(hub, proposal)
+	})
+	return db
+}
+
+// publishDAOEvents publishes rounds 6 and 7 to `hub`, round 6 is already in the
+// database.
+func publishDAOEvents(hub *idb.DAOEventHub, proposal basics.Address) {
+	hub.Publish(idb.DAOEventBatch{
+		Round:  6,
+		Events: []idb.DAOEvent{{Kind: idb.DAOEventDepositChanged, Round: 6, AppID: 5}},
+	})
+	hub.Publish(idb.DAOEventBatch{
+		Round: 7,
+		Events: []idb.DAOEvent{
+			{Kind: idb.DAOEventDepositChanged, Round: 7, AppID: 8},
+			{Kind: idb.DAOEventStatusChanged, Round: 7, AppID: 5, Proposal: &proposal,
+				Status: idb.ProposalVoting},
+		},
+	})
+}
+
+func TestStreamDAOEvents(t *testing.T) {
+	hub := idb.MakeDAOEventHub()
+	db := makeDAOEventStreamDb(hub, idb.DAOEventQuery{
+		ApplicationID: 5,
+		MinRound:      3,
+		MaxRound:      3 + daoEventReplayRounds - 1,
+	})
+	options := testOptions
+	options.DAOEventHub = hub
+	// The stream ends before the write timeout.
+	options.WriteTimeout = 200 * time.Millisecond
+
+	header := http.Header{}
+	header.Set("Last-Event-ID", "2")
+	rec := serve(t, db, options, "/v2/daos/events?application-id=5&min-round=1", header)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+	frames := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+	require.Len(t, frames, 4, rec.Body.String())
+	assert.Equal(t, "retry: 1000", frames[0])
+	assert.Equal(t, "id: 6", frames[2])
+
+	lines := strings.Split(frames[1], "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "event: proposal-created", lines[0])
+	assert.Equal(t, "id: 4", lines[2])
+	var event idb.DAOEventPayload
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+	assert.Equal(t, uint64(5), event.AppID)
+	require.NotNil(t, event.Txid)
+	assert.Equal(t, "TXID", *event.Txid)
+	require.NotNil(t, event.Method)
+	assert.Equal(t, idb.DAOMethodAddProposal, *event.Method)
+	assert.Nil(t, event.Status)
+
+	// The event of another app is filtered out.
+	lines = strings.Split(frames[3], "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "event: status-changed", lines[0])
+	assert.Equal(t, "id: 7", lines[2])
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+	require.NotNil(t, event.Status)
+	assert.Equal(t, string(idb.ProposalVoting), *event.Status)
+	assert.Nil(t, event.Method)
+	db.AssertExpectations(t)
+}
+
+func TestStreamDAOEventsWebSocket(t *testing.T) {
+	hub := idb.MakeDAOEventHub()
+	db := makeDAOEventStreamDb(hub, idb.DAOEventQuery{
+		MinRound: 3,
+		MaxRound: 3 + daoEventReplayRounds - 1,
+	})
+	options := testOptions
+	options.DAOEventHub = hub
+	logger, _ := test.NewNullLogger()
+	e, err := makeEcho(db, nil, logger, options)
+	require.NoError(t, err)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/v2/daos/events/ws?min-round=3"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Rounds without events are not sent.
+	var batch daoEventBatchModel
+	require.NoError(t, conn.ReadJSON(&batch))
+	assert.Equal(t, uint64(4), batch.Round)
+	require.Len(t, batch.Events, 1)
+	assert.Equal(t, idb.DAOEventProposalCreated, batch.Events[0].Kind)
+
+	require.NoError(t, conn.ReadJSON(&batch))
+	assert.Equal(t, uint64(7), batch.Round)
+	require.Len(t, batch.Events, 2)
+	assert.Equal(t, uint64(8), batch.Events[0].AppID)
+	assert.Equal(t, idb.DAOEventStatusChanged, batch.Events[1].Kind)
+}
+
+// Without `min-round` and `Last-Event-ID`, the stream starts after the last
+// committed round.
+func TestStreamDAOEventsWithoutResumePoint(t *testing.T) {
+	hub := idb.MakeDAOEventHub()
+	db := makeTestDb(true)
+	db.On("GetNextRoundToAccount").Return(uint64(7), nil)
+	filter := idb.DAOEventQuery{
+		ApplicationID: 5,
+		MinRound:      7,
+		MaxRound:      7 + daoEventReplayRounds - 1,
+	}
+	db.On("DAOEvents", mock.Anything, filter).Return(daoEventRows(), uint64(6)).Run(
+		func(mock.Arguments) {
+			publishDAOEvents(hub, basics.Address{1})
+		})
+	options := testOptions
+	options.DAOEventHub = hub
+	options.WriteTimeout = 200 * time.Millisecond
+
+	rec := serve(t, db, options, "/v2/daos/events?application-id=5", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	frames := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+	require.Len(t, frames, 2, rec.Body.String())
+	assert.Equal(t, "retry: 1000", frames[0])
+	lines := strings.Split(frames[1], "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "event: status-changed", lines[0])
+	assert.Equal(t, "id: 7", lines[2])
+	db.AssertExpectations(t)
+}
+
+func TestStreamDAOEventsNotServed(t *testing.T) {
+	db := makeTestDb(true)
+
+	rec := serve(t, db, testOptions, "/v2/daos/events", nil)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	rec = serve(t, db, testOptions, "/v2/daos/events/ws", nil)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func TestStreamDAOEventsBadRequest(t *testing.T) {
+	db := makeTestDb(true)
+	options := testOptions
+	options.DAOEventHub = idb.MakeDAOEventHub()
+
+	rec := serve(t, db, options, "/v2/daos/events?min-round=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errUnableToParseDAOEventMinRound)
+
+	header := http.Header{}
+	header.Set("Last-Event-ID", "abc")
+	rec = serve(t, db, options, "/v2/daos/events", header)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errUnableToParseLastEventID)
+	db.AssertNotCalled(t, "DAOEvents", mock.Anything, mock.Anything)
+}
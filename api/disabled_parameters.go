@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -321,7 +322,6 @@ func GetDefaultDisabledMapConfigForPostgres() *DisabledMapConfig {
 	get("/v2/accounts/{account-id}/transactions", []string{"note-prefix", "tx-type", "sig-type", "asset-id", "before-time", "after-time", "rekey-to"})
 	get("/v2/assets", []string{"name", "unit"})
 	get("/v2/assets/{asset-id}/balances", []string{"currency-greater-than", "currency-less-than"})
-	get("/v2/transactions", []string{"note-prefix", "tx-type", "sig-type", "asset-id", "before-time", "after-time", "currency-greater-than", "currency-less-than", "address-role", "exclude-close-to", "rekey-to", "application-id"})
 	get("/v2/assets/{asset-id}/transactions", []string{"note-prefix", "tx-type", "sig-type", "asset-id", "before-time", "after-time", "currency-greater-than", "currency-less-than", "address-role", "exclude-close-to", "rekey-to"})
 
 	return rval
@@ -457,7 +457,7 @@ func MakeDisabledMapFromOA3(swag *openapi3.Swagger, config *DisabledMapConfig) (
 }
 
 // ErrVerifyFailedEndpoint an error that signifies that the entire endpoint is disabled
-var ErrVerifyFailedEndpoint error = fmt.Errorf("endpoint is disabled")
+var ErrVerifyFailedEndpoint error = errors.New(errDisabledEndpoint)
 
 // ErrVerifyFailedParameter an error that signifies that a parameter was provided when it was disabled
 type ErrVerifyFailedParameter struct {
@@ -465,7 +465,7 @@ type ErrVerifyFailedParameter struct {
 }
 
 func (evfp ErrVerifyFailedParameter) Error() string {
-	return fmt.Sprintf("provided disabled parameter: %s", evfp.ParameterName)
+	return fmt.Sprintf("%s: %s", errDisabledParameter, evfp.ParameterName)
 }
 
 // DisabledParameterErrorReporter defines an error reporting interface
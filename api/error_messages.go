@@ -9,13 +9,22 @@ const (
 	errUnableToParseBase64             = "unable to parse base64 data"
 	errUnableToParseDigest             = "unable to parse base32 digest data"
 	errUnableToParseNext               = "unable to parse next token"
+	errUnableToParseDAOEventAppID      = "unable to parse application-id"
+	errUnableToParseDAOEventMinRound   = "unable to parse min-round"
+	errUnableToParseLastEventID        = "unable to parse Last-Event-ID header"
 	errUnableToDecodeTransaction       = "unable to decode transaction bytes"
 	errFailedSearchingAccount          = "failed while searching for account"
 	errFailedSearchingAsset            = "failed while searching for asset"
 	errFailedSearchingAssetBalances    = "failed while searching for asset balances"
 	errFailedSearchingApplication      = "failed while searching for application"
+	errFailedSearchingDAO              = "failed while searching for dao"
+	errFailedSearchingProposal         = "failed while searching for proposal"
+	errFailedSearchingVote             = "failed while searching for vote"
+	errFailedStreamingDAOEvents        = "failed while streaming dao events"
 	errFailedLookingUpHealth           = "failed while getting indexer health"
 	errNoApplicationsFound             = "no application found for application-id"
+	errNoDAOsFound                     = "no dao found for application-id"
+	errNoProposalsFound                = "no proposal found for address"
 	ErrNoAccountsFound                 = "no accounts found for address"
 	errNoAssetsFound                   = "no assets found for asset-id"
 	errNoTransactionFound              = "no transaction found for transaction id"
@@ -23,6 +32,8 @@ const (
 	errMultipleAccounts                = "multiple accounts found for this address, please contact us, this shouldn't happen"
 	errMultipleAssets                  = "multiple assets found for this id, please contact us, this shouldn't happen"
 	errMultipleApplications            = "multiple applications found for this id, please contact us, this shouldn't happen"
+	errMultipleDAOs                    = "multiple daos found for this id, please contact us, this shouldn't happen"
+	errMultipleProposals               = "multiple proposals found for this address, please contact us, this shouldn't happen"
 	errMultiAcctRewind                 = "multiple accounts rewind is not supported by this server"
 	errRewindingAccount                = "error while rewinding account"
 	errLookingUpBlockForRound          = "error while looking up block for round"
@@ -33,4 +44,13 @@ const (
 	ErrResultLimitReached              = "Result limit exceeded"
 	errValueExceedingInt64             = "searching by round or application-id or asset-id or filter by value greater than 9223372036854775807 is not supported"
 	errTransactionsLimitReached        = "Max transactions limit exceeded. header-only flag should be enabled"
+	errRewindNotSupported              = "accounts are only available at the current round, rewinding is not supported by this server"
+	errUnknownExclude                  = "unknown value for exclude"
+	errUnknownProposalStatus           = "unknown proposal status"
+	errUnknownVoteOption               = "unknown vote option"
+	errDisabledEndpoint                = "endpoint is disabled"
+	errDisabledParameter               = "provided disabled parameter"
+	errEndpointNotServed               = "endpoint is not served by this indexer"
+	errRequestTimeout                  = "request timed out"
+	errFailedReloading                 = "failed to reload the configuration"
 )
@@ -0,0 +1,289 @@
+// Package common provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/algorand/oapi-codegen DO NOT EDIT.
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Returns 200 if healthy.
+	// (GET /health)
+	MakeHealthCheck(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// MakeHealthCheck converts echo context to params.
+func (w *ServerInterfaceWrapper) MakeHealthCheck(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.MakeHealthCheck(ctx)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface, m ...echo.MiddlewareFunc) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET("/health", wrapper.MakeHealthCheck, m...)
+
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9fY/cNtIg/lWI/j3A2vm1ZhznSfCsgcWD2XhzMdbZGLaTBS7OZTkSu5s7alIhqZnp",
+	"5PzdD1VFSpREql9mPHHu8pfHLb4UWcVisV5/XZR622gllLOLZ78uGm74Vjhh8H+8LHWrXCEr+F8lbGlk",
+	"46RWi2fhG7POSLVeLBcSfm242yyWC8W3YvEs7r9cGPFzK42oFs+cacVyYcuN2HIY2O0aaO1Hev9+ueBV",
+	"ZYS101m/VfWOSVXWbSWYM1xZXsIny26k2zC3kZb5zkwqppVgesXcZtCYraSoK3sWgP65FWYXQe0nz4O4",
+	"XNwWvF5rw1VVrLTZcrd4trjw/d7v/exnKIyuxXSNX+rtpVQirEh0C+qQw5xmlVhhow13DKCDdYaGTjMr",
+	"uCk3bKXNnmUSEPFahWq3i2c/LKxQlTCIuVLIa/xzZYT4RRSOm7Vwix+XKdytnDCFk9vE0l54zBlh29pZ",
+	"hm1xjWt5LRSDXmfsm9Y6dikYV+z1V1+yzz777M+MttGJyhNcdlX97PGaOixU3Inw+RCkvv7qS5z/jV/g",
+	"oa1409Sy5LDu5PG56L+zF89zixkOkiBIqZxYC0Mbb61In9UL+DIzTei4b4LWbQogmzxi/Ym3rNRqJdet",
+	"ERVQY2sFnU3bCFVJtWZXYpdFYTfNhzuBl2KljTiQSqnxvZJpPP9vSqdla4xQ5a5YG8Hx6Gy4mm7Ja78V",
+	"dqPbumIbfo3r5lu8A3xfBn0Jz9e8bmGLZGn0Rb3WlnG/g5VY8bZ2LEzMWlULa3E0T4dMWtYYfS0rUS2Z",
+	"VOxmI8sNK7mlIbAdu5F1DdvfWlHltjm9uj1k3nUCuE7aD1zQx7sZ/br27IS4xYMwXf7fbv1xryoJP/Ga",
+	"SSe2ltm23DBuPVQbXcNht0sWcTJW65LXrOKOM+s0cIiVNv7qJvax9P17aYSViMCKXe7GLVU1GH1/H9gf",
+	"cdvUGla24rUV6f0Kq483CVcZX5K8rhee9drFcuGnLLofeNPYAldcWMediNs0DbRQWonETdr9wI3hO/i/",
+	"dTsQF5BHLHrsFGWtrSic3iNJBOEANyy6++MdO0quYG83guHk8IFkKqRsBeymrnfMeQQAQbAgRSyZXLGd",
+	"btkNHp1aXmF/vxqg6S0D5CPKBiIPyI054p5sRoK0L7WuBVdI2hvBK2EKrerddN++xo8MPrJVzddn7J8b",
+	"4Q+ztAQdgbNkRrjWKKCyWpdXrNLCMqUdXICOSzWWPW0G/hiePaB78bcA0stfxHU4ktQc7lzcm6q7o5es",
+	"ErVwYnB+8FfrjN7B70jFS6YboFfduum5VpUflj6PjznSfFbSjleyZ9G13Eo3Xe43/FZu2y1T7fYSMLbq",
+	"Lm2nPWqQTo1gJZLb5YBpNXwtLBNwp0t6JuA8TBIOjeDlJs9QCaY9PHTLbwujW1UdIA07pk0sbdhGlHIl",
+	"RcW6UXKw9NPsg0eq4+DpZfQIHKn2gCPVYeAocZtAK3AW+IIIirB6xr7z1x5+dfpKqO52JD4vWGPEtdSt",
+	"7TplYMSp59+hSjtRNEas5O0UyDd+O4C5URt/N2+9YOhZgKiY5wMwHDHKLEzRhMdKv5fcii/+c/F+39fG",
+	"6EZbXhfZV3ZoEVgF65/EiVf+ZLzD3/p3FeONuBK75NU3pmXCTKc52AgW+s4jpJthD3868Eit9PgozR6j",
+	"g44QNiqIAyYkVfjq+WMaf4P+B+Aunpt0BMWd9DU0RqCx3FaMZvpwNGXluqARJwdert+CRLSSNUpL/4Zz",
+	"HjDbWrhih7gN8pOVa8Vda8Szd+oT+B8r2BvHVcVNBb9s6adv2trJN3INP9X000u9luUbuc5tSoA1qb/B",
+	"blv6B8ZL62vcbbfc1BTuNj9Dw6HhldgZAXPwcoX/3K6QkPjK/EISMN7urlnlAEjpLF5qfdU28YaWAx3e",
+	"5Y69eJ4jFhxyjrUj77CNVlYg1V4Qo3vtf4OfgHsLhZdTJNac/9tqfA/2YwP/E8ZJEetM4c//MGK1eLb4",
+	"/857Hes5dbPnfsL+Ce5ytzIdYO48CyPW5ZkayTXbpnUkpaS4Q3ecf+hgG8/Zo0Vf/luUjjZoCMYjsW3c",
+	"7jEA7GG397dbdvC2OnDfxu+jD7iPJKcUKG9MR/7O+jdsw9dS4cKX7AZeDFt+BVyBK+02wjDAhbAuSCzE",
+	"/nDQXtnrxR7/7DlbpE5MAqf2zkjtsfYSJPc3KLnfB4pHL+AjcJ0C6Q/Md5ifbOx9ksD6nnA/qwV/9+4H",
+	"3jSyun337sfB41GqStym8fFBkV3rdQGaqdNodP0cuiYI9GOmoaGF4b4I6H6J5wgsPOyNel/bdc+H7SQe",
+	"+wdnTZyKuzNVa4X7K6+5Ku/lOr30Qx2M4W+kkgjE16S1+wPNAc3dVt4Hiv3u3stBJuPBwUf4D+SmznBn",
+	"krkzau8LpQch8oFfhDjlfWzSb0X4f1D8/VL8X8GMdRIu51CFo+6Z+fnFt/dAQx8U9xXX+1b6/OLbPVIi",
+	"DXMkWp5ffGt/B7tz+OHFffodvZQSOLTHIvFvxmhzD1gML9TR3MvFVljL1yJtzIpXExoesoAAMG69gCWg",
+	"nvxrwWu3+XIjPgC7iMbes6WvvPHpYz8bwUi2b+VhPXvprxvwSBoME3z03OTj4QT9Zh/O3no8jnncgXg9",
+	"mrm87Y0jHztqIzvOvn2MVrV36+JhT9+8Pw7GEQdj4Nl06NkY4PTI4zGY8Egkf6/vx6Lx/wx2r/Ux5hrY",
+	"3qPxSVMchcj3wbAbW24TTu/0gUlFVn6pFSCFex9u8rt4p96p52IlFXqEPXunQLQ6v+RWlva8tcJ4zd3Z",
+	"WrNnzA8JWv53arEckUXWCQJ2O3jSNO1lLUtwf09tOPkPp+0k9VqDlcRpx+vI3y3yKvZeRr2Vd0pdNEEB",
+	"RKBbV3hv/MKIG26qBOi283HCkbH37KxL5sfGH/34zI+fpviJi2zGTFSPjEQ24Uks1dDVF/D7D+28xw+/",
+	"YURfrLXCsn9tefODVO5HVrxrnzz5TLCLpuktjf/q/ZIBaAD+fs2WuHDEZyFuneEFuiMml+8EbxD7G8Fs",
+	"uwUUgIcndhu6Pxu9NnzrPRvHjtUzCCA4DhPPoxXi4t5Qr/fLSIMzxSB8QhRiG7YR9dQ1+1h8RarPk9G1",
+	"R306E/Xy7t0PGNASMNO5WK+5VDZcAFauFRwCHysAjoHwsBHVGXuxYsjVloPuPmLNs8qOdUhL7v3sLawR",
+	"vdZYyRUM2DYVOlpLxbjajf1krHAuOCe9Bn+2t5HT25HOU97Zl++5/aoWhutuwB7D7IZbttXoOFUK5eqd",
+	"9x9OkGYamFYqR46MA0f6DNPAUxN5uMPBiVlIJkYg8prmTcPWtb70nKYj0WcdjYY+eabyCgCw98BQktrO",
+	"YcxBeiO4SWwEdsiGSRy/UBjvTsdwdnknk9xKGou+6YL7O4LHR+QEyvOO81NQ/rkRKIBpgw7kQ5Ky4Uin",
+	"iL5zJl0uGm6cLGVzmOmbRn816AOD7Lvak5e5Xo3v7MmVmrxCqHEBTsZJAhTwBSiwtRQPAmsMjC7MRIIx",
+	"ruCMoeeoP6qXNYaIdMGFhGNuMHYlLFut50BLnwthVC9TBTCGOxILbxtuQxhLtYxYxEFiToZ4wdUdP+G5",
+	"iag3llslzFuLa57b/7zT6gtVAe8QdhjS07mkhmtlGlkV3NgpiDq4rgZ/1eCkCv8CtbcQcLJirbpS+kYt",
+	"lke5oS4X1nHXppGkFUp+cObWtB3UOJCPB/hPNkIbQPXtalVLJVjBZLcHDvdgg2FzupQUndSfTz+HgIfB",
+	"JwxoEAY4eIQUcUdgN1rXNDD7h45PrFofA6QSEnkMD2Mjs4n+L9JPdRTwUNajeB6p0tRYBr4AEubgskTA",
+	"MNbxUghFYUFMqiWDB941r4WiwKjBIOnIv0cDUduLefZxTo5Pq5FoRXiLHbUm7HHSamJhMQCdlmRnIJ6X",
+	"W1IosOxRJ0X0ezUT37h36oyskNurR7jwOwAwtlV2bvz+ybv3aTq90XrWvuwDJYiNpKk9RzFJvGR2bKqp",
+	"6PyhX42v7aQ+YtCKUZNL/76OxLMUS4ZTUWplhbItBgU7Xer6bKKIsKIWKNkUA0miAKVD8g0jkMG+Cd0i",
+	"JQV7JFfwpHgciS5GrKV1YhC420Wx9PFGOwx2bbhzwsBE/+vRfz/74aL4n7z45Unx5////Mdf//P9408m",
+	"Pz59/5e//O/hT5+9/8vj//6PRebWEEVjtF7lV+cas4L1vda648rYkWHHwTIffAWg/ypQQC2ueZ3xkYVG",
+	"X1l8PH8FTdMCwwDZjOLOZUbLiNNCZFMl6zZNr37evz+Haf/R6Ztse3kldigWQnQku+Su3MCH4fTQZmbq",
+	"mu9d8Eta8Et+b+s97DRAU5jYALkM5/idnIsRr51jBwkCTBHHFGvZLZ1hkHjVPxe149ONj/Oh0OGsoOHZ",
+	"nJZ1cpiqMPbcgymCIn8r0UjJtQy9kvOrQBd2DJCWLgpkt5MVHfrAveli0GOZCvQpfoQP/pCNVxc/Zv0o",
+	"6des/3iH5U2HP3R59xVzgNg7Rk9DktKEwPDg+MH2EFekOp4GIjptRFB/02mJngqU7UHFa5seoz5o/zDE",
+	"BBGE+jHddlfpaJoPRoAi8ZSgtadoka2M3uLJmwqlEXHKzIt8QIL9lTOa1WfXmtILME/M+rLXFCp4/Xex",
+	"+x7aIlahdxBMDz0yvYIivGH8s+VuqLmbLSBF+X7EvZRPcTQ5soeVeYXswLZ35Amo9Tqtb6jXKHfodR+u",
+	"HZPDpYC3n7gVZev6pAMjfWKn8nxYaXKsO02H1kb2d0oKNi8/4Eb5sfag7lXHJz8k5ngDdm1eF97YlePx",
+	"Rl97Ho/Ng23sgcWx9DF7+7eLl688+GhWEdwU3XMmuyps1/xuVmUEd9pkWGxIKgRqmKBJGN//3tgl7cBA",
+	"doMJXUYvZpC0PHERg+6Nn/14wWC2CnL5keYvb6elJc7Ya0XTmWt7PTt2Hllo+TWXdVBwB2jTlwotrreR",
+	"H32vxAPc2dIbGeyLe70pJqc7fTr2cKJ4hpnMLVvKH2SZpou096vAxy3MQAS65TugG1JPTlmSarcFHLrC",
+	"1rJMm0DUpQWSUGS9h8YMG2eeyTAi3MXpsVoZjQXN7AFKtxGQ0RzJzQwhK7m9u9Tek6hV8udWMFkJ5eCT",
+	"wbM4Op5wGkNau5OfQAkbH6W/e8BHEE54zPPHZ9O60+K6UU5YHr5rppN6rPn1dLi7y/un1xFP5T8EYv7x",
+	"EztiTMB93mlKAxV1eneuBjbrI/y54hknUsaML5Y/fJ5VtEp6K8AJ2NmftTU8tHzWtUzsxTHvqDiJ251e",
+	"T7ZYGf2LSGsPUel6M50+mph6pwc/+BU0OjeZ15AcJaU8AVVdGry7gtS9nu8M1Pju7IwpfUrfHknZQ5cT",
+	"26OPbOgJmGHseP4ifxN8oAZrKFd04L7E1MCDF1P62EYt7DmN3x9bD/NUr8FvLnl5lZaeAaaL3stqYLd1",
+	"moXOAR12iKUzFjlsdW19rsBGmK10TiSkyjtIwjTtwTJwL/JCx4Gw6zOl1lYnhmnVDVcuZHz0DM33toIs",
+	"T9DrRhvrMPdscpWVKOXWR0lMNr/C3X87ELIquZbOhgzRfaZBPxBrtFSOqKiStqn5jvzY+q15sWJPlhFX",
+	"89io5LW08rIW2OJTagE+KLi2TnUVusDyhHIbi82fHtB806rKiMptfBJMq1n3WkHNT+c+cSncjRCKPcF2",
+	"n/6ZPULHESuvxWPYRS+CLp59+mfMzkj/eZJm8pgueI7pVsh1A9NP0zF6ztAYcH36UdNcmBK+5/n7zGmi",
+	"roecJWzpr4T9Z2nLFV+LtDvmdg9M1BexiVas0b4obOSFLSZden7hOPCnYsPtJi0fEBis1NutdFvvSGD1",
+	"FuipTw9Hk4bhKJ8ucfgOrvARvXQaltbrPayOiVKspVaNvlT/4Fsx3NYl45bZFmDu9WWeIZ4xnyGxomy7",
+	"vUYT9wbmQgEFhE1AIdgZjVQOX8ytWxX/xcoNN7x0wtizHLjF5Rf/OQX5r5gRkwlVaphfHQf4g++7EVaY",
+	"6/TWmwzZB1HL92WPlFbFVgKrf+y5/PBUZh2HktO6wNHHQQnzQx8qb8EoRZbc2gG58YhT34nw1MyAdyTF",
+	"bj1H0ePRK3twymxNmjx4Cxj67vVLL2VstRFDxe9lCBQZyCtGOCPFtaiySIIx74gLUx+EhbtA/9ua/YPI",
+	"GYll4SynHgKUoWG6HfBzvOzcE1vrqyshGqnW55gJnUR1GnUspK+FElba/AW63gDlwGfmdKwRwaHZpai1",
+	"WtuHp/QAeMauvBbIk1483wf1ZOChHwWFc+yPMY47fef7wGA+AXaB8+Z3GdoBvK98ew8ntP8trrfOTXtv",
+	"IpHXvm3eqxruRIrL+dJH0WBDNjTn0npBv8abRqiKZETkpRsuVcbVWogq40YncMY32jg8Gwx++W2d4pzh",
+	"5VVSn/YWvtjOGY7cqSO3OHtw5Aaq2l9Bn7dhtpQpUm6FdXzbpCUJ1I0Ts0HGBdvXdWES9rLUqrLMSlUK",
+	"JhptN/tiyzOhdLcKJ6ulpVs16sBKbSgvMIpNTo/CRQ/dktkI5yGMhdHa5QBF+SoOTdfaMQhIE8p1zuKC",
+	"WTFdCYW7wCr82524MvtGmz6jMlTpWDIJvvMO4xDIQ5KzrTBXtWDOCCgFoq1gteDXoq9cg6P9ybK3t7Ky",
+	"WJemFreyBNtMs5El06YS5ox95Q3o+NCjTn6+J2fMB/p5Z/e3twqX11XQiNdJywwxC525Jl7xkmSE8c/w",
+	"w9aK+lrYM/b2RhMQto+Dtnw76nHZOgoSquRqJZB74HLwfYj9+g8RTFiDB/3Ju2H9mh6eB0worLAb/vTz",
+	"L3KE9vTzL1K09ubri6eff8EkKd3bW1lLbnZxM2i1ZJetrJ1Pgc7ZtSidNvHrVyrrBK8mtEW6Ez8LXver",
+	"VpXeG6vrEldKevP1xeefPv3p6edfeGVLNEsIhkSJUDGhrqXRCj4FPVdHIX7KbjZxK637DQQKd6sKfKpl",
+	"9BmOlGa36ktqRM75dmSrHLGwLSlPwsGvRbUWZkk6ffgB+GqfnwCeEdq4Xne4EkjQeC9K5Yyu2lJQqPyb",
+	"Ad+IwJITkLoaHT1sdNZDqaoezqD3Czcy6IbwrfWEJH6lhyvEMyauhaHAjX6gR3Q5RHBZxw18Iacdv1RR",
+	"PU5f7W2zNrwSh5nY8bL6jnp0Id5hhGt93ACUO2EkwQ/E5IHwmZbxojAIIeCf/s5N3TkzXCL7QHidC6b7",
+	"iso/GVFTPJPTIRpuORH/V0IUVqq0jn4lBF7PvCxFA5Qe1+0UAu4aOul4ljH8OghtgHzl5LWgSKsZKbMo",
+	"eV22NUnbMyLkTclrMzT21WLlNNBeXM6tV1xLmOsSvaYZ1q2h+QzcYVEPOGxAwTvfgt74UvXnxow8VKYR",
+	"jUUtrkX65S04BTZ+rW9A5bnrcAFT9GAso/CnDnISgtH9gbD9nVc/RODTOfMEOQ8koCKzuVWM50YYqStZ",
+	"Mqn+LfxB7zhWoBiqN6WVk6oFHsSM6OGmq55hjOY4DnNKASaXaQI+DEMelLgZYLuKHgrDAAHr+JUgsP08",
+	"jLujcGqElVWbUbgbXg4hO44Y/eF9zZ04Nx1q7T3R5Yh5dYd87tCNaXlENiNsTXcpy6cGfPkQZsW7eCrm",
+	"eXjCZ9rnrgktM49q7XTQi/oe/djXwtihN25PmbC982NDi8H48AOjjGGiOmGWIjhb2ex8O2GHNBfkZ4rB",
+	"xv7Ce/skdjCT4KgDwN5IV26KTAAStKUWFMA1esJPpyTpAk+hWK1E6Q6BASNZqOxaFgr6DFA8F7zCsOA+",
+	"KInCkcagPPqHZjC0jUQeZSU+JHqJB0d5fESq+TDPXuL/Xh9I+9ca/1phDPH+Y+A/eNrJKOepjSeePgad",
+	"s52wuCudb3V0RjDxXdoOGSatRM13c1Nig+GkncwbTLF053C4w+BCIV/utJN8NLU/Z3OTQ5PxgrvjOT0V",
+	"cRGkMSYhP2qioCdVVeaBpXP2Rq63/PnFt7FRcMkqQerrzo8n9ow9S/vfHemekAsvONSV9v/y0KNKNNrK",
+	"tGbHfwNKWcP1rZAUMT9bTzG8xnwTaI/G5icAsdbXlEsuq7HW1z9hg59klQLHmzZePE9MPhM+kyLK7CBQ",
+	"urLyNJ026vPbn0IDANJ7TAS+FT5FWsLMRFLtmUiq4URSnTyRf2tm5/Hf42n6R0ZgkrZnykQRTrOGW3sC",
+	"KWTtqBXXP8FHgKS3gQv2/OLbs2OsfTBOa+pg8tszihdREnXf+KWoQ++OjoLTvO82CcrZQhSysGdHWMUy",
+	"0Sx/u+Z1Jkb1tWiMsKhO4gxCHbynTy5StcwSGnc+o4njLJuECMrU71wmwICcyfG7ry+exFXOgZz8x+Hz",
+	"pPdpjoe5rKvRhoZ4hClAfw/hcqzh0rux9WG60531odtTojok5K5H8HgRPiAaB0mt5GtuN1/x0mmzmyYF",
+	"BQ1MJgWP95Q4Zos//SItFQAI6UnQDcMn9xlqUjvvRry6g9isV5MMPwxT/Gy4V7CG/4JCKErn031fLBcT",
+	"dVGPiziF99SxlG3wM2XCY6EU4xTT2Uzn1WXRhc6kSrIuFz5TeZy9dG+8nLTFVq4NSsbpUfMZ1iNbZCL/",
+	"QI7dBfNk/sk2ItLBwkcQ9+D1qsAwc4qgX4BTvjC9Ae+bfnWjqkOkZcQi5bbode5p3kTE/rCadEphAFNY",
+	"EPnySr3VkUeRrs+aO3HY+PVp46sCX1OquBFyvUlv7KuThgZBYj/Srh8eaSkG9w0ahy7gQCJFZhjtqmfD",
+	"s3UEIo6NXhYu4wnhNrT8jyVu1wh46jYZcF11JCH8V2azx6XBEozaym1Tk79qEL7GebCOytHRh9V8+Cit",
+	"+w5x+eBBKuJkX8n7j005FZb9mb7mI1K+VV/qbVOLvPDckKdxJVZSefUO5jbkVYV+N1i43qEFQZdla3pb",
+	"/zjm5HteSyrTbTG/odK6gX9146SCPzDdhW4d/S24gT/IPWv4F1FVJCfBUAvEi1QLnzdXty5Esy6WC+q8",
+	"CJSdlKKSLl6TTRm06vCJfuOotFFCVKhJ6HMPn/PSkZnce48q4W60uZqKYOK2AVyOUv3EhZun7JQb1zaV",
+	"2VL8fedqoymfYuibAE6ra2G8CUr7/I1kbHIbIc00yRTz4A1cc/bw1xQrfBXVZxmzwPgVio1G+jU0skeZ",
+	"QfzxCa1jBjPik5fWcZmhcvqGG0h/kkbglBDDXGL6twko4zr8R2r05iIm8RMupguH90l4V8KcsqamKe5F",
+	"+8Qtz5YmttyrxXgUitmFn85Af3ii7M6lucMDxjJjZ6YNXlCwlLsoIg+PDO2AkIfBED+3KCHKnB3FN/mp",
+	"t6W0St5Gbn70YWjeGWhCL0WXeCW96+Hr3PxVJi51MBnaxBNzTVYMzLASjsva5oKWm6Cth1m20qKyqjNG",
+	"wI9+ItbwHdy/Xfh8gMgJs02bs3sgqMZIHs2JeRAQkZsr3GTYCt6WHvLkRRXBMUfufhMCHOBIdAJJA9fN",
+	"WMj11jsVULUNChhMcppDuRq+N6BFckb4+lOozYHjSLehrAymLoIr9TSGzK6Tw20tJh/yz/iUP9rh8Vgp",
+	"pW7CyBaNlF6iwrUpffL9EybN8VnisaFVllMbUcpG5t6v3VcYyyfpNtlr5lDkW8Ubu9HuIKqeGE/g/SEM",
+	"C4Ms0TCtiOd7m4J3UDthV3MZs1/KlSh3ZS1GabL7i35SYaLz6QhH3qfapSyP9EcDlx55bIDMhH923HYZ",
+	"pMQkb8hrRXeNGKAefsAM2Z+ygl28/B/fdriDH5+yYnT1wq+fsYL1aq+E+TZjr5jaKmYPRzjOubF+go9d",
+	"1IofsdJli66p3gvou9cvDzrTtOuFUNn0qFKtf/KVR0aXaOfYSa2YyJqp/CxIgnPzYIMDZsJ2mbl2GTuG",
+	"9xDoDF53fm56kXoZPzxJVhzQq9ILAir1BD0xUelBoQFTc0hC/9NrZMldI1N6hlhdZJSK4jemcVql2TVO",
+	"n2MbbHJunWlLZylUq59z8k6BVxcFlOyvNj/W3wH/11aSj6nThRHXgudcpygN9c+tAB6K7pPQmHUDpF55",
+	"h/LL8R7T2DYfTxuHJ1D+BV46cjv0Weo57DmUQaFZfmQFe00Qd0WxoAPb2nVzfDQNDZUC3fLaFVmTglfm",
+	"sje8drHODqVPRE8ftZCvJkHq4mwajYePapLrO5AgLFhUc7r1mxN06+9zvAPn7bQyJLEMj9S1MJRu6WBy",
+	"+D70eL9cPOg6XncndsoVovUdtop4UyLWkNFN+K/hOHVkiy+kaH7L8Gwk4rfw6ArlzO4UEUuuC1vrI5b3",
+	"Rq7fQIc9WxqaTfa01jfCFDDvDIrrYdg9tRwUdemq8tF45FgiKgaLsadtBA181E74Lvv3oh97FCjA61Kr",
+	"YjD7w3Id4pcFUlfRJVLbs3t8O3n/6NW+nUtxLWQS8ILNJ6u/EruPw3CXiAKd4BPdbvOWU0ro0DmZR/n+",
+	"b7xjLzluDgWdPaW8wDaCamdf4XDmXGXTWWxlaTRHB/m+1ouYqLO9ZQUdl7rdmHP6Tz+MsC+jzm/hQcSy",
+	"FRG3vAnGDTR6gUb87ENaiLsiGqkov1IrxyXWOkxq+ilAUtQNMqreEeXsoyLf76ObeeQlO78/5RYJKPIS",
+	"i2Nq4e/pljkjxMNHHUJ68lquBDzl0jcM5nb6u9ix0Ozs3mSKXFLWgXcdmvlqitPuE80ybejLGr/E+WwZ",
+	"8VFMomTD/yyrhBNmC6S4gVCottyg7M7XImR0Re8ojPYdTTQYPSS5G2Ym9klBbMNLGogSh9XcrIXp3Dov",
+	"KeVk52215RLPSR+hOU7XA79hSqij88x+411je96FfopRttlEOtsAxpXYnZMbHv5+ipNpNmltBjBo/CFB",
+	"ulMG3DiJ8h56vRp4MCI9DailB/8ePRkBPq9CONKTcZoe+tDl4TrwOLRWTNd5eIaEeG8TT9x+bYe64U43",
+	"N+896y4P8Z7N6y2R0dOGoMqSIajsX5/+ixmxEgaNVZ98ghN88snSN/3X0+FnILxPPkkrLR/KcZf2yI/h",
+	"501STC9kJcNSgKFZrBi3ogAjuOS0goXCj8PUFKpimI8MRRaOkfqi1o1ItqYNjpCOeaeNWLc1p5QMUilh",
+	"Bp0OSShKKgF3q7z6C//79lal2kb/odbRdqQqwfcHonDDjTswa8io5C+lcy0x+ufUEfvUq/2IlKLxLiN+",
+	"hSP0IwbN/F3GfOvHOKD69loZyrNPCjoZ0oWhUEwYHlJTZ28PVblDdFOXdkT8DCo7bCIUJjF5i8lAyyuh",
+	"qOA2cD6c0WkmlG2NVxMCrDgegOKH0fEFb/smp5beLubK2ZqSNMI+8tinh8PEttQVRI8KkKPni0VCe3h2",
+	"znh0lJgE2zcMXh0Y07fvOYZkbLaimjeqdZiKBiTniNA/M3xfxbE7hJkU6H0u+9Ftje3ZoxfPHzO5Gn+M",
+	"ks1Hj6/9y44LSR4GEbRMwDJOeX8MFCshcmkXRglg2Epk1MP7yhWurvtKhdhqHCq7F8oDE8R97U1tvrnP",
+	"HvKRZoUbADk0ckdDxSU6ji5nt1ysjW7TGbbWVDZmlN4QHwYodNGjnqI7ziH6o5JrYd0Z5BJWzF++0yLF",
+	"Q2wy2Rc/HtRYZwhY59hC8pBPGhPNGdtOB2xb+uQxOMzDY/iUKkrLBcolhbtNJSJ7MZFZWOMz7WBJg4jf",
+	"DMKT7yP9mFTOcGK+hV6tkmU+vsXfe79kE3iyEVOsH8CVr8TOiFNll79jZwrDmOU89XVXMvQ0xlOLXAX7",
+	"+jZxfD57WvQn6Iy9hN5MqJU2pbBs26L1T9xium9vhIulVMyBjYCh0Ezpr9UvwmhUJCimvefr+Ix1m43Z",
+	"cHiJ8rz12Z4Ahq6eR6esfPQGpZklAfmY3qnTo8Za5SSJP7CN30e7iB4gAPQ/N7JOUEGj4buN4VgypZkm",
+	"97OoJaWf63O5E8w+fdeAkB72mMc1jaq0+R8oAb0uXkYOv72WotxwtRaHF46b0uRBB3xaUDVxzO+zwN0M",
+	"nL9tpIzSmTQ+ypfxhQcKLHPbadQeFuCG77ZCuRM53yvq7d3f0Kdt/gVgMi+A0Hte7jcCND0u7QeIH7uq",
+	"Ht1TC3WnxG2jNS4z754uFwpFP8SyK50gEBFWLRp5I3Nm0J36J53vhCev94CJiPV0J3a6FtOa8bdyK/p3",
+	"CQlyKRFIHnQl0vMy/a4lzy5i2X+aWU43zDxV2AxVUN95mjjY9huRbWT8neSjPeEURK5JmDOx2OtR2Cdg",
+	"wcigEBg9TEYImLJn7HmXzBOa+fSCfYZP0meNw4Uoc2VXhEUa3w4TpJIOGyOKMBoGT02CEfgGJBtBm6mU",
+	"5JvwcrXuAhESiqDQ7BacIrt2KWVMaLkyv/QNp3qg0Kxp6lGd5EQr6xo0GOUwHTmWclBM0zIXywUsC/4B",
+	"sOHflfllgf569QLoplktfjzsAHuaKHCeRCqyxfDVOhAku5PYk9YeDehsDXCfYAktRn27o9WTsVKdqh71",
+	"P3zJ6/rtraKZEnknZkKzeEO5J176kKzQGNm4j58LWivPHWLrDBjgrY0DnyI4/2TZuHgjnpJE+caZcK29",
+	"HHosAsS0yc06u25UWE3FUFkybtYtpYl9gPXtWUG2ZDl5yffl4KYiW+mzQ1HUDqVcliufTztXOO7AYrq8",
+	"8TKjLHvRsM8WmKH0JTx+RONLOGlVlF1oJ5OWoSu3Zu8oJPLd4gzyvsIzwAheEYM10olUWVcxzHfFLbsR",
+	"Nfq2e4ouOuxGoXln7MIvN2SUsUjZ5NOeKuT8ey0UzBvbZjCW40okVQ2R9Btg6EuYqXfwISSVXCntfkd4",
+	"OrJQ8LC6XhzI3DRhF1gtYN9/bjEDBZOKSghndLTaCLlWoAXPMcQVDxeBHaMreR0MuZRPCx8j3k5uiU4c",
+	"P42JouWFBmPSIs0VkPZ3zg08wV67vbBW5K/FriiA7YPfrV9lVGLvsCUGNvMqWiESdhBl73N9J9R1vnMx",
+	"59EAA66xr+8gwj9R/jm+C8dD75PMIivnrGRmsd5bDQsn/mREUcbZFUnAx1JwbZ8w4J26YKCf84/Vbig4",
+	"EL1unEYPdSzOEp26uo120m085ZF1MWnxM9Jhtt7su3c/3PKJlIEw3UG+OK108F4cf5WpSxjjOJjKfCHC",
+	"OxYcpRlnNjYXZQ8WMV5VoxJtsd8XMZmuxBjtti/QiMTCbzK1EGexuZrF5sz4g0y5N+F1mAs4jF6TlJP4",
+	"Juw49UhFT+eThPSFa6dTH3L4O+eBg0gjvJDvShxh1hnymEmOwMlz9KJPjWC94NWFrjLPQmiY7ncT9Dj1",
+	"KnCzYJsL1uOY0uBmontty5t7LVa9l3lEEM8kTMh6HPT5p/3FHMaLajbhAL1rA+O9MTIhMR659DB6GoP4",
+	"dZx1mMfV4exGt3VFBeK2+nrwxEwgh66fXizsy/2SFwds6SDJkY1miPcaammAzFXfQHyM19P2hJUfLuwq",
+	"JcxM6AjjnPqkXE7vjSnJczwEaHcuNzFekuHZ80pS7rWkbzch2TeUfqAOwRef93Wah5a3YHjzFWd5dEEv",
+	"/TbzeqgtoIGDJhrafBnGDivqUBrdZ/tT+qWqdndbuofnedPoLLPzasVjeRz1IiZH0+S5m9JqmCAoY5NR",
+	"jQ8l/oabq8Ed6A+rH0CtKTPHYFS1Tl0lQCM1VYwYgpANmrGi9paMV+1lLUvYH/ID7+wKPgigYq+5qvSW",
+	"fRWSaT76/vVXjxnl2ghEFgrQCNZB8ttWfcsuvDErv/I3UQBNt3xJKZWMWEvrTEJv+eCrwtT8+/yNoNHK",
+	"ut7piOzVeL6nCaOk54LZmHgB+1dUsm6zhAytrqphXQTbXmJJaamoeMolZnnRqykIdmbqPQ4O0Kampb7k",
+	"97DSww4MLtefmMEszej8fGwEtOclEayr89zTG26OZZ++G/FPP9Np4iFJh33kRFSvBfAZ6laOLv47CVnR",
+	"FBS6JQxerW4obA09Sv0liLre4Bga2RH2epwOx0vuRSdn4SRYCVlOJS5L6UvCjJFk5JNagWwFjua98LNq",
+	"VWVHW9jFD8+ZX2dlHy/6hDazltycUHCoJDCIox1CgnZLOo1RCLW1upS9DR7L01Mh+m+htBYlhR4XXuy3",
+	"EhRcsiJCGwcxr2Vp5Xrfoz8F/MvQF4Jv29rJE8f5JvQlC3b6OpRrfxWqipuKierp559/+udhdoSPiF1N",
+	"Nym1qtovyysZuZPlUI7tVncAEwuoPFvrKcvK2trMujc9dLa1JbscOM8dZyJDQPLR8H6xwT8EPCgjUtcg",
+	"ttdO9j8t4TfwNu1ZZ1TpBLP8ceb51djpD0OOIjvfA0eke8Iu7uSXMToeOcbRH5KP4WzE7JHo4VCW+E3E",
+	"SRI512iJpHYFeglxmLjXTS1Atut5YDazTkANXflhzjdyPTk68XjpXW8vPVQAi/V1EfQqlrhQQdBDdYJz",
+	"8GR/3sRwJU6h2xhhAaIk0G5jkslH5vLf95nHE+WvjsLtm9GeDnec9i0r4TZXv1FOmzka+DgSO6T9sOZF",
+	"5lx6BnZIXF6Xn2qclyovPUdVGeZIP1vvYPh+PjzJiQdn7OSW806zTfBPe7treukqStrFXhD5906NKMcq",
+	"SmHjU16T7dfXaRvu131E6afrxl1Q1Tat4ppjcca8SQjk/eTnPTaPLkJpRKlN5VPpCm5+i1y6MSBdRtmD",
+	"oBnml89mRyJ8NGSshFlR37/qJ8flc1RXYl5d6VgZpHeMKhODpI87YUNivJBFL5XNsclmyr7ndNKz+O5y",
+	"Dw5WS9t9EmphDJPfaHMPC8qlnnlO9d26LCE4XZQk9M45EUP+ww51UXkYXPZUjnqPcUIrTXlPlOMlAk7J",
+	"ZhcXfuqFT6+52DjX2Gfn5zc3N2cBrrNSb8/XGOtYON2Wm/Mw0PvlaAfCeL5WOUjf9c7J0rKLVy9w+dLV",
+	"AsOmkINHdW2eLZ6ePaGjIhRv5OLZ4rOzJ2ef0k25QWZ0TtWG4M81BTsBq0K+86LC5BRXIq5XtFxQEjFL",
+	"vOzpkydhG7y2KGJc5/+2dEAP85uIp3n/frIRj9Cq/ph2CCveT8nlO3Wl9I1ifzNGk5xk2+2Wmx0cDuFa",
+	"oyx7+uQJsAJaN6UE4vBa+2FBcfmLH6Hf+fXT88hbdPTL+a/+r0JW7/d8BndVW0RuJHvbB1+c2VaJWN7D",
+	"+xw0Q8X13jaxeBK1TcMU/Xr+69CZ5f2Bzc595E5oO14I/v/812Atej/z6dwnn5nrnlnfcGfgf+fiWiiX",
+	"/vH8Zvx7fvHJz+eBOdmDG57/Gv4sPFN+f5e+58ALcXa0KRngMItnP/w6YnHiloM3EnK3xfsfu5PVMUd/",
+	"wt4vu19qra/aJv7FCm7KzeAXZwS4Dv74/v8MAJXsADSU8wAA",
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file.
+func GetSwagger() (*openapi3.Swagger, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error loading Swagger: %s", err)
+	}
+	return swagger, nil
+}
@@ -414,6 +414,46 @@ type BlockUpgradeVote struct {
 	UpgradePropose *string `json:"upgrade-propose,omitempty"`
 }
 
+// DAO defines model for DAO.
+type DAO struct {
+
+	// The address that created the application.
+	Creator string `json:"creator"`
+
+	// Whether or not this application is currently deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// Round when this application was deleted.
+	DeletedAtRound *uint64 `json:"deleted-at-round,omitempty"`
+
+	// \[deposit\] governance tokens a proposal has to deposit.
+	Deposit *uint64 `json:"deposit,omitempty"`
+
+	// \[gov_token_id\] governance token asset ID.
+	GovTokenId *uint64 `json:"gov-token-id,omitempty"`
+
+	// The SigmaDAO application ID.
+	Id uint64 `json:"id"`
+
+	// \[max_duration\] maximum voting duration in seconds.
+	MaxDuration *uint64 `json:"max-duration,omitempty"`
+
+	// \[min_duration\] minimum voting duration in seconds.
+	MinDuration *uint64 `json:"min-duration,omitempty"`
+
+	// \[min_support\] minimum number of yes votes for a proposal to pass.
+	MinSupport *uint64 `json:"min-support,omitempty"`
+
+	// \[dao_name\] name of the DAO.
+	Name *string `json:"name,omitempty"`
+
+	// \[dao_url\] URL of the DAO.
+	Url *string `json:"url,omitempty"`
+
+	// Label of the SigmaDAO program version the application matches.
+	Version *string `json:"version,omitempty"`
+}
+
 // EvalDelta defines model for EvalDelta.
 type EvalDelta struct {
 
@@ -514,6 +554,94 @@ type ParticipationUpdates struct {
 	ExpiredParticipationAccounts *[]string `json:"expired-participation-accounts,omitempty"`
 }
 
+// Proposal defines model for Proposal.
+type Proposal struct {
+
+	// \[abstain\] abstain votes.
+	Abstain uint64 `json:"abstain"`
+
+	// The proposal account address.
+	Address string `json:"address"`
+
+	// \[amount\] amount of a transfer.
+	Amount *uint64 `json:"amount,omitempty"`
+
+	// The SigmaDAO application ID.
+	AppId uint64 `json:"app-id"`
+
+	// \[asa_id\] asset ID of an asset transfer.
+	AsaId *uint64 `json:"asa-id,omitempty"`
+
+	// Round when the proposal was closed or its DAO deleted.
+	ClosedAtRound *uint64 `json:"closed-at-round,omitempty"`
+
+	// Whether or not the proposal is closed or its DAO deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// \[execute_before\] unix timestamp before which the proposal has to be executed.
+	ExecuteBefore *uint64 `json:"execute-before,omitempty"`
+
+	// \[executed\] whether or not the proposal has been executed.
+	Executed bool `json:"executed"`
+
+	// Description of the mismatch between the execute payout and the proposal terms.
+	ExecutionDetails *string `json:"execution-details,omitempty"`
+
+	// Whether the execute payout matched the proposal terms.
+	ExecutionResult *string `json:"execution-result,omitempty"`
+
+	// Round of the execute call.
+	ExecutionRound *uint64 `json:"execution-round,omitempty"`
+
+	// \[from\] account paying a transfer.
+	From *string `json:"from,omitempty"`
+
+	// \[hash_algo\] algorithm of url-hash.
+	HashAlgo *string `json:"hash-algo,omitempty"`
+
+	// \[msg\] message.
+	Msg *[]byte `json:"msg,omitempty"`
+
+	// \[name\] name of the proposal.
+	Name *string `json:"name,omitempty"`
+
+	// \[no\] no votes.
+	No uint64 `json:"no"`
+
+	// \[id\] proposal ID.
+	ProposalId *uint64 `json:"proposal-id,omitempty"`
+
+	// \[recipient\] receiver of a transfer.
+	Recipient *string `json:"recipient,omitempty"`
+
+	// Round of the governance token holder snapshot, taken when voting started.
+	SnapshotRound *uint64 `json:"snapshot-round,omitempty"`
+
+	// Lifecycle status of the proposal as of the current round.
+	Status *string `json:"status,omitempty"`
+
+	// \[type\] proposal type.
+	// * 1 - ALGO transfer
+	// * 2 - asset transfer
+	// * 3 - message
+	Type *uint64 `json:"type,omitempty"`
+
+	// \[url\] URL of the proposal.
+	Url *string `json:"url,omitempty"`
+
+	// \[url_hash\] hash of the document at the URL.
+	UrlHash *[]byte `json:"url-hash,omitempty"`
+
+	// \[voting_end\] unix timestamp at which voting ends.
+	VotingEnd *uint64 `json:"voting-end,omitempty"`
+
+	// \[voting_start\] unix timestamp at which voting starts.
+	VotingStart *uint64 `json:"voting-start,omitempty"`
+
+	// \[yes\] yes votes.
+	Yes uint64 `json:"yes"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
@@ -985,6 +1113,34 @@ type TransactionStateProof struct {
 	StateProofType *uint64 `json:"state-proof-type,omitempty"`
 }
 
+// Vote defines model for Vote.
+type Vote struct {
+
+	// The SigmaDAO application ID.
+	AppId uint64 `json:"app-id"`
+
+	// Round when the vote record was cleared or its DAO deleted.
+	ClosedAtRound *uint64 `json:"closed-at-round,omitempty"`
+
+	// Whether or not the vote record has been cleared or its DAO deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// The vote option, not set if the vote was cast before it could be indexed.
+	Option *string `json:"option,omitempty"`
+
+	// The proposal account address.
+	Proposal string `json:"proposal"`
+
+	// Round at which the vote was recorded.
+	Round uint64 `json:"round"`
+
+	// The voter account address.
+	Voter string `json:"voter"`
+
+	// Deposit of the voter when voting.
+	Weight *uint64 `json:"weight,omitempty"`
+}
+
 // AccountId defines model for account-id.
 type AccountId string
 
@@ -1042,6 +1198,9 @@ type Next string
 // NotePrefix defines model for note-prefix.
 type NotePrefix string
 
+// ProposalAddress defines model for proposal-address.
+type ProposalAddress string
+
 // RekeyTo defines model for rekey-to.
 type RekeyTo bool
 
@@ -1179,6 +1338,27 @@ type AssetsResponse struct {
 // BlockResponse defines model for BlockResponse.
 type BlockResponse Block
 
+// DAOResponse defines model for DAOResponse.
+type DAOResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Configuration of a SigmaDAO application, decoded from its global state.
+	Dao DAO `json:"dao"`
+}
+
+// DAOsResponse defines model for DAOsResponse.
+type DAOsResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+	Daos         []DAO  `json:"daos"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Data    *map[string]interface{} `json:"data,omitempty"`
@@ -1188,6 +1368,27 @@ type ErrorResponse struct {
 // HealthCheckResponse defines model for HealthCheckResponse.
 type HealthCheckResponse HealthCheck
 
+// ProposalResponse defines model for ProposalResponse.
+type ProposalResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// A SigmaDAO proposal, decoded from the local state of the proposal account.
+	Proposal Proposal `json:"proposal"`
+}
+
+// ProposalsResponse defines model for ProposalsResponse.
+type ProposalsResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string    `json:"next-token,omitempty"`
+	Proposals []Proposal `json:"proposals"`
+}
+
 // TransactionResponse defines model for TransactionResponse.
 type TransactionResponse struct {
 
@@ -1212,3 +1413,14 @@ type TransactionsResponse struct {
 	NextToken    *string       `json:"next-token,omitempty"`
 	Transactions []Transaction `json:"transactions"`
 }
+
+// VotesResponse defines model for VotesResponse.
+type VotesResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+	Votes     []Vote  `json:"votes"`
+}
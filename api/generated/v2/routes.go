@@ -0,0 +1,2172 @@
+// Package generated provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/algorand/oapi-codegen DO NOT EDIT.
+package generated
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"github.com/algorand/oapi-codegen/pkg/runtime"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /v2/accounts)
+	SearchForAccounts(ctx echo.Context, params SearchForAccountsParams) error
+
+	// (GET /v2/accounts/{account-id})
+	LookupAccountByID(ctx echo.Context, accountId string, params LookupAccountByIDParams) error
+
+	// (GET /v2/accounts/{account-id}/apps-local-state)
+	LookupAccountAppLocalStates(ctx echo.Context, accountId string, params LookupAccountAppLocalStatesParams) error
+
+	// (GET /v2/accounts/{account-id}/assets)
+	LookupAccountAssets(ctx echo.Context, accountId string, params LookupAccountAssetsParams) error
+
+	// (GET /v2/accounts/{account-id}/created-applications)
+	LookupAccountCreatedApplications(ctx echo.Context, accountId string, params LookupAccountCreatedApplicationsParams) error
+
+	// (GET /v2/accounts/{account-id}/created-assets)
+	LookupAccountCreatedAssets(ctx echo.Context, accountId string, params LookupAccountCreatedAssetsParams) error
+
+	// (GET /v2/accounts/{account-id}/daos)
+	LookupAccountDAOs(ctx echo.Context, accountId string, params LookupAccountDAOsParams) error
+
+	// (GET /v2/accounts/{account-id}/transactions)
+	LookupAccountTransactions(ctx echo.Context, accountId string, params LookupAccountTransactionsParams) error
+
+	// (GET /v2/applications)
+	SearchForApplications(ctx echo.Context, params SearchForApplicationsParams) error
+
+	// (GET /v2/applications/{application-id})
+	LookupApplicationByID(ctx echo.Context, applicationId uint64, params LookupApplicationByIDParams) error
+
+	// (GET /v2/applications/{application-id}/logs)
+	LookupApplicationLogsByID(ctx echo.Context, applicationId uint64, params LookupApplicationLogsByIDParams) error
+
+	// (GET /v2/assets)
+	SearchForAssets(ctx echo.Context, params SearchForAssetsParams) error
+
+	// (GET /v2/assets/{asset-id})
+	LookupAssetByID(ctx echo.Context, assetId uint64, params LookupAssetByIDParams) error
+
+	// (GET /v2/assets/{asset-id}/balances)
+	LookupAssetBalances(ctx echo.Context, assetId uint64, params LookupAssetBalancesParams) error
+
+	// (GET /v2/assets/{asset-id}/transactions)
+	LookupAssetTransactions(ctx echo.Context, assetId uint64, params LookupAssetTransactionsParams) error
+
+	// (GET /v2/daos)
+	SearchForDAOs(ctx echo.Context, params SearchForDAOsParams) error
+
+	// (GET /v2/daos/{application-id})
+	LookupDAOByID(ctx echo.Context, applicationId uint64, params LookupDAOByIDParams) error
+
+	// (GET /v2/daos/{application-id}/proposals)
+	LookupDAOProposals(ctx echo.Context, applicationId uint64, params LookupDAOProposalsParams) error
+
+	// (GET /v2/daos/{application-id}/proposals/{proposal-address})
+	LookupDAOProposalByAddress(ctx echo.Context, applicationId uint64, proposalAddress string, params LookupDAOProposalByAddressParams) error
+
+	// (GET /v2/daos/{application-id}/proposals/{proposal-address}/votes)
+	LookupProposalVotes(ctx echo.Context, applicationId uint64, proposalAddress string, params LookupProposalVotesParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// SearchForAccounts converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForAccounts(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                true,
+		"asset-id":              true,
+		"limit":                 true,
+		"next":                  true,
+		"currency-greater-than": true,
+		"include-all":           true,
+		"exclude":               true,
+		"currency-less-than":    true,
+		"auth-addr":             true,
+		"round":                 true,
+		"application-id":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForAccountsParams
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-greater-than" -------------
+	if paramValue := ctx.QueryParam("currency-greater-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-greater-than", ctx.QueryParams(), &params.CurrencyGreaterThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-greater-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "exclude" -------------
+	if paramValue := ctx.QueryParam("exclude"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "exclude", ctx.QueryParams(), &params.Exclude)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter exclude: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-less-than" -------------
+	if paramValue := ctx.QueryParam("currency-less-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-less-than", ctx.QueryParams(), &params.CurrencyLessThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-less-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "auth-addr" -------------
+	if paramValue := ctx.QueryParam("auth-addr"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "auth-addr", ctx.QueryParams(), &params.AuthAddr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter auth-addr: %s", err))
+	}
+
+	// ------------- Optional query parameter "round" -------------
+	if paramValue := ctx.QueryParam("round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForAccounts(ctx, params)
+	return err
+}
+
+// LookupAccountByID converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"round":       true,
+		"include-all": true,
+		"exclude":     true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountByIDParams
+	// ------------- Optional query parameter "round" -------------
+	if paramValue := ctx.QueryParam("round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "exclude" -------------
+	if paramValue := ctx.QueryParam("exclude"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "exclude", ctx.QueryParams(), &params.Exclude)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter exclude: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountByID(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountAppLocalStates converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountAppLocalStates(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"application-id": true,
+		"include-all":    true,
+		"limit":          true,
+		"next":           true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountAppLocalStatesParams
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountAppLocalStates(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountAssets converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountAssets(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"asset-id":    true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountAssetsParams
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountAssets(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountCreatedApplications converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountCreatedApplications(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"application-id": true,
+		"include-all":    true,
+		"limit":          true,
+		"next":           true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountCreatedApplicationsParams
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountCreatedApplications(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountCreatedAssets converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountCreatedAssets(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"asset-id":    true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountCreatedAssetsParams
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountCreatedAssets(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountDAOs converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountDAOs(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountDAOsParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountDAOs(ctx, accountId, params)
+	return err
+}
+
+// LookupAccountTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAccountTransactions(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                true,
+		"limit":                 true,
+		"next":                  true,
+		"note-prefix":           true,
+		"tx-type":               true,
+		"sig-type":              true,
+		"txid":                  true,
+		"round":                 true,
+		"min-round":             true,
+		"max-round":             true,
+		"asset-id":              true,
+		"before-time":           true,
+		"after-time":            true,
+		"currency-greater-than": true,
+		"currency-less-than":    true,
+		"rekey-to":              true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "account-id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account-id", ctx.Param("account-id"), &accountId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter account-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAccountTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "note-prefix" -------------
+	if paramValue := ctx.QueryParam("note-prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "note-prefix", ctx.QueryParams(), &params.NotePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter note-prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "tx-type" -------------
+	if paramValue := ctx.QueryParam("tx-type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tx-type", ctx.QueryParams(), &params.TxType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tx-type: %s", err))
+	}
+
+	// ------------- Optional query parameter "sig-type" -------------
+	if paramValue := ctx.QueryParam("sig-type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sig-type", ctx.QueryParams(), &params.SigType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sig-type: %s", err))
+	}
+
+	// ------------- Optional query parameter "txid" -------------
+	if paramValue := ctx.QueryParam("txid"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid: %s", err))
+	}
+
+	// ------------- Optional query parameter "round" -------------
+	if paramValue := ctx.QueryParam("round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+	if paramValue := ctx.QueryParam("min-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+	if paramValue := ctx.QueryParam("max-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-time" -------------
+	if paramValue := ctx.QueryParam("before-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "before-time", ctx.QueryParams(), &params.BeforeTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "after-time" -------------
+	if paramValue := ctx.QueryParam("after-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "after-time", ctx.QueryParams(), &params.AfterTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-greater-than" -------------
+	if paramValue := ctx.QueryParam("currency-greater-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-greater-than", ctx.QueryParams(), &params.CurrencyGreaterThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-greater-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-less-than" -------------
+	if paramValue := ctx.QueryParam("currency-less-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-less-than", ctx.QueryParams(), &params.CurrencyLessThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-less-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "rekey-to" -------------
+	if paramValue := ctx.QueryParam("rekey-to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "rekey-to", ctx.QueryParams(), &params.RekeyTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rekey-to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAccountTransactions(ctx, accountId, params)
+	return err
+}
+
+// SearchForApplications converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForApplications(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"application-id": true,
+		"creator":        true,
+		"include-all":    true,
+		"limit":          true,
+		"next":           true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForApplicationsParams
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "creator" -------------
+	if paramValue := ctx.QueryParam("creator"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "creator", ctx.QueryParams(), &params.Creator)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter creator: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForApplications(ctx, params)
+	return err
+}
+
+// LookupApplicationByID converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupApplicationByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupApplicationByIDParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupApplicationByID(ctx, applicationId, params)
+	return err
+}
+
+// LookupApplicationLogsByID converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupApplicationLogsByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"limit":          true,
+		"next":           true,
+		"txid":           true,
+		"min-round":      true,
+		"max-round":      true,
+		"sender-address": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupApplicationLogsByIDParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "txid" -------------
+	if paramValue := ctx.QueryParam("txid"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+	if paramValue := ctx.QueryParam("min-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+	if paramValue := ctx.QueryParam("max-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "sender-address" -------------
+	if paramValue := ctx.QueryParam("sender-address"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sender-address", ctx.QueryParams(), &params.SenderAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sender-address: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupApplicationLogsByID(ctx, applicationId, params)
+	return err
+}
+
+// SearchForAssets converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForAssets(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+		"creator":     true,
+		"name":        true,
+		"unit":        true,
+		"asset-id":    true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForAssetsParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "creator" -------------
+	if paramValue := ctx.QueryParam("creator"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "creator", ctx.QueryParams(), &params.Creator)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter creator: %s", err))
+	}
+
+	// ------------- Optional query parameter "name" -------------
+	if paramValue := ctx.QueryParam("name"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+	if paramValue := ctx.QueryParam("unit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", ctx.QueryParams(), &params.Unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unit: %s", err))
+	}
+
+	// ------------- Optional query parameter "asset-id" -------------
+	if paramValue := ctx.QueryParam("asset-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "asset-id", ctx.QueryParams(), &params.AssetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForAssets(ctx, params)
+	return err
+}
+
+// LookupAssetByID converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAssetByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "asset-id", ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAssetByIDParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAssetByID(ctx, assetId, params)
+	return err
+}
+
+// LookupAssetBalances converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAssetBalances(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                true,
+		"include-all":           true,
+		"limit":                 true,
+		"next":                  true,
+		"currency-greater-than": true,
+		"currency-less-than":    true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "asset-id", ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAssetBalancesParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-greater-than" -------------
+	if paramValue := ctx.QueryParam("currency-greater-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-greater-than", ctx.QueryParams(), &params.CurrencyGreaterThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-greater-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-less-than" -------------
+	if paramValue := ctx.QueryParam("currency-less-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-less-than", ctx.QueryParams(), &params.CurrencyLessThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-less-than: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAssetBalances(ctx, assetId, params)
+	return err
+}
+
+// LookupAssetTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupAssetTransactions(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":                true,
+		"limit":                 true,
+		"next":                  true,
+		"note-prefix":           true,
+		"tx-type":               true,
+		"sig-type":              true,
+		"txid":                  true,
+		"round":                 true,
+		"min-round":             true,
+		"max-round":             true,
+		"before-time":           true,
+		"after-time":            true,
+		"currency-greater-than": true,
+		"currency-less-than":    true,
+		"address":               true,
+		"address-role":          true,
+		"exclude-close-to":      true,
+		"rekey-to":              true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "asset-id" -------------
+	var assetId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "asset-id", ctx.Param("asset-id"), &assetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter asset-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupAssetTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// ------------- Optional query parameter "note-prefix" -------------
+	if paramValue := ctx.QueryParam("note-prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "note-prefix", ctx.QueryParams(), &params.NotePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter note-prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "tx-type" -------------
+	if paramValue := ctx.QueryParam("tx-type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "tx-type", ctx.QueryParams(), &params.TxType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tx-type: %s", err))
+	}
+
+	// ------------- Optional query parameter "sig-type" -------------
+	if paramValue := ctx.QueryParam("sig-type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sig-type", ctx.QueryParams(), &params.SigType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sig-type: %s", err))
+	}
+
+	// ------------- Optional query parameter "txid" -------------
+	if paramValue := ctx.QueryParam("txid"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", ctx.QueryParams(), &params.Txid)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txid: %s", err))
+	}
+
+	// ------------- Optional query parameter "round" -------------
+	if paramValue := ctx.QueryParam("round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "round", ctx.QueryParams(), &params.Round)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter round: %s", err))
+	}
+
+	// ------------- Optional query parameter "min-round" -------------
+	if paramValue := ctx.QueryParam("min-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "min-round", ctx.QueryParams(), &params.MinRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "max-round" -------------
+	if paramValue := ctx.QueryParam("max-round"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max-round", ctx.QueryParams(), &params.MaxRound)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max-round: %s", err))
+	}
+
+	// ------------- Optional query parameter "before-time" -------------
+	if paramValue := ctx.QueryParam("before-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "before-time", ctx.QueryParams(), &params.BeforeTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter before-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "after-time" -------------
+	if paramValue := ctx.QueryParam("after-time"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "after-time", ctx.QueryParams(), &params.AfterTime)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter after-time: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-greater-than" -------------
+	if paramValue := ctx.QueryParam("currency-greater-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-greater-than", ctx.QueryParams(), &params.CurrencyGreaterThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-greater-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency-less-than" -------------
+	if paramValue := ctx.QueryParam("currency-less-than"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency-less-than", ctx.QueryParams(), &params.CurrencyLessThan)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency-less-than: %s", err))
+	}
+
+	// ------------- Optional query parameter "address" -------------
+	if paramValue := ctx.QueryParam("address"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "address", ctx.QueryParams(), &params.Address)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address: %s", err))
+	}
+
+	// ------------- Optional query parameter "address-role" -------------
+	if paramValue := ctx.QueryParam("address-role"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "address-role", ctx.QueryParams(), &params.AddressRole)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter address-role: %s", err))
+	}
+
+	// ------------- Optional query parameter "exclude-close-to" -------------
+	if paramValue := ctx.QueryParam("exclude-close-to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "exclude-close-to", ctx.QueryParams(), &params.ExcludeCloseTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter exclude-close-to: %s", err))
+	}
+
+	// ------------- Optional query parameter "rekey-to" -------------
+	if paramValue := ctx.QueryParam("rekey-to"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "rekey-to", ctx.QueryParams(), &params.RekeyTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rekey-to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupAssetTransactions(ctx, assetId, params)
+	return err
+}
+
+// SearchForDAOs converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchForDAOs(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":         true,
+		"application-id": true,
+		"creator":        true,
+		"gov-token-id":   true,
+		"name":           true,
+		"version":        true,
+		"include-all":    true,
+		"limit":          true,
+		"next":           true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchForDAOsParams
+	// ------------- Optional query parameter "application-id" -------------
+	if paramValue := ctx.QueryParam("application-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "application-id", ctx.QueryParams(), &params.ApplicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "creator" -------------
+	if paramValue := ctx.QueryParam("creator"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "creator", ctx.QueryParams(), &params.Creator)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter creator: %s", err))
+	}
+
+	// ------------- Optional query parameter "gov-token-id" -------------
+	if paramValue := ctx.QueryParam("gov-token-id"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "gov-token-id", ctx.QueryParams(), &params.GovTokenId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gov-token-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "name" -------------
+	if paramValue := ctx.QueryParam("name"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "version" -------------
+	if paramValue := ctx.QueryParam("version"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SearchForDAOs(ctx, params)
+	return err
+}
+
+// LookupDAOByID converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupDAOByID(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupDAOByIDParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupDAOByID(ctx, applicationId, params)
+	return err
+}
+
+// LookupDAOProposals converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupDAOProposals(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"status":      true,
+		"type":        true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupDAOProposalsParams
+	// ------------- Optional query parameter "status" -------------
+	if paramValue := ctx.QueryParam("status"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "type" -------------
+	if paramValue := ctx.QueryParam("type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupDAOProposals(ctx, applicationId, params)
+	return err
+}
+
+// LookupDAOProposalByAddress converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupDAOProposalByAddress(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"include-all": true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Path parameter "proposal-address" -------------
+	var proposalAddress string
+
+	err = runtime.BindStyledParameter("simple", false, "proposal-address", ctx.Param("proposal-address"), &proposalAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter proposal-address: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupDAOProposalByAddressParams
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupDAOProposalByAddress(ctx, applicationId, proposalAddress, params)
+	return err
+}
+
+// LookupProposalVotes converts echo context to params.
+func (w *ServerInterfaceWrapper) LookupProposalVotes(ctx echo.Context) error {
+
+	validQueryParams := map[string]bool{
+		"pretty":      true,
+		"voter":       true,
+		"option":      true,
+		"include-all": true,
+		"limit":       true,
+		"next":        true,
+	}
+
+	// Check for unknown query parameters.
+	for name, _ := range ctx.QueryParams() {
+		if _, ok := validQueryParams[name]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown parameter detected: %s", name))
+		}
+	}
+
+	var err error
+	// ------------- Path parameter "application-id" -------------
+	var applicationId uint64
+
+	err = runtime.BindStyledParameter("simple", false, "application-id", ctx.Param("application-id"), &applicationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter application-id: %s", err))
+	}
+
+	// ------------- Path parameter "proposal-address" -------------
+	var proposalAddress string
+
+	err = runtime.BindStyledParameter("simple", false, "proposal-address", ctx.Param("proposal-address"), &proposalAddress)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter proposal-address: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupProposalVotesParams
+	// ------------- Optional query parameter "voter" -------------
+	if paramValue := ctx.QueryParam("voter"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "voter", ctx.QueryParams(), &params.Voter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter voter: %s", err))
+	}
+
+	// ------------- Optional query parameter "option" -------------
+	if paramValue := ctx.QueryParam("option"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "option", ctx.QueryParams(), &params.Option)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter option: %s", err))
+	}
+
+	// ------------- Optional query parameter "include-all" -------------
+	if paramValue := ctx.QueryParam("include-all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include-all", ctx.QueryParams(), &params.IncludeAll)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter include-all: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "next" -------------
+	if paramValue := ctx.QueryParam("next"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "next", ctx.QueryParams(), &params.Next)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter next: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.LookupProposalVotes(ctx, applicationId, proposalAddress, params)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}, si ServerInterface, m ...echo.MiddlewareFunc) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET("/v2/accounts", wrapper.SearchForAccounts, m...)
+	router.GET("/v2/accounts/:account-id", wrapper.LookupAccountByID, m...)
+	router.GET("/v2/accounts/:account-id/apps-local-state", wrapper.LookupAccountAppLocalStates, m...)
+	router.GET("/v2/accounts/:account-id/assets", wrapper.LookupAccountAssets, m...)
+	router.GET("/v2/accounts/:account-id/created-applications", wrapper.LookupAccountCreatedApplications, m...)
+	router.GET("/v2/accounts/:account-id/created-assets", wrapper.LookupAccountCreatedAssets, m...)
+	router.GET("/v2/accounts/:account-id/daos", wrapper.LookupAccountDAOs, m...)
+	router.GET("/v2/accounts/:account-id/transactions", wrapper.LookupAccountTransactions, m...)
+	router.GET("/v2/applications", wrapper.SearchForApplications, m...)
+	router.GET("/v2/applications/:application-id", wrapper.LookupApplicationByID, m...)
+	router.GET("/v2/applications/:application-id/logs", wrapper.LookupApplicationLogsByID, m...)
+	router.GET("/v2/assets", wrapper.SearchForAssets, m...)
+	router.GET("/v2/assets/:asset-id", wrapper.LookupAssetByID, m...)
+	router.GET("/v2/assets/:asset-id/balances", wrapper.LookupAssetBalances, m...)
+	router.GET("/v2/assets/:asset-id/transactions", wrapper.LookupAssetTransactions, m...)
+	router.GET("/v2/daos", wrapper.SearchForDAOs, m...)
+	router.GET("/v2/daos/:application-id", wrapper.LookupDAOByID, m...)
+	router.GET("/v2/daos/:application-id/proposals", wrapper.LookupDAOProposals, m...)
+	router.GET("/v2/daos/:application-id/proposals/:proposal-address", wrapper.LookupDAOProposalByAddress, m...)
+	router.GET("/v2/daos/:application-id/proposals/:proposal-address/votes", wrapper.LookupProposalVotes, m...)
+
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9bXPcNrIo/FdQ85yqtfMMJcfZpM666tQprb2+ca2zdllOTtWNc7MQiZlBxAEYAJQ0",
+	"yfV/v4VugARJgMMZjWQ5mU+Wh3hpAI1Gv/fvs1yuKymYMHr27PdZRRVdM8MU/I/muayFyXhh/1cwnSte",
+	"GS7F7Jn/RrRRXCxn8xm3v1bUrGbzmaBrNnsW9p/PFPu15ooVs2dG1Ww+0/mKrakd2Gwq29qN9PHjfEaL",
+	"QjGth7O+EeWGcJGXdcGIUVRomttPmlxzsyJmxTVxnQkXRApG5IKYVacxWXBWFvrEA/1rzdQmgNpNngZx",
+	"PrvJaLmUiooiW0i1pmb2bHbm+n3c+tnNkClZsuEan8v1BRfMr4g1C2oOhxhJCraARitqiIXOrtM3NJJo",
+	"RlW+IguptiwTgQjXykS9nj37caaZKJiCk8sZv4I/F4qx31hmqFoyM/tpHju7hWEqM3wdWdord3KK6bo0",
+	"mkBbWOOSXzFBbK8T8l2tDblghAry7uVz8tVXX/2N4DYaVjiES66qnT1cU3MKBTXMf55yqO9ePof5z90C",
+	"p7aiVVXynNp1R6/PWfudvHqRWkx3kAhCcmHYkinceK1Z/K6e2S8j0/iO2yaozSqzaJM+WHfjNcmlWPBl",
+	"rVhhsbHWDO+mrpgouFiSS7ZJHmEzzd3dwAu2kIpNxFJsfFA0Def/pHia10oxkW+ypWIUrs6KiuGWvHNb",
+	"oVeyLguyolewbrqGN8D1JbYvnvMVLWu7RTxX8qxcSk2o28GCLWhdGuInJrUomdYwmsNDwjWplLziBSvm",
+	"hAtyveL5iuRU4xDQjlzzsrTbX2tWpLY5vrotaN50snDttR+woIe7Ge26tuwEu4GLMFz+P27cdS8Kbn+i",
+	"JeGGrTXRdb4iVDuoVrK0l13PSUDJSClzWpKCGkq0kZZCLKRyTzeSj7nr33IjJIcDLMjFpt9SFJ3Rt/ex",
+	"+8NuqlLalS1oqVl8v/zqw02CVYaPJC3LmSO9ejafuSmz5gdaVTqDFWfaUMPCNlVlWwgpWOQlbX6gStGN",
+	"/b82G8suAI2YtaeT5aXULDNyCyfhmQPYsODtD3dsJ76CvF8xApPbD8hTAWYLS27KckOMOwCLEMRzEXPC",
+	"F2Qja3INV6fkl9Dfrcbi9JrYw4cj67A8lm9MIfdgMyKofSFlyagA1F4xWjCVSVFuhvv2LXwk9iNZlHR5",
+	"Qv5nxdxl5hqhQ3DmRDFTK2GxrJT5JSkk00RIYx9AQ7no8546AX8IzxbQHfubWdRLP8Slv5LY3L65sDdF",
+	"80bPScFKZljn/sCv2ii5sb8DFs+JrCy+ytoM77Uo3LD4uX/NAeeTnHa4ki2LLvmam+Fyv6M3fF2viajX",
+	"F/bEFs2jbaQ7GsBTxUgO6HbRIVoVXTJNmH3TOYoJMA/heIaK0XyVJqgI0xYauqY3mZK1KCZww4ZIFXIb",
+	"umI5X3BWkGaUFCztNNvg4WI3eFoePQCHiy3gcDENHMFuIsdqKYv9AgcUnOoJ+d49e/DVyEsmmtcR6Twj",
+	"lWJXXNa66ZSAEaYel0OFNCyrFFvwmyGQ5247LHHDNu5tXjvG0JEAVhBHB+xwSCiTMAUT7sr9XlDNvvnr",
+	"7OO2r5WSldS0zJJStm/hSQVpReKIlD8Yb7qsf1s2XrFLtok+fX1cxpNpNAcrRnzf8QNpZthCnyZeqYXs",
+	"X6XRazTpCkGjDClghFO1Xx19jJ9fp/+EswvnRh1Bdit9DY7hcSy1Fb2Z7g6nNF9mOOLgwvPle8sRLXgJ",
+	"3NIv9p77k621fWK7Z+v5J82XgppasWcfxBf2fyQj54aKgqrC/rLGn76rS8PP+dL+VOJPr+WS5+d8mdoU",
+	"D2tUfwPd1viPHS+urzE3zXJjU5ib9AwVtQ0v2UYxOwfNF/DPzQIQiS7Ub8gBw+tuqkUKgJjO4rWUl3UV",
+	"bmje0eFdbMirFylkgSHHSDvQDl1JoRlg7RkSunfuN/uTpd5MwOMUsDWnv2gJ8mA7tqV/TBnOQp2p/fM/",
+	"FFvMns3+v9NWx3qK3fSpm7AVwU3qVcYLTI0jYUi6HFFDvmZd1Qa5lBh1aK7zjw1s/TnbY5EXv7Dc4AZ1",
+	"wXjE1pXZPLYAO9j14XZLd2SrifvWl4/ucB+RT8mA3xiO/L12MmxFl1zAwufk2koMa3ppqQIV0qyYIvYs",
+	"mDaeY0HyB4O2yl7H9jix52QWuzGRM9W3PtT21F5bzv0cOPdDHHFPAt7hrGMgHU++OfnBxh4SBZYHOvtR",
+	"LfiHDz/SquLFzYcPP3WERy4KdhM/jzs97FIuM6uZ2g9Hly9s1wiCPmQc6loYDoVAh0WeHU7hfl/UQ23X",
+	"gS/bXjT2SFkjt+L2RFVrZv5OSyrygzynF26oySf8HRccgPgWtXbHY/bH3GzlIY7Y7e5BLjIaDyZf4ePh",
+	"xu5wY5K59dEe6kgnHeQ9S4Qw5SE26VMh/hHjD4vxf7dmrL3OcuyoYNQtM784e3MAHLrTsy+o3LbSF2dv",
+	"tnCJOMyOx/Li7I3+DHZn+uWFffqMJKXIGepdD/EfSkl1gFP0Empv7vlszbSmSxY3ZoWr8Q2nLMADDFvP",
+	"7BJAT/4to6VZPV+xOyAXwdhbtvStMz499LvhjWTbVu7XsxX/mgF3xEE/wYOnJg+HErSbPZ28tefYp3ET",
+	"z3Vn4vK+NY489KMN7Djb9jFY1datC4fdf/OOF2OHi9HxbJp6NzpnuuP16Ey44yH/IA9j0fjTnO6V3MVc",
+	"Y7d35/PEKXY6yI/esBtabiNO7/iBcIFWfi6FPRTqfLjR7+KD+CBesAUX4BH27IOwrNXpBdU816e1Zspp",
+	"7k6Wkjwjbkir5f8gZvMeWiSdIOxue0+aqr4oeW7d32Mbjv7DcTtJuZTWSmKkoWXg7xZ4FTsvo9bKO8Qu",
+	"nCCzSCBrkzlv/Eyxa6qKCOi68XGCkaH36Kxz4saGH934xI0fx/iBi2zCTFT2jEQ64knMRdfV157vv6Rx",
+	"Hj/0miB+kVozTf69ptWPXJifSPahfvLkK0bOqqq1NP679Uu2QFvgD2u2hIXDeWbsxiiagTtidPmG0QpO",
+	"f8WIrtf2CKyHJ3Truj8ruVR07Twb+47VIweAcExjz4MVwuLOsdfHeaDBGZ6g/QRHCG3IipVD1+xdzytQ",
+	"fe59XFvUpyNRLx8+/AgBLf5kGhfrJeVC+wdA86Wwl8DFCljHQCvYsOKEvFoQoGrzTncXseZIZUM6uEb3",
+	"fvLerhG81khOhR2wrgpwtOaCULHp+8loZox3Tnpn/dneB05vOzpPOWdfuuX1K2o7XPMCtidMrqkmawmO",
+	"UzkTptw4/+EIasaBqbkw6MjYcaRPEA24NYGHu704IQlJxAgEXtO0qsiylBeO0jQo+qzBUd8nTVTeWgD0",
+	"AQhKVNvZjTmIbwRVkY2ADskwid0Xase71TUcXd7eKLfgSoNvOqPujaDhFdkD85zj/BCU/1kxYMCkAgfy",
+	"Lkppf6VjSN84k85nFVWG57yaZvrG0d92+thBtj3t0cdcLvpv9uBJjT4h2DizTsZRBGT2i8XAWmM8iF2j",
+	"J3R+JmSMYQUnBDxH3VW9KCFEpAkuxDOmCmJX/LLFcgy0+L1gSrQ8lQejuyMh87ai2oexFPOARExicxLI",
+	"a13d4RPcmwB7Q76V23lLdkVT+592Wn0lCks7mO6G9DQuqf5ZGUZWeTd2DKL2rqveX9U7qdp/LbbXNuBk",
+	"QWpxKeS1mM13ckOdz7Shpo4fkhTA+dk7t8TtwMYefRzAf9HBsVmo3iwWJReMZIQ3e2BgD1YQNidzjtFJ",
+	"7f10czArGHxBLA7aASaPEEPuAOxKyhIHJv+S4Y0Vy12AFIwDjaF+bCA2wf9ZXFQHBg94PYzn4SKOjbmn",
+	"C5bD7DyWABjEOl4wJjAsiHAxJ1bAu6IlExgY1RkkHvn3qMNqOzZPP07x8XE1Eq4IXrGd1gQ99lpNyCx6",
+	"oOOc7AjE43xL7Ag0edRwEe1ejcQ3bp06wSuk9uoRLPwWAPRtlY0bvxN5t4qmwxetJe3zNlACyUgc21MY",
+	"Ez2XxI4NNRWNP/Tb/rMd1Ud0WhFscuHk64A9i5FkeytyKTQTuoagYCNzWZ4MFBGalQw4m6zDSWRW6RCV",
+	"YRgQ2HPfLVBSkEd8YUWKxwHrotiSa8M6gbtNFEsbb7SBYNeKGsOUnej/PPrvZz+eZf+bZr89yf72/5/+",
+	"9PtfPz7+YvDj04//9V//t/vTVx//6/F//8cs8WqwrFJSLtKrM5Va2PW9k7KhytCRQMfOMu99BVb/lQGD",
+	"ml3RMuEjaxu91CA8v7RN4wxD57AJxp3zhJYRprWRTQUv6zi+unn/+cJO+69G36Tri0u2AbbQRkeSC2ry",
+	"lf3Qnd62GZm6pFsX/BoX/JoebL3TboNtaidWFl26c3wm96JHa8fIQQQBY8gxPLXklo4QSHjqX7DS0OHG",
+	"h/lQ8HIWtuHJmJZ1cJkKP/aYwBRAkX6VcKToWrpeyelVgAs7BEhzEwSy68GKpgq4100MeshTWX2KG+HO",
+	"BdlwdaEw60aJS7Pu4y2WNxx+6vIOFXMAp7eLngY5pQGCwcVxg21BrkB1PAxENFIxr/7G2xKICpjtQYRr",
+	"G16jNmh/2sF4FgT7EVk3T2lvmjtDQBYRJXDtMVwkCyXXcPOGTGmAnDwhkXdQsH1yerO67FpDfLHEE7K+",
+	"bDWFMlr+k21+sG3hVG1vz5hOvTKtgsLLME5sud3R3M4WEMN8N+JWzMc4mhTa25U5hWzHtrfjDSjlMq5v",
+	"KJfAd8hlG64dosMFs7Ifu2F5bdqkAz19YqPyvF9usq87jYfWBvZ3TAo2zj/ARrmxthzd24ZO3uXJ0cra",
+	"tWmZOWNXisYreeVoPDT3trF7Zsfi1+z9P85ev3Xgg1mFUZU14kxyVdCu+mxWpRg1UiVIrE8qZNUwXpPQ",
+	"f/+dsYvrjoHsGhK69CRmy2k55EIC3Ro/2/G8wWzh+fIdzV/OTotLHLHXsqox17Z6dujcs9DSK8pLr+D2",
+	"0MYfFVxcayPf+V0JB7i1pTcw2GcHfSkGtzt+O7ZQonCGkcwta8wfpInEh7T1qwDh1s6ACLqmG4s3qJ4c",
+	"kiRRrzN76TJd8jxuAhEX2qKEQOu9bUygcUJMtiPatzg+Vs2DsWwzPUHp1gMymCO6mT5kJbV3F9J5EtWC",
+	"/1ozwgsmjP2k4C72rqe9jT6t3d4iUMTGh+nv7lEIggl3EX9cNq1bLa4ZZY/lgVwznNSdmltPc3a3kX9a",
+	"HfGQ/wMgxoWf0BFjAO6LRlPqsajRu1PRsVnv4M8VzjjgMkZ8sdzlc6SiFtxZAfY4ne1ZW72g5bKuJWIv",
+	"dpGjwiRut5KedLZQ8jcW1x6C0vV6OH0wMfaODz5ZCurdm4Q0xHtJKfc4qiYN3m1BaqTnWwPVfzsbY0qb",
+	"0rc9pOSlS7HtwUfS9QRMEHa4f4G/CQio3hpKBV6455AauCMxxa9t0EKf4vjttXUwD/Ua9PqC5pdx7tnC",
+	"dNZ6WXXstkYS39kfh+6e0gkJHLaati5XYMXUmhvDIlzlLThhnHYyD9yyvLZjh9l1mVJLLSPD1OKaCuMz",
+	"PjqC5nprhpYn2+taKm0g92x0lQXL+dpFSQw2v4Ddf99hsgq+5Eb7DNFtpkE3EKkkFwaxqOC6KukG/dja",
+	"rXm1IE/mAVVzp1HwK675RcmgxZfYwvqgwNoa1ZXvYpfHhFlpaP50QvNVLQrFCrNySTC1JI20Apqfxn3i",
+	"gplrxgR5Au2+/Bt5BI4jml+xx3YXHQs6e/bl3yA7I/7nSZzIQ7rgMaJbANX1RD+Ox+A5g2PY59ONGqfC",
+	"mPA9Td9HbhN2nXKXoKV7ErbfpTUVdMni7pjrLTBhXzhNsGL19kVAI8dsEW7i8zNDLX3KVlSv4vwBgkFy",
+	"uV5zs3aOBFquLT616eFwUj8c5tNFCt/A5T+Cl05F4nq9+9UxYYq12KrBl+pfdM262zonVBNdW5hbfZkj",
+	"iCfEZUgsMNtuq9GEvbFzAYNimU17hNbOqLgwIDHXZpH9J8lXVNHcMKVPUuBmF9/8dQjy3yEjJmEil3Z+",
+	"sRvg977vimmmruJbrxJo71kt15c8ElJka25J/WNH5bu3Muk4FJ3WeIreD0oYH3oqv2VHyZLoVnfQjQaU",
+	"+laIJ0YGvCUqNuvZCR93Xtm9Y2at4uhBa3tC37977biMtVSsq/i98IEiHX5FMaM4u2JF8pDsmLc8C1VO",
+	"OoXbQP9pzf6e5QzYMn+XY4IAZmgYbof9OVx2SsSW8vKSsYqL5SlkQkdWHUftM+lLJpjmOv2ALlcWc+xn",
+	"YmSoEYGhyQUrpVjq+8d0D3jCrrxkQJNevdgG9WDgrh8FhnNsjzEOO33v+tjBXALsDOZN77JtZ+F969o7",
+	"OG37T/G8NW7aWxOJvHNt017V9k3EuJznLooGGpKuORfXa/VrtKqYKJBHBFq6olwkXK0ZKxJudAxmPJfK",
+	"wN0g9pdP6xRnFM0vo/q09/aLbpzh0J06cIvTkyM3QNX+1vZ572eLmSL5mmlD11WckwDdOBIbIFx2+5ou",
+	"hNu9zKUoNNFc5IywSurVttjyRCjdjYDJSq7xVQ06kFwqzAsMbJORvXDRqVsyGuHchTFTUpoUoMBfhaHp",
+	"UhpiA9KYMI2zOCOaDVeC4S52FU52R6pMvpOqzahsq3TMCbe+8wbiENBDkpI1U5clI0YxWwpEakZKRq9Y",
+	"W7kGRvuLJu9veKGhLk3JbnhubTPViudEqoKpE/LSGdBB0MNObr4nJ8QF+jln9/c3ApbXVNAI14nL9DEL",
+	"jbkmXPEceYT+z/aHtWblFdMn5P21RCB0Gwet6brX46I2GCRU8MWCAfWA5YB8CP3aDwFMUIMH/MmbYd2a",
+	"7p8GDDAs0yv69OtvUoj29OtvYrh2/u3Z06+/IRyV7vUNLzlVm7CZbTUnFzUvjUuBTskVy41UofTLhTaM",
+	"FgPcQt2JmwWe+0UtcueN1XQJKyWdf3v29ZdPf3769TdO2RLM4oMhgSMUhIkrrqSwn7yeq8EQN2UzG7vh",
+	"2nwChsLciAxEtYQ+w6DS7EY8x0bonK97tsoeCVuj8sRf/JIVS6bmqNO3P1i62uYnsGKEVKbVHS4YIDS8",
+	"i1wYJYs6Zxgqf96hGwFYfABSU6OjhQ3vui9V1cLp9X7+Rba6IZC1niDHL2R3hXDH2BVTGLjRDvQIH4cA",
+	"Lm2osl/QacctlRWP4097XS0VLdg0Ezs8Vt9jjybE249wJXcbAHMn9Dj4DpvcYT7jPF4QBsGY/ad9c2Nv",
+	"zgiVSAoI71LBdC+x/JNiJcYzGemj4eYD9n/BWKa5iOvoF4zB80zznFUW08O6nYzZtwZvOtxlCL/2TJs9",
+	"fGH4FcNIqxEuM8tpmdclctsjLOR1TkvVNfaVbGGkxb2wnFuruOZ2rgvwmiZQtwbnU/YNC3rYy2YxeONa",
+	"oIzPRXtvVM9DZRjRmJXsisUlb0YxsPFbeW1VnpvmLOwULRjzIPypgRyZYHB/wNP+3qkfAvDxnjmEHAfS",
+	"HkVic4vwnCumuCx4Trj4hbmL3lAsjzFYb0oKw0VtaRBRrIUbn3oCMZr9OMwhBqhUpgn7oRvyINh157SL",
+	"QFDoBghoQy8Zgu3mIdTsdKaKaV7UCYW7onkXst2Q0V3ed9SwU9UcrT4QXvaIV3PJxy5dH5d7aNM7reEu",
+	"JelUhy5PIVa0iacijoZHfKZd7hrfMiFUSyO9XtT1aMe+Ykp3vXFbzLTbOz62bdEZ3/5AMGMYK/aYJfPO",
+	"Vjo534bpLs55/hljsKE/c94+kR1MJDhqANDX3OSrLBGAZNtiCwzg6onwwymRu4BbyBYLlpspMEAkC5Zd",
+	"S0KBny0ULxgtICy4DUrCcKQ+KI/+JYkdWgcsj9AcBImW44FRHu+Qat7PsxX5f5ATcf9Kwl8LiCHefg3c",
+	"B4c7CeU8tnHI08agU7JhGnal8a0O7ggkvovbIf2kBSvpZmxKaNCdtOF5vSkW3xxq3zD7oKAvd9xJPpja",
+	"3bOxyW2T/oKb6zm8FWERpP5J2vyokYKeWFWZepJOyTlfrumLszehUXBOCobq68aPJ/SMPYn73+3onpAK",
+	"L5jqSvsHDz0qWCU1j2t23DeLKUv7fAtARcjP1mIMLSHfBNijofkeQCzlFeaSS2qs5dXP0OBnXsTAcaaN",
+	"Vy8ik4+Ez8SQMjmILV1ZOJyOG/Xpzc++gQXSeUx4uuU/BVrCxERcbJmIi+5EXOw9kZM1k/O47+E0rZDh",
+	"iaRuiTJihJGkolrvgQpJO2pB5c/2o4WktYEz8uLszcku1j47Tq1Kb/LbMopjUSJ13+gFK33vBo+807zr",
+	"NgjKWdsoZKZPdrCKJaJZ/nFFy0SM6jtWKaZBnUSJDXVwnj6pSNU8iWjUuIwmhpJkEiJbpn5jEgEG6EwO",
+	"31198ehZpRzI0X/cfh703s/xMJV1NdhQH48wBOifPlyOVJQ7N7Y2THe4sy50e4hUU0Lu2gPuL8IFRMMg",
+	"sZV8S/XqJc2NVJthUlCrgUmk4HGeErts8ZffxLkCC0J8EnDDcMl9uprUxrsRnm7PNsvFIMMPgRQ/K+oU",
+	"rP6/ViEUpPNpvs/ms4G6qD2LMIX30LGUrOAzZsIjvhTj8KSTmc6Li6wJnYmVZJ3PXKbyMHvp1ng5rrM1",
+	"XyrgjOOjpjOsB7bISP6BFLnz5sm0yNZD0s7CexC34LWqQD9zDKFfWad8ploD3nft6npVh1DLCEXKddbq",
+	"3OO0CZH9fjXpmMLATqEty5dW6i12vIr4fJbUsGnjl/uNLzKQpkR2zfhyFd/Yt3sNbRmJ7Yd2df+HFiNw",
+	"34Fx6MxeSMDIBKFdtGR4tI5AQLHBy8IkPCHMCpf/UOJ2FbOibpUA1xQ7IsJ/Jja7XxosQqg1X1cl+qt6",
+	"5qufB2unHB1tWM3dR2kdOsTlzoNU2N6+koePTdkXlu2ZvsYjUt6I53JdlSzNPFfoaVywBRdOvQO5DWlR",
+	"gN8NFK43YEGQeV6r1tbfjzn5gZYcy3RryG8opKzsv7IyXNg/IN2FrA3+zaiyf6B7VvcvxKqAT7JDzeBc",
+	"uJi5vLmyNj6adTafYeeZx+woFxV18RpsSqdVc57gNw5KG8FYAZqENvfwKc0Nmsmd96hg5lqqyyELxm4q",
+	"e5a9VD9h4eYhOaXK1FWh1hh/37jaSMyn6PtGgJPiiilngpIufyMam8yKcTVMMkUceB3XnC30NUYK3wb1",
+	"WfokMJRCoVFPvwZG9iAziLs+vnVIYHp08kIbyhNYjt9gA/FP1AjsE2KYSkz/PgJlWId/R43eWMQkfILF",
+	"NOHwLgnvgql91lRV2UG0T1TTZGliTZ1ajAahmE346Qj00xNlNy7NzTlALDN0JlLBA2WXchtF5PTI0AYI",
+	"Pg2GUNzChChjdhTX5OfWllILfhO4+eGHrnmnowm9YE3ilfiu+69j8xeJuNTOZGATj8w1WLElhgUzlJc6",
+	"FbRceW29nWXNNSirGmOE/dFNRCq6se9vEz7vITJMrePm7BYIrDGSPubIPAAIS83lXzJoZWVLB3n0oQrg",
+	"GEN3twkeDutItAdKW6qbsJDLtXMqwGobGDAYpTRTqRrIG7ZFdEb79WdfmwPG4WaFWRlUmXlX6mEMmV5G",
+	"h1trSD7kxPiYP9r0eKyYUjdiZAtGii9RwNqE3Pv98ZOm6CzSWN8qSakVy3nFU/Jr89WO5ZJ0q+QzM/Xw",
+	"taCVXkkzCasHxhMrfzBF/CBzMEwLpPnOpuAc1PbY1VTG7Nd8wfJNXrJemuz2oR9UmGh8OvyVd6l2Mcsj",
+	"/lHZRw89NizPBH821HbuucQobUhrRTcV6xy9/QEyZH9JMnL2+n+9ac7O/viUZL2n1/76FclIq/aKmG8T",
+	"9oqhrWL0cvjrnBrrZ/uxiVpxIxYyr8E11XkBff/u9aQ7jbueMZFMj8rF8mdXeaT3iDaOndiKsKSZys0C",
+	"KDg2DzSYMBO0S8y1SdgxnIdAY/C6tbjpWOp5KHgir9jBVyFnCFRMBN0zUemk0IChOSSi/2k1suiukSg9",
+	"g6QuMEoF8RvDOK1cbSojT6ENNDnVRtW50Riq1c45kFOs1IUBJdurzff1d5b+S83Rx9TITLErRlOuU5iG",
+	"+teaWRoK7pO2MWkGiEl5U+llf49xbJ2Opw3DEzD/As0Nuh26LPXU7rktg4Kz/EQy8g4hbopi2Q5krZfV",
+	"7tE0OFQMdE1LkyVNCk6ZS85paUKdHXCfcDxt1EK6mgSqi5NpNO4/qokvb4GCdsGsGNOtX++hW/+Yoh0w",
+	"b6OVQY6le6WumMJ0S5PR4Qff4+N8dq/reNfc2CFVCNY3bRXhpgSkIaGbcF/9dWrQFiSkYH5N4G5E4rfg",
+	"6jJh1GYfFosvM13KHZZ3zpfntsOWLfXNBntaymumMjvvyBGX3bB7bNkp6tJU5cPx0LGEFcQuRu+3ETjw",
+	"Tjvhumzfi3bsXqAALXMpss7s90t1kF5mgF1Zk0hty+7R9UD+kYttOxejWkAkrASbTlZ/yTYPw3AXiQId",
+	"nCe43aYtp5jQoXEyD/L9XzvHXnTc7DI6W0p5WdsIqJ1dhcORe5VMZ7HmuZIUHOTbWi9soM52lhVwXGp2",
+	"Y8zpPy4YQV+Cnd9bgYgkKyKuaeWNG2D0shrxk7u0EDdFNGJRfrkUhnKodRjV9GOAJCsrIFStI8rJg0Lf",
+	"H4KXueclO74/+RoQKPASC2Nq7d/DLTOKsfuPOrTpyUu+YFaUi78wkNvpn2xDfLOTg/EUqaSsHe86MPOV",
+	"GKfdJpolUuGXJXwJ89kSpKOQREn7/2lSMMPU2qLiyoZC1fkKeHe6ZD6jK3hHQbRvb6LO6D7JXTczsUsK",
+	"oiua40CYOKykaslU49Z5gSknG2+rNeVwT9oIzX66HvsbpITaOc/sd841tqVd4KcYZJuNpLP1YFyyzSm6",
+	"4cHv+ziZJpPWJgCzje8SpFtlwA2TKG/B18uOByPgUwdbWvAP6Mlo4XMqhB09GYfpoacuD9YB16HWbLjO",
+	"6RkSwr2NiLjt2qa64Q43N+09ay6meM+m9ZZA6HFDQGVJAFTy7y//TRRbMAXGqi++gAm++GLumv77afez",
+	"RbwvvogrLe/LcRf3yI3h5o1iTMtkRcNSLEHTUDFugQFG9pGTwi7U/thNTSEKAvnIgGWhEKnPSlmxaGvc",
+	"4ODQIe+0Ysu6pJiSgQvBVKfTlISiqBIwN8Kpv+C/729ErG3wH2wdbEesEnx7ITLT3biJWUN6JX8xnWsO",
+	"0T/7jtimXm1HxBSNtxnxJYzQjug187cZ870bY0L17aVQmGcfFXTcpwsDphhPuItNjb3dV+X20U1N2hH2",
+	"q1XZQRMmIInJe0gGml8ygQW3LeWDGY0kTOhaOTWhhRXGs6C4YWT4wOu2yb6lt7OxcrYqR42wizx26eEg",
+	"sS12taxHYQ9HjheLtO2t2Dni0ZFDEmzX0Ht1QEzfNnEM0FitWTFuVGtOKhgQnSN8/8TwbRXH5hImUqC3",
+	"uex7rzW0J49evXhM+KL/MUg2Hwhf25cdFpKcBpFtGYGln/J+FygWjKXSLvQSwJAFS6iHt5UrXFy1lQqh",
+	"VT9UdiuUExPEfetMba65yx7yQLPCdYDsGrmDocISHTuXs5vPlkrW8QxbSywb00tvCIIBMF0o1GN0x6mN",
+	"/ij4kmlzYnMJC+Ie32GR4u5pEt4WP+7UWCcAWOPYgvyQSxoTzBnaTjtkm7vkMTDM/Z/wPlWU5jPgSzJz",
+	"E0tE9mrAs5DKZdqBkgYBvemEJx8i/RgXRlEkvplcLKJlPt7A761fsvI0WbHhqU+gypdso9i+vMs/oTOG",
+	"YYxSnvKqKRm6H+EpWaqCfXkTuT5fPc3aG3RCXtvehImFVDnTZF2D9Y/dQLpvZ4QLuVTIgQ2AAdOM6a/F",
+	"b0xJUCQIIp3na/+ONZsN2XBoDvy8dtmeLAxNPY9GWfnoHLiZOQL5GOXU4VUjtTAc2R+7jT8EuwgeIBbo",
+	"/1nxMoIFlbTfdQjHnAhJJLqfBS0x/Vybyx1hdum7Ooh0v9c8rGlUxM3/FhPA6+J14PDbainyFRVLNr1w",
+	"3BAnJ13wYUHVyDU/ZIG7ETg/baSMkIk0PsKV8bUCil3mutGo3S/AFd2smTB7Ur632Nu5v4FP27gEoBIS",
+	"gO89zvcrZjU9Ju4HCB+bqh6NqAW6U6S2wRrnCbmnyYWC0Q8h74o3yLIIixqMvIE50+tOnUjnOsHNaz1g",
+	"AmTd34kdn8W4Zvw9X7NWLkFGLsYC8UlPIoqXcbkWPbuQZP9lZDnNMONYoRNYgX3HcWKy7TdA28D4O8hH",
+	"u8ctCFyTIGdittWjsE3AApFBPjC6m4zQnpQ+IS+aZJ62mUsv2Gb4RH1WP1wIM1c2RVi4cu0gQSrqsCGi",
+	"CKJh4NZECIFrgLyRbTPkklwTmi+WTSBCRBHkm91Yp8imXUwZ41su1G9tw6EeyDerqrJXJznSSpsKDEap",
+	"kw4cS6lVTOMyZ/OZXZb9x4Jt/12o32bgr1fOLN5Ui9lP0y6ww4kM5omkIpt1pdYOI9ncxBa1tmhAR2uA",
+	"uwRLYDFq2+2sngyV6lj1qP3hOS3L9zcCZ4rknRgJzaIV5p547UKyfGMg4y5+zmutHHUIrTPWAK91GPgU",
+	"wPkXTfrFG+GWRMo3joRrbaXQfRYgxE2qlsl1g8JqyIbynFC1rDFN7D2sb8sKkiXL0Uu+LQc3ZNlylx0K",
+	"o3Yw5TJfuHzaqcJxE4vp0srxjDxvWcM2W2AC0+dW+GGVK+EkRZY3oZ2EawKu3JJ8wJDID7MTm/fVigGK",
+	"0QIJrOKGxcq6sm6+K6rJNSvBt91hdNacbhCad0LO3HJ9RhkNmI0+7bFCzp9roWBa6TpxYimqhFxV95A+",
+	"wQk9tzO1Dj54SDkVQprP6Jx2LBTcra4XBjJXld8FUjK777/WkIGCcIElhBM6WqkYXwqrBU8RxAX1D4Hu",
+	"H1f0OehSKZcWPjx4PXglGnZ8PyIKlhccjHANOJfZtL9jbuAR8trshdYs/Sw2RQF0G/yu3SqDEnvTlujJ",
+	"zNtghYDYnpU95Pr2qOt862LOvQE6VGNb306Ef6T8c/gW9ofexpkFVs5RzkxDvbfSLhzpk2JZHmZXRAYf",
+	"SsHVbcKAD+KMWP2cE1aboeyFaHXjOLqvY3ES6dTUbdSDbv0pd6yLiYsf4Q6T9WY/fPjxhg64DIDpFvzF",
+	"fqWDt57xy0RdwvCMvanMFSK8ZcFRnHFkY1NR9tYiRouiV6It9PtCItOUGMPddgUaAVnodaIW4uhpLkZP",
+	"c2T8Tqbcay8dpgIOA2kScxJf+x3HHrHo6XSSkLZw7XDqKZe/cR6YhBpeQr4tcvhZR9BjJDkCRc/RszY1",
+	"gnaMVxO6ShwJwWGa35XX45QLT828bc5bj0NMsy8TvmtrWh20WPVW4hFAPJIwIelx0Oafdg+zHy+o2QQD",
+	"tK4NhLbGyAjHuOPS/ejxE4Sv/azDNKwOp1eyLgssELeWVx0RM3I4+Py0bGFb7he9OOyWdpIc6WCGcK9t",
+	"LQ3Lc5XXNj7G6WlbxEoP53cVE2ZGdIRhTn1ULsf3RuXoOe4DtBuXm/BcouHZ40pS6rSk71c+2bct/YAd",
+	"vC8+bes0dy1v3vDmKs7S4IGeu22mZVdbgAN7TbRt89yP7VfUHGnwnm1P6Rer2t1s6Raa50yjo8TOqRV3",
+	"pXHYC4kcTpOmbkKKboKghE1GVC6U+DuqLjtvoLusbgCxxMwcnVHFMvaUWBwpsWJEF4Rk0IxmpbNkvK0v",
+	"Sp7b/UE/8Mau4IIACvKOikKuyUufTPPRD+9ePiaYa8MjmS9Aw0gDyaet+pZceKUWbuXnQQBNs3yOKZUU",
+	"W3JtVERvee+rgtT82/yNbKOFNq3TEdqr4X4PE0ZxRwWTMfHM7l9W8LJOIrJtdVl06yLo+gJKSnOBxVMu",
+	"IMuLXAxB0CNTb3FwsG1KXOpreoCVTrswsFx3YzqzVL3789AQaIsk4a2r49TTGW52JZ+uG9JPN9N+7CFy",
+	"h23kRFCvxZ6nr1vZe/hvxWQFU2DoFlPwtJous9X1KHWPIOh6vWNoYEfY6nHaHS+6Fw2fBZNAJWQ+5Lg0",
+	"pi/xMwackUtqZXkr62jeMj+LWhS6t4VN/PCY+XWU93Gsj28zaslNMQVTOYFOHG0XErBb4m0MQqi1ljlv",
+	"bfBQnh4L0b+xpbUwKXS/8GK7lVbBxQtEtH4Q85Lnmi+3Cf0x4F/7vjb4ti4N33Oc73xftGDHn0O+dE+h",
+	"KKgqCCuefv31l3/rZkd4QORquEmxVZVuWU7JSA3Pu3xss7oJRMwf5clSDklW0tamlq3pobGtzclFx3lu",
+	"NxMZAJKOhneL9f4h1oMyQHVp2fbS8Panuf3Nepu2pDOodAJZ/ihx9Krv9AchR4Gd754j0h1iZ7fyy+hd",
+	"jxThaC/JQ7gbIXlEfJhKEr8LKEkk5xouEdWuFl98HCbsdVUyy9u1NDCZWccfDT75fs5zvhxcnXC8+K7X",
+	"Fw4qC4t2dRHkIuS4QEHQQrWHc/Bgf85DuCK30KwU0xaiKNBmpaLJR8by37eZxyPlr3Y62/PennZ3HPct",
+	"yeFWl58op80YDjyMxA5xP6xxljmVnoFMictr8lP181KlueegKsMY6ifrHXTl5+lJThw4fSe3lHearrx/",
+	"2vtN1XJXQdIu8grRv3VqBD5WYAobl/Iabb+uTlt3vw4RpR+vG3eGVdukCGuOhRnzBiGQh8nPu2seXYBS",
+	"sVyqwqXSZVR9ily6ISBNRtlJ0HTzyyezI+F5VGistLOCvn/RTg7Lp6CuhLy63JDcc+8QVcY6SR83TPvE",
+	"eD6LXiybY5XMlH3gdNKj593kHuysFrd7r6O1Y6j0RqsDLCiVeuYF1ndrsoTAdEGS0FvnRPT5D5ujC8rD",
+	"wLKHfNRHiBNaSMx7IgzNAXBMNjs7c1PPXHrN2cqYSj87Pb2+vj7xcJ3kcn26hFjHzMg6X536gT7Oezvg",
+	"x3O1yi33XW4MzzU5e/sKls9NySBsCih4UNfm2ezpyRO8KkzQis+ezb46eXLyJb6UKyBGp1htaPbs94/z",
+	"2enV09PQRXIZC386Z1TlK3zNXNsTyObPUKv1qmgavZTqzA83n7WeIrNnPw5oqEsjPrN7O3s2+7VmajPz",
+	"KXxD9X/rhDFki7an1kD1tEa/f1MrTFaiWHv9Aw8jcCKybnzCE4+Sr7nxlhFllZlOWovADG13BLitXEuX",
+	"LID3hHyvWVA5HnLoejWDj2Pyhc+bTgnA7BAxuFpWZ0BY3K45FQe4gVPhLa5LiLUFY7kI4hVOOqWXnYmu",
+	"YAtq7QOYXzffkFqUTLcxveAtopulQVVupGY5dTvggnx9sIROn4CfJHMQZhbCHU/kFQZzgE4MhAgX3gHW",
+	"Dacyczg+9w9Wx19sjm4rcsMKBF3PSVOSpGdZnDt/L6n953YgdAVEb7LUghE0ltGyjC0z8DHoL/MfN26Z",
+	"LfbjarVNDET1ENA+ZFhUw+WoacIK3d7MXf+WBjQR2hebfkvR2cAJfU4wy3IpCzZ7tqClZvHtYbjIztY0",
+	"gqF/63Hv8KRmvdh0lzJXZ4FX2KwTV498gmAj2Z5bmVGbDZBu+0rNdr11pSs8+0CvnJ3iVvfNXarQtcrI",
+	"NsGEdom4fV636KvRZMhIU7utrMn45xT4/p3xHm3eXcFFjJ6Ql1KRiikYUuS2A9VALbzFCnHeO1UWXNuy",
+	"dlCWBtTRHY88eB9AHOo6ooY+eAtewh2CU8S3D/PFNG4MorCEKeOifdjJS+hlh77YkIC8dIYZGQE2oCGL",
+	"cIfwgjcz/EuKzHVaU0GXTCHqCmk6kXRm1e4qcIAh8o6hpOfndsHCs474lUKvvj/myAw/zWe+lCNQm6dP",
+	"nnj+0ZnZgtFOf9Eo2bQDpuNYdomKjREhXzx9ukzRnALyTesqXXQE0loCtzIc+XvtHoqKLrlwnqVwsmt6",
+	"ibI9xkc7x25PoXyCGagX4L0SHNPkbs0EG1LLl3Y3IM7vdyF/BA6ej+0C/3qrc0zW8EzX0uytwzecAvY7",
+	"h4Cw6a4G6Mf57OvPfQkWqak1uPw40yB3zH762JNmTn93f2W8+JgUbV5LeVlXjTjLBRJ857TSlXCwrbtX",
+	"f98AkRiVcPyozbsDJMUKYgFFaYCchXtkVM124tenvkIHpJhHPvnIJ98Pn3wnT+kOD+gdPpjxR+r4Rs3+",
+	"+uSvx2f24TyzJTx+W57Z0wEF2PbuisDfu09HUZsPJUacIc2HSKJ5ZOR1PqsqSEkDxin9kN7pg4sZf9Zn",
+	"+ajo3UvRe+CntHffdxBP21nam3oUVgMrVW9jjxzBkSP4HDmCJsz8k/ABXjR5OO//nVg9j2/+8c2/tze/",
+	"udHTHnrb/FtEneP73rzvjRLl+KgfH/XP7VGPZJXf7Yl3AySUmbd68p/j0GchaEf5/8gLHHmBu5H/OwRg",
+	"V9H/yBBEMj0d2YIjW/B5swW7y/wNQ9CzhR6EFTgqAY4P//Hh/+RKgONjf5T+j8/85//MF1RufdztrXhx",
+	"9kYHr7x/4+cuipuSwoV6cQEZCGz0VUH4Ns87O+xD9Lw7PrvHZ/eun907fS79vZ70or84exN7zx/sk9vd",
+	"ObfYo+f3H/+1CtMpTHUD72bHDHI+YJwKUjtW2Oy+Fk+NJLK0NHzLyxUOtO0FO5Lbw8SxBjVk7SwLfuOI",
+	"o09dmmO57jbiSEjDsH5REgpIFgiD7RxmhmmfZh+3fP09OrGvyBNOerhiQrHd40tIzuEjyX6xm+YRsW5z",
+	"2jVBBr62VJPMBeo+ab4kWZNazP6yxp8gXc05X9qfSvwJEmVhmqDYFthkT8k90NBtjf/Y8SYtMmBZG91S",
+	"mCPsYuNUTfEjietpHmSkhp+SGogTXBjWn3rNRTY6fdPgICC4XBw9GOjNFhjozV4w3K3aza8sWNOSWwJs",
+	"+NqG6Tp6QwV59/I5+eqrr/5G8N5beQDRJbVgHBLr8IXANXSjoKb5PIUKvXv5HAA4bwIwJrXaeqgNRh1q",
+	"5TDiw1v4nzg7wp8yRP1TRvLhqp3g7mQ6LEw6zqX4VuNy/mcknz4c2XI+60sVt69EvkVe7Ux4lFv/UHLr",
+	"FC+qMAdT2D6dhmkHB6i7d0rCpBIoP4Twt5cOOYYmr0SbVyxK0LHZ7KioPSpqj45RR8eoiQ/hHzrvRbBP",
+	"p793ifX2/Bdt86QOs20Sz30RY4n7T8ZWtvhP595yZ2RnR2JzfykObunzcHQY+ExY2QERsvUT9A6UiNj2",
+	"E8jRa7nUn4YkHVmtwxhpPrEG/k+qDofCNo1eaVD7HHMuumpF4+IYtsrawqV3k3rxzt7KVBFPWlW8uLEp",
+	"6oPGmC08kZ/9Lln0Ui4zT/53z7GwfGG7fk4uGwNSfQvOYezNGvdWDxUv0HIs9fUkT/OjHuL4OO7wWnVU",
+	"Z64O//0pzbbPbkdPrpau2aHnqwU3qfnst9n9h2IcfeuPvvVHOfM+lV1wyKe/++u5XcHl6r9vT+9qG06X",
+	"JsMa1UfV1p2qtuwiJtPCe8zYCVMeyc1RM/ewNXN9inl6QUsqcjYplKfkGioyNTn+r1cSCIpLNgwEZpSi",
+	"+smOstFRNjpWKTr64U31wzsY03VYbiQknpOktO+44MdUaLFX76J9Go4i25+JAdklMitsCzN6+jQWnoVB",
+	"WfZJxUCtUZnvGJx1DM46Bmcdg7OOwVmfxhp9DKM6hlEdxbc/dhjVFI8TZ8S0gErBfBnwoDE+/0ku5K6d",
+	"UAaLei7XF1ywVgDyK2grehppDwoarahp3mHf0EiiGy+DLevKlCwT7ys44YBQnDN+BX8uFGO/scxQtWRm",
+	"0nvbWY0HEOqOBfOHhcd2WptlilHhRnz4mi/9qdaQ4880iQAJJX4lc8snb2RNruGylPwS+ruiZXbT11Dv",
+	"v1dI1UhiVJ00TrvuGcCzNVBufh8GoGPM3zHm7xjz9yfQhozmUAtczs75ck1fnL2ZGPQ3JTfa/Qb7WYju",
+	"zF/pwC/5NriX8oopAbW7gXqkAF/KK6RZh9o6Ck5WXhuCgj3XjuefIyvHhWZCc8Ov7sgbC8BZU5OvGgAa",
+	"7KyUXCq6JldMaedcEQPAfT6GbR5Ngsf8esf8ekee5bNyurNHemopDDinDn88ve7/vmsYaozbSRltXpy9",
+	"OcahPlRnvbumo5Oo53YKdYw+/cMJVcOoU7sCqWk5ydGtaQw6rF0J0ttmqnunSiGn3i6iJ8BYWlGno/jg",
+	"a9x+yUSBAteVNPhHRbVmCLU9P/iT3bC89n9WsJQpOsc47HIRQG7HSME9sDgeS2UcZYo/iUzxkPSjHUI7",
+	"Scjx9HJnzWg71VHE+BO94Ke/+z99rPn2wJ/mRbnVi/73zVljV7znl72B3yehbC2ckan7G7RDLsoDa1OP",
+	"ctInfhs8KkynwhOp7jG66fhufO7vxumVNNNin6AhkeJWT4m/Yz/ArMc3ZA+LnD+LrmB4JR07GrU32Y+f",
+	"xn6IWNOTv7GWZQpa/BqVvzeANELO5jN6oQ3lYpJgfRRzj2LuUcw9sJjbPByTRFxL8HcWb3GKo2j7x2BR",
+	"Ps5nmqkr/9bXqpw9m62MqfSz01N2Q9dVyU5yuT6FDGeu/++NF4xcr8F3rPnFjRz84ix14S9GMbqeffzp",
+	"4/8bANlZC7PVlAEA",
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file.
+func GetSwagger() (*openapi3.Swagger, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error loading Swagger: %s", err)
+	}
+	return swagger, nil
+}
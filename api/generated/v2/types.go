@@ -414,6 +414,46 @@ type BlockUpgradeVote struct {
 	UpgradePropose *string `json:"upgrade-propose,omitempty"`
 }
 
+// DAO defines model for DAO.
+type DAO struct {
+
+	// The address that created the application.
+	Creator string `json:"creator"`
+
+	// Whether or not this application is currently deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// Round when this application was deleted.
+	DeletedAtRound *uint64 `json:"deleted-at-round,omitempty"`
+
+	// \[deposit\] governance tokens a proposal has to deposit.
+	Deposit *uint64 `json:"deposit,omitempty"`
+
+	// \[gov_token_id\] governance token asset ID.
+	GovTokenId *uint64 `json:"gov-token-id,omitempty"`
+
+	// The SigmaDAO application ID.
+	Id uint64 `json:"id"`
+
+	// \[max_duration\] maximum voting duration in seconds.
+	MaxDuration *uint64 `json:"max-duration,omitempty"`
+
+	// \[min_duration\] minimum voting duration in seconds.
+	MinDuration *uint64 `json:"min-duration,omitempty"`
+
+	// \[min_support\] minimum number of yes votes for a proposal to pass.
+	MinSupport *uint64 `json:"min-support,omitempty"`
+
+	// \[dao_name\] name of the DAO.
+	Name *string `json:"name,omitempty"`
+
+	// \[dao_url\] URL of the DAO.
+	Url *string `json:"url,omitempty"`
+
+	// Label of the SigmaDAO program version the application matches.
+	Version *string `json:"version,omitempty"`
+}
+
 // EvalDelta defines model for EvalDelta.
 type EvalDelta struct {
 
@@ -514,6 +554,94 @@ type ParticipationUpdates struct {
 	ExpiredParticipationAccounts *[]string `json:"expired-participation-accounts,omitempty"`
 }
 
+// Proposal defines model for Proposal.
+type Proposal struct {
+
+	// \[abstain\] abstain votes.
+	Abstain uint64 `json:"abstain"`
+
+	// The proposal account address.
+	Address string `json:"address"`
+
+	// \[amount\] amount of a transfer.
+	Amount *uint64 `json:"amount,omitempty"`
+
+	// The SigmaDAO application ID.
+	AppId uint64 `json:"app-id"`
+
+	// \[asa_id\] asset ID of an asset transfer.
+	AsaId *uint64 `json:"asa-id,omitempty"`
+
+	// Round when the proposal was closed or its DAO deleted.
+	ClosedAtRound *uint64 `json:"closed-at-round,omitempty"`
+
+	// Whether or not the proposal is closed or its DAO deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// \[execute_before\] unix timestamp before which the proposal has to be executed.
+	ExecuteBefore *uint64 `json:"execute-before,omitempty"`
+
+	// \[executed\] whether or not the proposal has been executed.
+	Executed bool `json:"executed"`
+
+	// Description of the mismatch between the execute payout and the proposal terms.
+	ExecutionDetails *string `json:"execution-details,omitempty"`
+
+	// Whether the execute payout matched the proposal terms.
+	ExecutionResult *string `json:"execution-result,omitempty"`
+
+	// Round of the execute call.
+	ExecutionRound *uint64 `json:"execution-round,omitempty"`
+
+	// \[from\] account paying a transfer.
+	From *string `json:"from,omitempty"`
+
+	// \[hash_algo\] algorithm of url-hash.
+	HashAlgo *string `json:"hash-algo,omitempty"`
+
+	// \[msg\] message.
+	Msg *[]byte `json:"msg,omitempty"`
+
+	// \[name\] name of the proposal.
+	Name *string `json:"name,omitempty"`
+
+	// \[no\] no votes.
+	No uint64 `json:"no"`
+
+	// \[id\] proposal ID.
+	ProposalId *uint64 `json:"proposal-id,omitempty"`
+
+	// \[recipient\] receiver of a transfer.
+	Recipient *string `json:"recipient,omitempty"`
+
+	// Round of the governance token holder snapshot, taken when voting started.
+	SnapshotRound *uint64 `json:"snapshot-round,omitempty"`
+
+	// Lifecycle status of the proposal as of the current round.
+	Status *string `json:"status,omitempty"`
+
+	// \[type\] proposal type.
+	// * 1 - ALGO transfer
+	// * 2 - asset transfer
+	// * 3 - message
+	Type *uint64 `json:"type,omitempty"`
+
+	// \[url\] URL of the proposal.
+	Url *string `json:"url,omitempty"`
+
+	// \[url_hash\] hash of the document at the URL.
+	UrlHash *[]byte `json:"url-hash,omitempty"`
+
+	// \[voting_end\] unix timestamp at which voting ends.
+	VotingEnd *uint64 `json:"voting-end,omitempty"`
+
+	// \[voting_start\] unix timestamp at which voting starts.
+	VotingStart *uint64 `json:"voting-start,omitempty"`
+
+	// \[yes\] yes votes.
+	Yes uint64 `json:"yes"`
+}
+
 // StateDelta defines model for StateDelta.
 type StateDelta []EvalDeltaKeyValue
 
@@ -985,9 +1113,40 @@ type TransactionStateProof struct {
 	StateProofType *uint64 `json:"state-proof-type,omitempty"`
 }
 
+// Vote defines model for Vote.
+type Vote struct {
+
+	// The SigmaDAO application ID.
+	AppId uint64 `json:"app-id"`
+
+	// Round when the vote record was cleared or its DAO deleted.
+	ClosedAtRound *uint64 `json:"closed-at-round,omitempty"`
+
+	// Whether or not the vote record has been cleared or its DAO deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// The vote option, not set if the vote was cast before it could be indexed.
+	Option *string `json:"option,omitempty"`
+
+	// The proposal account address.
+	Proposal string `json:"proposal"`
+
+	// Round at which the vote was recorded.
+	Round uint64 `json:"round"`
+
+	// The voter account address.
+	Voter string `json:"voter"`
+
+	// Deposit of the voter when voting.
+	Weight *uint64 `json:"weight,omitempty"`
+}
+
 // AccountId defines model for account-id.
 type AccountId string
 
+// Address defines model for address.
+type Address string
+
 // AddressRole defines model for address-role.
 type AddressRole string
 
@@ -1039,6 +1198,9 @@ type Next string
 // NotePrefix defines model for note-prefix.
 type NotePrefix string
 
+// ProposalAddress defines model for proposal-address.
+type ProposalAddress string
+
 // RekeyTo defines model for rekey-to.
 type RekeyTo bool
 
@@ -1060,17 +1222,6 @@ type TxType string
 // Txid defines model for txid.
 type Txid string
 
-// AccountsResponse defines model for AccountsResponse.
-type AccountsResponse struct {
-	Accounts []Account `json:"accounts"`
-
-	// Round at which the results were computed.
-	CurrentRound uint64 `json:"current-round"`
-
-	// Used for pagination, when making another request provide this token with the next parameter.
-	NextToken *string `json:"next-token,omitempty"`
-}
-
 // AccountResponse defines model for AccountResponse.
 type AccountResponse struct {
 
@@ -1084,6 +1235,17 @@ type AccountResponse struct {
 	CurrentRound uint64 `json:"current-round"`
 }
 
+// AccountsResponse defines model for AccountsResponse.
+type AccountsResponse struct {
+	Accounts []Account `json:"accounts"`
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // ApplicationLocalStatesResponse defines model for ApplicationLocalStatesResponse.
 type ApplicationLocalStatesResponse struct {
 	AppsLocalStates []ApplicationLocalState `json:"apps-local-states"`
@@ -1176,6 +1338,27 @@ type AssetsResponse struct {
 // BlockResponse defines model for BlockResponse.
 type BlockResponse Block
 
+// DAOResponse defines model for DAOResponse.
+type DAOResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Configuration of a SigmaDAO application, decoded from its global state.
+	Dao DAO `json:"dao"`
+}
+
+// DAOsResponse defines model for DAOsResponse.
+type DAOsResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+	Daos         []DAO  `json:"daos"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Data    *map[string]interface{} `json:"data,omitempty"`
@@ -1185,6 +1368,27 @@ type ErrorResponse struct {
 // HealthCheckResponse defines model for HealthCheckResponse.
 type HealthCheckResponse HealthCheck
 
+// ProposalResponse defines model for ProposalResponse.
+type ProposalResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// A SigmaDAO proposal, decoded from the local state of the proposal account.
+	Proposal Proposal `json:"proposal"`
+}
+
+// ProposalsResponse defines model for ProposalsResponse.
+type ProposalsResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string    `json:"next-token,omitempty"`
+	Proposals []Proposal `json:"proposals"`
+}
+
 // TransactionResponse defines model for TransactionResponse.
 type TransactionResponse struct {
 
@@ -1210,6 +1414,17 @@ type TransactionsResponse struct {
 	Transactions []Transaction `json:"transactions"`
 }
 
+// VotesResponse defines model for VotesResponse.
+type VotesResponse struct {
+
+	// Round at which the results were computed.
+	CurrentRound uint64 `json:"current-round"`
+
+	// Used for pagination, when making another request provide this token with the next parameter.
+	NextToken *string `json:"next-token,omitempty"`
+	Votes     []Vote  `json:"votes"`
+}
+
 // SearchForAccountsParams defines parameters for SearchForAccounts.
 type SearchForAccountsParams struct {
 
@@ -1321,6 +1536,19 @@ type LookupAccountCreatedAssetsParams struct {
 	Next *string `json:"next,omitempty"`
 }
 
+// LookupAccountDAOsParams defines parameters for LookupAccountDAOs.
+type LookupAccountDAOsParams struct {
+
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
+
+	// Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `json:"limit,omitempty"`
+
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+}
+
 // LookupAccountTransactionsParams defines parameters for LookupAccountTransactions.
 type LookupAccountTransactionsParams struct {
 
@@ -1526,71 +1754,82 @@ type LookupAssetTransactionsParams struct {
 	RekeyTo *bool `json:"rekey-to,omitempty"`
 }
 
-// LookupBlockParams defines parameters for LookupBlock.
-type LookupBlockParams struct {
+// SearchForDAOsParams defines parameters for SearchForDAOs.
+type SearchForDAOsParams struct {
 
-	// Header only flag. When this is set to true, returned block does not contain the transactions
-	HeaderOnly *bool `json:"header-only,omitempty"`
-}
+	// Application ID
+	ApplicationId *uint64 `json:"application-id,omitempty"`
 
-// SearchForTransactionsParams defines parameters for SearchForTransactions.
-type SearchForTransactionsParams struct {
+	// Filter just DAOs with the given creator address.
+	Creator *string `json:"creator,omitempty"`
+
+	// Filter just DAOs with the given governance token.
+	GovTokenId *uint64 `json:"gov-token-id,omitempty"`
+
+	// Filter just DAOs with a name containing this string, case insensitive.
+	Name *string `json:"name,omitempty"`
+
+	// Filter just DAOs matching this SigmaDAO program version.
+	Version *string `json:"version,omitempty"`
+
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
 
 	// Maximum number of results to return. There could be additional pages even if the limit is not reached.
 	Limit *uint64 `json:"limit,omitempty"`
 
 	// The next page of results. Use the next token provided by the previous results.
 	Next *string `json:"next,omitempty"`
+}
 
-	// Specifies a prefix which must be contained in the note field.
-	NotePrefix *string `json:"note-prefix,omitempty"`
-	TxType     *string `json:"tx-type,omitempty"`
+// LookupDAOByIDParams defines parameters for LookupDAOByID.
+type LookupDAOByIDParams struct {
 
-	// SigType filters just results using the specified type of signature:
-	// * sig - Standard
-	// * msig - MultiSig
-	// * lsig - LogicSig
-	SigType *string `json:"sig-type,omitempty"`
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
+}
 
-	// Lookup the specific transaction by ID.
-	Txid *string `json:"txid,omitempty"`
+// LookupDAOProposalsParams defines parameters for LookupDAOProposals.
+type LookupDAOProposalsParams struct {
 
-	// Include results for the specified round.
-	Round *uint64 `json:"round,omitempty"`
+	// Filter just proposals with the given status.
+	Status *string `json:"status,omitempty"`
 
-	// Include results at or after the specified min-round.
-	MinRound *uint64 `json:"min-round,omitempty"`
+	// Filter just proposals of the given type.
+	Type *uint64 `json:"type,omitempty"`
 
-	// Include results at or before the specified max-round.
-	MaxRound *uint64 `json:"max-round,omitempty"`
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
 
-	// Asset ID
-	AssetId *uint64 `json:"asset-id,omitempty"`
+	// Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `json:"limit,omitempty"`
 
-	// Include results before the given time. Must be an RFC 3339 formatted string.
-	BeforeTime *time.Time `json:"before-time,omitempty"`
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
+}
 
-	// Include results after the given time. Must be an RFC 3339 formatted string.
-	AfterTime *time.Time `json:"after-time,omitempty"`
+// LookupDAOProposalByAddressParams defines parameters for LookupDAOProposalByAddress.
+type LookupDAOProposalByAddressParams struct {
 
-	// Results should have an amount greater than this value. MicroAlgos are the default currency unless an asset-id is provided, in which case the asset will be used.
-	CurrencyGreaterThan *uint64 `json:"currency-greater-than,omitempty"`
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
+}
 
-	// Results should have an amount less than this value. MicroAlgos are the default currency unless an asset-id is provided, in which case the asset will be used.
-	CurrencyLessThan *uint64 `json:"currency-less-than,omitempty"`
+// LookupProposalVotesParams defines parameters for LookupProposalVotes.
+type LookupProposalVotesParams struct {
 
-	// Only include transactions with this address in one of the transaction fields.
-	Address *string `json:"address,omitempty"`
+	// Filter just the vote of the given voter.
+	Voter *string `json:"voter,omitempty"`
 
-	// Combine with the address parameter to define what type of address to search for.
-	AddressRole *string `json:"address-role,omitempty"`
+	// Filter just votes with the given option.
+	Option *string `json:"option,omitempty"`
 
-	// Combine with address and address-role parameters to define what type of address to search for. The close to fields are normally treated as a receiver, if you would like to exclude them set this parameter to true.
-	ExcludeCloseTo *bool `json:"exclude-close-to,omitempty"`
+	// Include all items including closed accounts, deleted applications, destroyed assets, opted-out asset holdings, and closed-out application localstates.
+	IncludeAll *bool `json:"include-all,omitempty"`
 
-	// Include results which include the rekey-to field.
-	RekeyTo *bool `json:"rekey-to,omitempty"`
+	// Maximum number of results to return. There could be additional pages even if the limit is not reached.
+	Limit *uint64 `json:"limit,omitempty"`
 
-	// Application ID
-	ApplicationId *uint64 `json:"application-id,omitempty"`
+	// The next page of results. Use the next token provided by the previous results.
+	Next *string `json:"next,omitempty"`
 }
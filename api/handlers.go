@@ -0,0 +1,1042 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/algorand/indexer/api/generated/common"
+	generated "github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/version"
+)
+
+// ServerImplementation implements the handler interface used by the generated route
+// definitions.
+type ServerImplementation struct {
+	// EnableAddressSearchRoundRewind allows configuring whether or not the
+	// 'accounts' endpoint allows specifying a round number. This is done for
+	// performance reasons, because requesting many accounts at a particular round
+	// could put a lot of strain on the system.
+	EnableAddressSearchRoundRewind bool
+
+	db idb.IndexerDb
+
+	fetcher error
+
+	timeout time.Duration
+
+	log *log.Logger
+
+	opts ExtraOptions
+
+	// disabledParams are the disabled endpoints and parameters, keyed by operation
+	// ID. Nil if every parameter is enabled.
+	disabledParams *DisabledMap
+}
+
+// errTimeout is returned by callWithTimeout() when the handler timeout expires.
+var errTimeout = errors.New(errRequestTimeout)
+
+/////////////////////
+// Limit Constants //
+/////////////////////
+
+// accountsLimit returns the limit of an account search.
+func (si *ServerImplementation) accountsLimit(limit *uint64) uint64 {
+	return limitOrDefault(limit, si.opts.DefaultAccountsLimit, si.opts.MaxAccountsLimit)
+}
+
+// assetsLimit returns the limit of an asset search.
+func (si *ServerImplementation) assetsLimit(limit *uint64) uint64 {
+	return limitOrDefault(limit, si.opts.DefaultAssetsLimit, si.opts.MaxAssetsLimit)
+}
+
+// applicationsLimit returns the limit of an application, local state or SigmaDAO
+// search.
+func (si *ServerImplementation) applicationsLimit(limit *uint64) uint64 {
+	return limitOrDefault(
+		limit, si.opts.DefaultApplicationsLimit, si.opts.MaxApplicationsLimit)
+}
+
+// verifyHandler returns an error if the endpoint of `operationID` is disabled or if
+// a disabled parameter was provided.
+func (si *ServerImplementation) verifyHandler(operationID string, ctx echo.Context) error {
+	return Verify(si.disabledParams, operationID, ctx, si.log)
+}
+
+////////////////////////////
+// Handler implementation //
+////////////////////////////
+
+// MakeHealthCheck returns health check information about indexer and the IndexerDb
+// being used. Returns 200 if healthy.
+// (GET /health)
+func (si *ServerImplementation) MakeHealthCheck(ctx echo.Context) error {
+	var health idb.Health
+	err := callWithTimeout(ctx.Request().Context(), si.timeout, func(ctx context.Context) error {
+		var err error
+		health, err = si.db.Health(ctx)
+		return err
+	})
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedLookingUpHealth, err))
+	}
+
+	var healthErrors []string
+	if health.Error != "" {
+		healthErrors = append(healthErrors, fmt.Sprintf("database error: %s", health.Error))
+	}
+	if (si.fetcher != nil) && (si.fetcher.Error() != "") {
+		healthErrors = append(healthErrors, fmt.Sprintf("fetcher error: %s", si.fetcher.Error()))
+	}
+
+	res := common.HealthCheckResponse{
+		Version:     version.Version(),
+		Data:        health.Data,
+		Round:       health.Round,
+		IsMigrating: health.IsMigrating,
+		DbAvailable: health.DBAvailable,
+		Message:     strconv.FormatUint(health.Round, 10),
+	}
+	if len(healthErrors) > 0 {
+		res.Errors = &healthErrors
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// LookupAccountByID queries indexer for a given account.
+// (GET /v2/accounts/{account-id})
+func (si *ServerImplementation) LookupAccountByID(ctx echo.Context, accountID string, params generated.LookupAccountByIDParams) error {
+	if err := si.verifyHandler("lookupAccountByID", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	addr, errorArr := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	options := idb.AccountQueryOptions{
+		EqualToAddress:       addr,
+		IncludeAssetHoldings: true,
+		IncludeAssetParams:   true,
+		IncludeAppLocalState: true,
+		IncludeAppParams:     true,
+		Limit:                1,
+		IncludeDeleted:       boolOrDefault(params.IncludeAll),
+		MaxResources:         si.opts.MaxAPIResourcesPerAccount,
+	}
+	if params.Exclude != nil {
+		err := setExcludeQueryOptions(*params.Exclude, &options)
+		if err != nil {
+			return badRequest(ctx, err.Error())
+		}
+	}
+
+	accounts, round, err := si.fetchAccounts(ctx.Request().Context(), options)
+	if err != nil {
+		var maxErr idb.MaxAPIResourcesPerAccountError
+		if errors.As(err, &maxErr) {
+			return ctx.JSON(http.StatusBadRequest, si.maxAccountsErrorToAccountsErrorResponse(maxErr))
+		}
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAccount, err))
+	}
+	if (params.Round != nil) && (*params.Round != round) {
+		return badRequest(ctx, errRewindNotSupported)
+	}
+
+	if len(accounts) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %s", ErrNoAccountsFound, accountID))
+	}
+	if len(accounts) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %s", errMultipleAccounts, accountID))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.AccountResponse{
+		CurrentRound: round,
+		Account:      accounts[0],
+	})
+}
+
+// SearchForAccounts returns accounts matching the provided parameters.
+// (GET /v2/accounts)
+func (si *ServerImplementation) SearchForAccounts(ctx echo.Context, params generated.SearchForAccountsParams) error {
+	if err := si.verifyHandler("searchForAccounts", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if !si.EnableAddressSearchRoundRewind && (params.Round != nil) {
+		return badRequest(ctx, errMultiAcctRewind)
+	}
+	if exceedsInt64(params.AssetId, params.ApplicationId) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+
+	spendingAddr, errorArr := decodeAddress(params.AuthAddr, "auth-addr", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	options := idb.AccountQueryOptions{
+		IncludeAssetHoldings: true,
+		IncludeAssetParams:   true,
+		IncludeAppLocalState: true,
+		IncludeAppParams:     true,
+		Limit:                si.accountsLimit(params.Limit),
+		HasAssetID:           uintOrDefault(params.AssetId),
+		HasAppID:             uintOrDefault(params.ApplicationId),
+		EqualToAuthAddr:      spendingAddr,
+		IncludeDeleted:       boolOrDefault(params.IncludeAll),
+		MaxResources:         si.opts.MaxAPIResourcesPerAccount,
+	}
+	if params.Exclude != nil {
+		err := setExcludeQueryOptions(*params.Exclude, &options)
+		if err != nil {
+			return badRequest(ctx, err.Error())
+		}
+	}
+
+	// Set GT/LT on Algos or Asset depending on whether or not an assetID was
+	// specified.
+	if options.HasAssetID == 0 {
+		options.AlgosGreaterThan = params.CurrencyGreaterThan
+		options.AlgosLessThan = params.CurrencyLessThan
+	} else {
+		options.AssetGT = params.CurrencyGreaterThan
+		options.AssetLT = params.CurrencyLessThan
+	}
+
+	if params.Next != nil {
+		addr, errorArr := decodeAddress(params.Next, "next", make([]string, 0))
+		if len(errorArr) != 0 {
+			return badRequest(ctx, errUnableToParseNext)
+		}
+		options.GreaterThanAddress = addr
+	}
+
+	accounts, round, err := si.fetchAccounts(ctx.Request().Context(), options)
+	if err != nil {
+		var maxErr idb.MaxAPIResourcesPerAccountError
+		if errors.As(err, &maxErr) {
+			return ctx.JSON(http.StatusBadRequest, si.maxAccountsErrorToAccountsErrorResponse(maxErr))
+		}
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAccount, err))
+	}
+	if (params.Round != nil) && (*params.Round != round) {
+		return badRequest(ctx, errRewindNotSupported)
+	}
+
+	var next *string
+	if len(accounts) > 0 {
+		next = strPtr(accounts[len(accounts)-1].Address)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.AccountsResponse{
+		CurrentRound: round,
+		NextToken:    next,
+		Accounts:     accounts,
+	})
+}
+
+// LookupAccountAppLocalStates returns the application local states of an account.
+// (GET /v2/accounts/{account-id}/apps-local-state)
+func (si *ServerImplementation) LookupAccountAppLocalStates(ctx echo.Context, accountID string, params generated.LookupAccountAppLocalStatesParams) error {
+	if err := si.verifyHandler("lookupAccountAppLocalStates", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	addr, errorArr := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+	if exceedsInt64(params.ApplicationId) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+
+	search := idb.ApplicationQuery{
+		Address:        addr,
+		ApplicationID:  uintOrDefault(params.ApplicationId),
+		IncludeDeleted: boolOrDefault(params.IncludeAll),
+		Limit:          si.applicationsLimit(params.Limit),
+	}
+	var err error
+	search.ApplicationIDGreaterThan, err = decodeNextID(params.Next)
+	if err != nil {
+		return badRequest(ctx, errUnableToParseNext)
+	}
+
+	states, round, err := si.fetchAppLocalStates(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingApplication, err))
+	}
+
+	var next *string
+	if len(states) > 0 {
+		next = strPtr(strconv.FormatUint(states[len(states)-1].Id, 10))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ApplicationLocalStatesResponse{
+		AppsLocalStates: states,
+		CurrentRound:    round,
+		NextToken:       next,
+	})
+}
+
+// SearchForApplications returns applications for the provided parameters.
+// (GET /v2/applications)
+func (si *ServerImplementation) SearchForApplications(ctx echo.Context, params generated.SearchForApplicationsParams) error {
+	if err := si.verifyHandler("searchForApplications", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(params.ApplicationId) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	creator, errorArr := decodeAddress(params.Creator, "creator", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	search := idb.ApplicationQuery{
+		Address:        creator,
+		ApplicationID:  uintOrDefault(params.ApplicationId),
+		IncludeDeleted: boolOrDefault(params.IncludeAll),
+		Limit:          si.applicationsLimit(params.Limit),
+	}
+	var err error
+	search.ApplicationIDGreaterThan, err = decodeNextID(params.Next)
+	if err != nil {
+		return badRequest(ctx, errUnableToParseNext)
+	}
+
+	apps, round, err := si.fetchApplications(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingApplication, err))
+	}
+
+	var next *string
+	if len(apps) > 0 {
+		next = strPtr(strconv.FormatUint(apps[len(apps)-1].Id, 10))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ApplicationsResponse{
+		Applications: apps,
+		CurrentRound: round,
+		NextToken:    next,
+	})
+}
+
+// LookupApplicationByID returns one application for the requested ID.
+// (GET /v2/applications/{application-id})
+func (si *ServerImplementation) LookupApplicationByID(ctx echo.Context, applicationID uint64, params generated.LookupApplicationByIDParams) error {
+	if err := si.verifyHandler("lookupApplicationByID", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&applicationID) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	if applicationID == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoApplicationsFound, applicationID))
+	}
+
+	search := idb.ApplicationQuery{
+		ApplicationID:  applicationID,
+		IncludeDeleted: boolOrDefault(params.IncludeAll),
+		Limit:          1,
+	}
+	apps, round, err := si.fetchApplications(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingApplication, err))
+	}
+
+	if len(apps) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoApplicationsFound, applicationID))
+	}
+	if len(apps) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleApplications, applicationID))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ApplicationResponse{
+		Application:  &apps[0],
+		CurrentRound: round,
+	})
+}
+
+// SearchForAssets returns assets matching the provided parameters.
+// (GET /v2/assets)
+func (si *ServerImplementation) SearchForAssets(ctx echo.Context, params generated.SearchForAssetsParams) error {
+	if err := si.verifyHandler("searchForAssets", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(params.AssetId) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	creator, errorArr := decodeAddress(params.Creator, "creator", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	options := idb.AssetsQuery{
+		AssetID:        uintOrDefault(params.AssetId),
+		Creator:        creator,
+		Name:           strOrDefault(params.Name),
+		Unit:           strOrDefault(params.Unit),
+		IncludeDeleted: boolOrDefault(params.IncludeAll),
+		Limit:          si.assetsLimit(params.Limit),
+	}
+	var err error
+	options.AssetIDGreaterThan, err = decodeNextID(params.Next)
+	if err != nil {
+		return badRequest(ctx, errUnableToParseNext)
+	}
+
+	assets, round, err := si.fetchAssets(ctx.Request().Context(), options)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAsset, err))
+	}
+
+	var next *string
+	if len(assets) > 0 {
+		next = strPtr(strconv.FormatUint(assets[len(assets)-1].Index, 10))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.AssetsResponse{
+		CurrentRound: round,
+		NextToken:    next,
+		Assets:       assets,
+	})
+}
+
+// LookupAssetByID looks up a particular asset.
+// (GET /v2/assets/{asset-id})
+func (si *ServerImplementation) LookupAssetByID(ctx echo.Context, assetID uint64, params generated.LookupAssetByIDParams) error {
+	if err := si.verifyHandler("lookupAssetByID", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&assetID) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	if assetID == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoAssetsFound, assetID))
+	}
+
+	options := idb.AssetsQuery{
+		AssetID:        assetID,
+		IncludeDeleted: boolOrDefault(params.IncludeAll),
+		Limit:          1,
+	}
+	assets, round, err := si.fetchAssets(ctx.Request().Context(), options)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingAsset, err))
+	}
+
+	if len(assets) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoAssetsFound, assetID))
+	}
+	if len(assets) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleAssets, assetID))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.AssetResponse{
+		Asset:        assets[0],
+		CurrentRound: round,
+	})
+}
+
+// SearchForDAOs returns SigmaDAO apps matching the provided parameters.
+// (GET /v2/daos)
+func (si *ServerImplementation) SearchForDAOs(ctx echo.Context, params generated.SearchForDAOsParams) error {
+	if err := si.verifyHandler("searchForDAOs", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(params.ApplicationId, params.GovTokenId) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	creator, errorArr := decodeAddress(params.Creator, "creator", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	search := idb.DAOQuery{
+		ApplicationID: uintOrDefault(params.ApplicationId),
+		Creator:       creator,
+		GovTokenID:    params.GovTokenId,
+		Name:          strOrDefault(params.Name),
+		Version:       strOrDefault(params.Version),
+		Deleted:       deletedFilter(params.IncludeAll),
+		Limit:         si.applicationsLimit(params.Limit),
+	}
+	var err error
+	search.ApplicationIDGreaterThan, err = decodeNextID(params.Next)
+	if err != nil {
+		return badRequest(ctx, errUnableToParseNext)
+	}
+
+	return si.searchDAOs(ctx, search)
+}
+
+// LookupAccountDAOs returns the SigmaDAO apps that an account created, holds a
+// deposit in or has voted in.
+// (GET /v2/accounts/{account-id}/daos)
+func (si *ServerImplementation) LookupAccountDAOs(ctx echo.Context, accountID string, params generated.LookupAccountDAOsParams) error {
+	if err := si.verifyHandler("lookupAccountDAOs", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	addr, errorArr := decodeAddress(&accountID, "account-id", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	search := idb.DAOQuery{
+		Member:  addr,
+		Deleted: deletedFilter(params.IncludeAll),
+		Limit:   si.applicationsLimit(params.Limit),
+	}
+	var err error
+	search.ApplicationIDGreaterThan, err = decodeNextID(params.Next)
+	if err != nil {
+		return badRequest(ctx, errUnableToParseNext)
+	}
+
+	return si.searchDAOs(ctx, search)
+}
+
+// searchDAOs writes the SigmaDAO apps matching `search` with the next token of the
+// following page.
+func (si *ServerImplementation) searchDAOs(ctx echo.Context, search idb.DAOQuery) error {
+	daos, round, err := si.fetchDAOs(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingDAO, err))
+	}
+
+	var next *string
+	if len(daos) > 0 {
+		next = strPtr(strconv.FormatUint(daos[len(daos)-1].Id, 10))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.DAOsResponse{
+		CurrentRound: round,
+		Daos:         daos,
+		NextToken:    next,
+	})
+}
+
+// LookupDAOByID returns one SigmaDAO app for the requested ID.
+// (GET /v2/daos/{application-id})
+func (si *ServerImplementation) LookupDAOByID(ctx echo.Context, applicationID uint64, params generated.LookupDAOByIDParams) error {
+	if err := si.verifyHandler("lookupDAOByID", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&applicationID) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	if applicationID == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoDAOsFound, applicationID))
+	}
+
+	search := idb.DAOQuery{
+		ApplicationID: applicationID,
+		Deleted:       deletedFilter(params.IncludeAll),
+		Limit:         1,
+	}
+	daos, round, err := si.fetchDAOs(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingDAO, err))
+	}
+
+	if len(daos) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %d", errNoDAOsFound, applicationID))
+	}
+	if len(daos) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %d", errMultipleDAOs, applicationID))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.DAOResponse{
+		CurrentRound: round,
+		Dao:          daos[0],
+	})
+}
+
+// LookupDAOProposals returns the proposals of a SigmaDAO app.
+// (GET /v2/daos/{application-id}/proposals)
+func (si *ServerImplementation) LookupDAOProposals(ctx echo.Context, applicationID uint64, params generated.LookupDAOProposalsParams) error {
+	if err := si.verifyHandler("lookupDAOProposals", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&applicationID, params.Type) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	search := idb.ProposalQuery{
+		ApplicationID: applicationID,
+		Type:          params.Type,
+		Deleted:       deletedFilter(params.IncludeAll),
+		Limit:         si.applicationsLimit(params.Limit),
+	}
+	if params.Status != nil {
+		search.Status = idb.ProposalStatus(*params.Status)
+		if !idb.IsProposalStatusValid(search.Status) {
+			return badRequest(ctx, fmt.Sprintf(
+				"%s: %s, expected one of %s",
+				errUnknownProposalStatus, *params.Status, idb.ProposalStatusEnumString))
+		}
+	}
+	if params.Next != nil {
+		addr, errorArr := decodeAddress(params.Next, "next", make([]string, 0))
+		if len(errorArr) != 0 {
+			return badRequest(ctx, errUnableToParseNext)
+		}
+		search.AddressGreaterThan = addr
+	}
+
+	proposals, round, err := si.fetchProposals(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingProposal, err))
+	}
+
+	var next *string
+	if len(proposals) > 0 {
+		next = strPtr(proposals[len(proposals)-1].Address)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ProposalsResponse{
+		CurrentRound: round,
+		NextToken:    next,
+		Proposals:    proposals,
+	})
+}
+
+// LookupDAOProposalByAddress returns one proposal of a SigmaDAO app.
+// (GET /v2/daos/{application-id}/proposals/{proposal-address})
+func (si *ServerImplementation) LookupDAOProposalByAddress(ctx echo.Context, applicationID uint64, proposalAddress string, params generated.LookupDAOProposalByAddressParams) error {
+	if err := si.verifyHandler("lookupDAOProposalByAddress", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&applicationID) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	addr, errorArr := decodeAddress(&proposalAddress, "proposal-address", make([]string, 0))
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	search := idb.ProposalQuery{
+		ApplicationID: applicationID,
+		Address:       addr,
+		Deleted:       deletedFilter(params.IncludeAll),
+		Limit:         1,
+	}
+	proposals, round, err := si.fetchProposals(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingProposal, err))
+	}
+
+	if len(proposals) == 0 {
+		return notFound(ctx, fmt.Sprintf("%s: %s", errNoProposalsFound, proposalAddress))
+	}
+	if len(proposals) > 1 {
+		return indexerError(ctx, fmt.Errorf("%s: %s", errMultipleProposals, proposalAddress))
+	}
+
+	return ctx.JSON(http.StatusOK, generated.ProposalResponse{
+		CurrentRound: round,
+		Proposal:     proposals[0],
+	})
+}
+
+// LookupProposalVotes returns the votes on a proposal of a SigmaDAO app.
+// (GET /v2/daos/{application-id}/proposals/{proposal-address}/votes)
+func (si *ServerImplementation) LookupProposalVotes(ctx echo.Context, applicationID uint64, proposalAddress string, params generated.LookupProposalVotesParams) error {
+	if err := si.verifyHandler("lookupProposalVotes", ctx); err != nil {
+		return badRequest(ctx, err.Error())
+	}
+	if exceedsInt64(&applicationID) {
+		return badRequest(ctx, errValueExceedingInt64)
+	}
+	errorArr := make([]string, 0)
+	var proposal, voter []byte
+	proposal, errorArr = decodeAddress(&proposalAddress, "proposal-address", errorArr)
+	voter, errorArr = decodeAddress(params.Voter, "voter", errorArr)
+	if len(errorArr) != 0 {
+		return badRequest(ctx, errorArr[0])
+	}
+
+	search := idb.VoteQuery{
+		ApplicationID: applicationID,
+		Proposal:      proposal,
+		Voter:         voter,
+		Option:        strOrDefault(params.Option),
+		Deleted:       deletedFilter(params.IncludeAll),
+		Limit:         si.applicationsLimit(params.Limit),
+	}
+	switch search.Option {
+	case "", "yes", "no", "abstain":
+	default:
+		return badRequest(ctx, fmt.Sprintf("%s: %s", errUnknownVoteOption, search.Option))
+	}
+	if params.Next != nil {
+		addr, errorArr := decodeAddress(params.Next, "next", make([]string, 0))
+		if len(errorArr) != 0 {
+			return badRequest(ctx, errUnableToParseNext)
+		}
+		search.VoterGreaterThan = addr
+	}
+
+	votes, round, err := si.fetchVotes(ctx.Request().Context(), search)
+	if err != nil {
+		return indexerError(ctx, fmt.Errorf("%s: %w", errFailedSearchingVote, err))
+	}
+
+	var next *string
+	if len(votes) > 0 {
+		next = strPtr(votes[len(votes)-1].Voter)
+	}
+
+	return ctx.JSON(http.StatusOK, generated.VotesResponse{
+		CurrentRound: round,
+		NextToken:    next,
+		Votes:        votes,
+	})
+}
+
+// LookupAccountAssets is not served.
+// (GET /v2/accounts/{account-id}/assets)
+func (si *ServerImplementation) LookupAccountAssets(ctx echo.Context, accountID string, params generated.LookupAccountAssetsParams) error {
+	return notServed(ctx)
+}
+
+// LookupAccountCreatedApplications is not served.
+// (GET /v2/accounts/{account-id}/created-applications)
+func (si *ServerImplementation) LookupAccountCreatedApplications(ctx echo.Context, accountID string, params generated.LookupAccountCreatedApplicationsParams) error {
+	return notServed(ctx)
+}
+
+// LookupAccountCreatedAssets is not served.
+// (GET /v2/accounts/{account-id}/created-assets)
+func (si *ServerImplementation) LookupAccountCreatedAssets(ctx echo.Context, accountID string, params generated.LookupAccountCreatedAssetsParams) error {
+	return notServed(ctx)
+}
+
+// LookupAccountTransactions is not served.
+// (GET /v2/accounts/{account-id}/transactions)
+func (si *ServerImplementation) LookupAccountTransactions(ctx echo.Context, accountID string, params generated.LookupAccountTransactionsParams) error {
+	return notServed(ctx)
+}
+
+// LookupApplicationLogsByID is not served.
+// (GET /v2/applications/{application-id}/logs)
+func (si *ServerImplementation) LookupApplicationLogsByID(ctx echo.Context, applicationID uint64, params generated.LookupApplicationLogsByIDParams) error {
+	return notServed(ctx)
+}
+
+// LookupAssetBalances is not served.
+// (GET /v2/assets/{asset-id}/balances)
+func (si *ServerImplementation) LookupAssetBalances(ctx echo.Context, assetID uint64, params generated.LookupAssetBalancesParams) error {
+	return notServed(ctx)
+}
+
+// LookupAssetTransactions is not served.
+// (GET /v2/assets/{asset-id}/transactions)
+func (si *ServerImplementation) LookupAssetTransactions(ctx echo.Context, assetID uint64, params generated.LookupAssetTransactionsParams) error {
+	return notServed(ctx)
+}
+
+///////////////////
+// Error Helpers //
+///////////////////
+
+// badRequest is a simple helper to return a 400 error.
+func badRequest(ctx echo.Context, err string) error {
+	return ctx.JSON(http.StatusBadRequest, generated.ErrorResponse{
+		Message: err,
+	})
+}
+
+// indexerError is a simple helper to return a 500 error, or a 503 error if the
+// request timed out.
+func indexerError(ctx echo.Context, err error) error {
+	if errors.Is(err, errTimeout) {
+		return ctx.JSON(http.StatusServiceUnavailable, generated.ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+		Message: err.Error(),
+	})
+}
+
+// notFound is a simple helper to return a 404 error.
+func notFound(ctx echo.Context, err string) error {
+	return ctx.JSON(http.StatusNotFound, generated.ErrorResponse{
+		Message: err,
+	})
+}
+
+// notServed is a simple helper to return a 501 error for the endpoints of the
+// specification that are not served.
+func notServed(ctx echo.Context) error {
+	return ctx.JSON(http.StatusNotImplemented, generated.ErrorResponse{
+		Message: errEndpointNotServed,
+	})
+}
+
+///////////////////////
+// IndexerDb helpers //
+///////////////////////
+
+// callWithTimeout calls `handler` with a context that is canceled after `timeout`, so
+// that database queries stop before the server stops writing the response.
+// errTimeout is returned if the timeout expired.
+func callWithTimeout(ctx context.Context, timeout time.Duration, handler func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := handler(ctx)
+	if (err != nil) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errTimeout
+	}
+	return err
+}
+
+// fetchAccounts queries for accounts and converts them into the API model. The
+// first error is returned once the result channel is drained.
+func (si *ServerImplementation) fetchAccounts(ctx context.Context, options idb.AccountQueryOptions) ([]generated.Account, uint64, error) {
+	accounts := make([]generated.Account, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var accountchan <-chan idb.AccountRow
+		accountchan, round = si.db.GetAccounts(ctx, options)
+
+		var err error
+		for row := range accountchan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			accounts = append(accounts, row.Account)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return accounts, round, nil
+}
+
+// fetchAssets queries for assets and converts them into the API model.
+func (si *ServerImplementation) fetchAssets(ctx context.Context, options idb.AssetsQuery) ([]generated.Asset, uint64, error) {
+	assets := make([]generated.Asset, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var assetchan <-chan idb.AssetRow
+		assetchan, round = si.db.Assets(ctx, options)
+
+		var err error
+		for row := range assetchan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			assets = append(assets, assetRowToAsset(&row))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return assets, round, nil
+}
+
+// fetchApplications queries for applications.
+func (si *ServerImplementation) fetchApplications(ctx context.Context, search idb.ApplicationQuery) ([]generated.Application, uint64, error) {
+	apps := make([]generated.Application, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var appchan <-chan idb.ApplicationRow
+		appchan, round = si.db.Applications(ctx, search)
+
+		var err error
+		for row := range appchan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			apps = append(apps, row.Application)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return apps, round, nil
+}
+
+// fetchAppLocalStates queries for application local states.
+func (si *ServerImplementation) fetchAppLocalStates(ctx context.Context, search idb.ApplicationQuery) ([]generated.ApplicationLocalState, uint64, error) {
+	states := make([]generated.ApplicationLocalState, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var statechan <-chan idb.AppLocalStateRow
+		statechan, round = si.db.AppLocalState(ctx, search)
+
+		var err error
+		for row := range statechan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			states = append(states, row.AppLocalState)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return states, round, nil
+}
+
+// fetchDAOs queries for SigmaDAO apps and converts them into the API model.
+func (si *ServerImplementation) fetchDAOs(ctx context.Context, search idb.DAOQuery) ([]generated.DAO, uint64, error) {
+	daos := make([]generated.DAO, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var daochan <-chan idb.DAORow
+		daochan, round = si.db.DAOs(ctx, search)
+
+		var err error
+		for row := range daochan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			daos = append(daos, daoToModel(&row.DAO))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return daos, round, nil
+}
+
+// fetchProposals queries for SigmaDAO proposals and converts them into the API
+// model.
+func (si *ServerImplementation) fetchProposals(ctx context.Context, search idb.ProposalQuery) ([]generated.Proposal, uint64, error) {
+	proposals := make([]generated.Proposal, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var proposalchan <-chan idb.ProposalRow
+		proposalchan, round = si.db.Proposals(ctx, search)
+
+		var err error
+		for row := range proposalchan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			proposals = append(proposals, proposalToModel(&row.Proposal))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return proposals, round, nil
+}
+
+// fetchVotes queries for the votes on a SigmaDAO proposal and converts them into
+// the API model.
+func (si *ServerImplementation) fetchVotes(ctx context.Context, search idb.VoteQuery) ([]generated.Vote, uint64, error) {
+	votes := make([]generated.Vote, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var votechan <-chan idb.VoteRow
+		votechan, round = si.db.Votes(ctx, search)
+
+		var err error
+		for row := range votechan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			votes = append(votes, voteToModel(&row.Vote))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return votes, round, nil
+}
+
+// fetchDAOEvents queries for SigmaDAO events and converts them into their json
+// form.
+func (si *ServerImplementation) fetchDAOEvents(ctx context.Context, filter idb.DAOEventQuery) ([]idb.DAOEventPayload, uint64, error) {
+	events := make([]idb.DAOEventPayload, 0)
+	var round uint64
+	err := callWithTimeout(ctx, si.timeout, func(ctx context.Context) error {
+		var eventchan <-chan idb.DAOEventRow
+		eventchan, round = si.db.DAOEvents(ctx, filter)
+
+		var err error
+		for row := range eventchan {
+			if row.Error != nil {
+				if err == nil {
+					err = row.Error
+				}
+				continue
+			}
+			events = append(events, row.Event.Payload())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, round, err
+	}
+
+	return events, round, nil
+}
+
+// maxAccountsErrorToAccountsErrorResponse converts the resource limit error of an
+// account query into the response body.
+func (si *ServerImplementation) maxAccountsErrorToAccountsErrorResponse(maxErr idb.MaxAPIResourcesPerAccountError) generated.ErrorResponse {
+	addr := maxErr.Address.String()
+	extraData := map[string]interface{}{
+		"max-results":           si.opts.MaxAPIResourcesPerAccount,
+		"address":               addr,
+		"total-assets-opted-in": maxErr.TotalAssets,
+		"total-created-assets":  maxErr.TotalAssetParams,
+		"total-apps-opted-in":   maxErr.TotalAppLocalStates,
+		"total-created-apps":    maxErr.TotalAppParams,
+	}
+	return generated.ErrorResponse{
+		Message: ErrResultLimitReached,
+		Data:    &extraData,
+	}
+}
@@ -0,0 +1,519 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/api/generated/common"
+	generated "github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/api/middlewares"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/mocks"
+)
+
+var testOptions = ExtraOptions{
+	WriteTimeout:             30 * time.Second,
+	ReadTimeout:              5 * time.Second,
+	MaxAccountsLimit:         1000,
+	DefaultAccountsLimit:     100,
+	MaxAssetsLimit:           1000,
+	DefaultAssetsLimit:       100,
+	MaxApplicationsLimit:     1000,
+	DefaultApplicationsLimit: 100,
+}
+
+func makeTestDb(dbAvailable bool) *mocks.IndexerDb {
+	db := &mocks.IndexerDb{}
+	db.On("Health", mock.Anything).Return(idb.Health{Round: 10, DBAvailable: dbAvailable}, nil)
+	return db
+}
+
+func assetRows(rows ...idb.AssetRow) <-chan idb.AssetRow {
+	ch := make(chan idb.AssetRow, len(rows))
+	for _, row := range rows {
+		ch <- row
+	}
+	close(ch)
+	return ch
+}
+
+func accountRows(rows ...idb.AccountRow) <-chan idb.AccountRow {
+	ch := make(chan idb.AccountRow, len(rows))
+	for _, row := range rows {
+		ch <- row
+	}
+	close(ch)
+	return ch
+}
+
+// serve sends a GET request for `target` to a server using `db` and `options`.
+func serve(t *testing.T, db idb.IndexerDb, options ExtraOptions, target string, header http.Header) *httptest.ResponseRecorder {
+	logger, _ := test.NewNullLogger()
+	e, err := makeEcho(db, nil, logger, options)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHealthCheck(t *testing.T) {
+	db := makeTestDb(true)
+	options := testOptions
+	options.Auth = makeTestAuth(t, idb.APIKey{
+		Name: "secret", Hash: idb.HashAPIKey("secret"), Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic}})
+
+	// The health check is not protected by the API token.
+	rec := serve(t, db, options, "/health", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res common.HealthCheckResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, uint64(10), res.Round)
+	assert.True(t, res.DbAvailable)
+	assert.Nil(t, res.Errors)
+}
+
+func makeTestAuth(t *testing.T, keys ...idb.APIKey) *middlewares.Auth {
+	auth := middlewares.MakeAuth(TokenHeader)
+	require.NoError(t, auth.SetKeys("test", keys))
+	return auth
+}
+
+func TestAuth(t *testing.T) {
+	db := makeTestDb(true)
+	db.On("Assets", mock.Anything, mock.Anything).Return(assetRows(), uint64(10))
+	options := testOptions
+	options.MetricsEndpoint = true
+	options.Auth = makeTestAuth(t,
+		idb.APIKey{
+			Name:   "public",
+			Hash:   idb.HashAPIKey("secret"),
+			Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic},
+		},
+		idb.APIKey{
+			Name:   "metrics",
+			Hash:   idb.HashAPIKey("other"),
+			Scopes: []idb.APIKeyScope{idb.APIKeyScopeMetrics},
+		},
+		idb.APIKey{
+			Name:      "expired",
+			Hash:      idb.HashAPIKey("expired"),
+			Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic},
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+
+	rec := serve(t, db, options, "/v2/assets", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), middlewares.ErrInvalidToken)
+
+	rec = serve(t, db, options, "/v2/assets", http.Header{TokenHeader: {"wrong"}})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(t, db, options, "/v2/assets", http.Header{TokenHeader: {"secret"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(t, db, options, "/v2/assets", http.Header{"Authorization": {"Bearer secret"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(t, db, options, "/v2/assets", http.Header{TokenHeader: {"expired"}})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), middlewares.ErrExpiredToken)
+
+	// Every scope is granted separately.
+	rec = serve(t, db, options, "/v2/assets", http.Header{TokenHeader: {"other"}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), middlewares.ErrMissingScope)
+	rec = serve(t, db, options, "/metrics", http.Header{TokenHeader: {"secret"}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = serve(t, db, options, "/metrics", http.Header{TokenHeader: {"other"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	db := makeTestDb(true)
+	db.On("Assets", mock.Anything, mock.Anything).Return(assetRows(), uint64(10))
+	options := testOptions
+	options.Auth = makeTestAuth(t, idb.APIKey{
+		Name:      "limited",
+		Hash:      idb.HashAPIKey("secret"),
+		Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic},
+		RateLimit: 0.01,
+		Burst:     2,
+	})
+	header := http.Header{TokenHeader: {"secret"}}
+
+	for i := 0; i < 2; i++ {
+		rec := serve(t, db, options, "/v2/assets", header)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	rec := serve(t, db, options, "/v2/assets", header)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Contains(t, rec.Body.String(), middlewares.ErrRateLimited)
+	assert.Equal(t, "100", rec.Header().Get("Retry-After"))
+
+	// Reloading the same key keeps its limiter.
+	require.NoError(t, options.Auth.SetKeys("test", []idb.APIKey{{
+		Name:      "limited",
+		Hash:      idb.HashAPIKey("rotated"),
+		Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic},
+		RateLimit: 0.01,
+		Burst:     2,
+	}}))
+	rec = serve(t, db, options, "/v2/assets", header)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = serve(t, db, options, "/v2/assets", http.Header{TokenHeader: {"rotated"}})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestReloadConfig(t *testing.T) {
+	db := makeTestDb(true)
+	options := testOptions
+	options.Auth = makeTestAuth(t,
+		idb.APIKey{
+			Name:   "public",
+			Hash:   idb.HashAPIKey("secret"),
+			Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic},
+		},
+		idb.APIKey{
+			Name:   "admin",
+			Hash:   idb.HashAPIKey("admin"),
+			Scopes: []idb.APIKeyScope{idb.APIKeyScopeDAOAdmin},
+		})
+	var reasons []string
+	options.Reload = func(reason string) error {
+		reasons = append(reasons, reason)
+		return nil
+	}
+	logger, _ := test.NewNullLogger()
+	e, err := makeEcho(db, nil, logger, options)
+	require.NoError(t, err)
+
+	post := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v2/admin/reload", nil)
+		req.Header.Set(TokenHeader, token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusForbidden, post("secret").Code)
+	assert.Empty(t, reasons)
+	assert.Equal(t, http.StatusNoContent, post("admin").Code)
+	assert.Equal(t, []string{"admin request"}, reasons)
+
+	options.Reload = func(string) error { return errors.New("bad config") }
+	e, err = makeEcho(db, nil, logger, options)
+	require.NoError(t, err)
+	rec := post("admin")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), errFailedReloading)
+}
+
+func TestMigrationMiddleware(t *testing.T) {
+	db := makeTestDb(false)
+
+	rec := serve(t, db, testOptions, "/v2/assets", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), middlewares.DBUnavailableError)
+	db.AssertNotCalled(t, "Assets", mock.Anything, mock.Anything)
+}
+
+func TestSearchForAssetsPagination(t *testing.T) {
+	db := makeTestDb(true)
+	expected := idb.AssetsQuery{
+		AssetIDGreaterThan: 5,
+		Name:               "dao",
+		Limit:              testOptions.MaxAssetsLimit,
+	}
+	db.On("Assets", mock.Anything, expected).Return(
+		assetRows(idb.AssetRow{AssetID: 6}, idb.AssetRow{AssetID: 9}), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/assets?next=5&name=dao&limit=5000", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res generated.AssetsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, uint64(10), res.CurrentRound)
+	require.Len(t, res.Assets, 2)
+	require.NotNil(t, res.NextToken)
+	assert.Equal(t, "9", *res.NextToken)
+	db.AssertExpectations(t)
+
+	rec = serve(t, db, testOptions, "/v2/assets?next=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errUnableToParseNext)
+}
+
+func TestLookupAssetByIDNotFound(t *testing.T) {
+	db := makeTestDb(true)
+	db.On("Assets", mock.Anything, mock.Anything).Return(assetRows(), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/assets/7", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), errNoAssetsFound)
+}
+
+func TestAccountRound(t *testing.T) {
+	address := basics.Address{1}
+	db := makeTestDb(true)
+	db.On("GetAccounts", mock.Anything, mock.Anything).Return(
+		func(context.Context, idb.AccountQueryOptions) <-chan idb.AccountRow {
+			return accountRows(idb.AccountRow{Account: generated.Account{Address: address.String()}})
+		},
+		uint64(10))
+
+	// Searching at a round requires developer mode.
+	rec := serve(t, db, testOptions, "/v2/accounts?round=10", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errMultiAcctRewind)
+
+	options := testOptions
+	options.DeveloperMode = true
+	rec = serve(t, db, options, "/v2/accounts?round=10", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Accounts can not be rewound.
+	rec = serve(t, db, testOptions, "/v2/accounts/"+address.String()+"?round=9", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errRewindNotSupported)
+
+	rec = serve(t, db, testOptions, "/v2/accounts/"+address.String()+"?round=10", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res generated.AccountResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, address.String(), res.Account.Address)
+}
+
+func TestAccountResourceLimit(t *testing.T) {
+	address := basics.Address{1}
+	db := makeTestDb(true)
+	maxErr := idb.MaxAPIResourcesPerAccountError{Address: address, TotalAssets: 3}
+	db.On("GetAccounts", mock.Anything, mock.Anything).Return(
+		accountRows(idb.AccountRow{Error: maxErr}), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/accounts/"+address.String(), nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var res generated.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, ErrResultLimitReached, res.Message)
+	require.NotNil(t, res.Data)
+	assert.Equal(t, address.String(), (*res.Data)["address"])
+}
+
+func TestNotServed(t *testing.T) {
+	db := makeTestDb(true)
+
+	rec := serve(t, db, testOptions, "/v2/assets/7/balances", nil)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	assert.Contains(t, rec.Body.String(), errEndpointNotServed)
+}
+
+func TestCallWithTimeout(t *testing.T) {
+	err := callWithTimeout(context.Background(), time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.Equal(t, errTimeout, err)
+
+	// A zero timeout does not set a deadline.
+	err = callWithTimeout(context.Background(), 0, func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestSearchForDAOs(t *testing.T) {
+	db := makeTestDb(true)
+	notDeleted := false
+	expected := idb.DAOQuery{
+		ApplicationIDGreaterThan: 3,
+		Name:                     "red",
+		Deleted:                  &notDeleted,
+		Limit:                    testOptions.DefaultApplicationsLimit,
+	}
+	rows := make(chan idb.DAORow, 2)
+	rows <- idb.DAORow{DAO: idb.DAO{AppID: 4, Version: "v1"}}
+	rows <- idb.DAORow{DAO: idb.DAO{AppID: 8, Deleted: true}}
+	close(rows)
+	db.On("DAOs", mock.Anything, expected).Return((<-chan idb.DAORow)(rows), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/daos?next=3&name=red", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res generated.DAOsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Daos, 2)
+	assert.Equal(t, uint64(4), res.Daos[0].Id)
+	require.NotNil(t, res.Daos[0].Version)
+	assert.Equal(t, "v1", *res.Daos[0].Version)
+	require.NotNil(t, res.NextToken)
+	assert.Equal(t, "8", *res.NextToken)
+	db.AssertExpectations(t)
+}
+
+func TestLookupAccountDAOs(t *testing.T) {
+	address := basics.Address{1}
+	db := makeTestDb(true)
+	expected := idb.DAOQuery{
+		Member: address[:],
+		Limit:  testOptions.DefaultApplicationsLimit,
+	}
+	rows := make(chan idb.DAORow)
+	close(rows)
+	db.On("DAOs", mock.Anything, expected).Return((<-chan idb.DAORow)(rows), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/accounts/"+address.String()+"/daos?include-all=true", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	db.AssertExpectations(t)
+
+	rec = serve(t, db, testOptions, "/v2/accounts/abc/daos", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLookupDAOProposals(t *testing.T) {
+	proposal := basics.Address{2}
+	db := makeTestDb(true)
+	proposalType := uint64(3)
+	notDeleted := false
+	expected := idb.ProposalQuery{
+		ApplicationID: 5,
+		Status:        idb.ProposalVoting,
+		Type:          &proposalType,
+		Deleted:       &notDeleted,
+		Limit:         testOptions.DefaultApplicationsLimit,
+	}
+	rows := make(chan idb.ProposalRow, 1)
+	rows <- idb.ProposalRow{Proposal: idb.Proposal{
+		AppID: 5, Address: proposal, Status: idb.ProposalVoting, Yes: 7}}
+	close(rows)
+	db.On("Proposals", mock.Anything, expected).Return((<-chan idb.ProposalRow)(rows), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/daos/5/proposals?status=voting&type=3", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res generated.ProposalsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Proposals, 1)
+	assert.Equal(t, proposal.String(), res.Proposals[0].Address)
+	assert.Equal(t, uint64(7), res.Proposals[0].Yes)
+	require.NotNil(t, res.Proposals[0].Status)
+	assert.Equal(t, "voting", *res.Proposals[0].Status)
+	require.NotNil(t, res.NextToken)
+	assert.Equal(t, proposal.String(), *res.NextToken)
+	db.AssertExpectations(t)
+
+	rec = serve(t, db, testOptions, "/v2/daos/5/proposals?status=open", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errUnknownProposalStatus)
+}
+
+func TestLookupDAOProposalByAddressNotFound(t *testing.T) {
+	proposal := basics.Address{2}
+	db := makeTestDb(true)
+	rows := make(chan idb.ProposalRow)
+	close(rows)
+	db.On("Proposals", mock.Anything, mock.Anything).Return((<-chan idb.ProposalRow)(rows), uint64(10))
+
+	rec := serve(t, db, testOptions, "/v2/daos/5/proposals/"+proposal.String(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), errNoProposalsFound)
+}
+
+func TestLookupProposalVotes(t *testing.T) {
+	proposal := basics.Address{2}
+	voter := basics.Address{3}
+	db := makeTestDb(true)
+	expected := idb.VoteQuery{
+		ApplicationID:    5,
+		Proposal:         proposal[:],
+		Option:           "yes",
+		VoterGreaterThan: voter[:],
+		Limit:            2,
+	}
+	option := "yes"
+	rows := make(chan idb.VoteRow, 1)
+	rows <- idb.VoteRow{Vote: idb.Vote{
+		AppID: 5, Proposal: proposal, Voter: basics.Address{4}, Option: &option, Round: 9}}
+	close(rows)
+	db.On("Votes", mock.Anything, expected).Return((<-chan idb.VoteRow)(rows), uint64(10))
+
+	target := "/v2/daos/5/proposals/" + proposal.String() +
+		"/votes?option=yes&include-all=true&limit=2&next=" + voter.String()
+	rec := serve(t, db, testOptions, target, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res generated.VotesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Votes, 1)
+	assert.Equal(t, basics.Address{4}.String(), res.Votes[0].Voter)
+	assert.Equal(t, uint64(9), res.Votes[0].Round)
+	require.NotNil(t, res.NextToken)
+	assert.Equal(t, basics.Address{4}.String(), *res.NextToken)
+	db.AssertExpectations(t)
+
+	rec = serve(t, db, testOptions, "/v2/daos/5/proposals/"+proposal.String()+"/votes?option=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errUnknownVoteOption)
+}
+
+func TestDisabledParameters(t *testing.T) {
+	db := makeTestDb(true)
+	db.On("Assets", mock.Anything, mock.Anything).Return(assetRows(), uint64(10))
+	options := testOptions
+	options.DisabledMapConfig = GetDefaultDisabledMapConfigForPostgres()
+
+	rec := serve(t, db, options, "/v2/assets?name=dao", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errDisabledParameter+": name")
+	db.AssertNotCalled(t, "Assets", mock.Anything, mock.Anything)
+
+	rec = serve(t, db, options, "/v2/assets?limit=5", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDisabledEndpoint(t *testing.T) {
+	db := makeTestDb(true)
+	options := testOptions
+	options.DisabledMapConfig = MakeDisabledMapConfig()
+	options.DisabledMapConfig.addEntry("/v2/daos/{application-id}/proposals", http.MethodGet, []string{"application-id"})
+
+	rec := serve(t, db, options, "/v2/daos/5/proposals", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errDisabledEndpoint)
+	db.AssertNotCalled(t, "Proposals", mock.Anything, mock.Anything)
+}
+
+func TestInvalidDisabledMapConfig(t *testing.T) {
+	db := makeTestDb(true)
+	logger, _ := test.NewNullLogger()
+	options := testOptions
+	options.DisabledMapConfig = MakeDisabledMapConfig()
+	options.DisabledMapConfig.addEntry("/v2/daos", http.MethodGet, []string{"unknown"})
+
+	_, err := makeEcho(db, nil, logger, options)
+	assert.Error(t, err)
+
+	// The default configuration matches the specification.
+	options.DisabledMapConfig = GetDefaultDisabledMapConfigForPostgres()
+	_, err = makeEcho(db, nil, logger, options)
+	assert.NoError(t, err)
+}
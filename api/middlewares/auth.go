@@ -0,0 +1,265 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/util/metrics"
+)
+
+// Errors returned by the auth middleware.
+var (
+	// ErrInvalidToken is the error returned when the API token is missing or wrong.
+	ErrInvalidToken = "Invalid API Token"
+	// ErrExpiredToken is the error returned when the API token expired.
+	ErrExpiredToken = "Expired API Token"
+	// ErrMissingScope is the error returned when the API token does not grant access
+	// to the endpoint.
+	ErrMissingScope = "API Token Lacks The Required Scope"
+	// ErrRateLimited is the error returned when the API token made too many requests.
+	ErrRateLimited = "API Token Rate Limit Exceeded"
+)
+
+const bearerPrefix = "Bearer "
+
+// Results of the authenticated requests, counted by the api_key_requests metric.
+const (
+	resultAccepted    = "accepted"
+	resultInvalid     = "invalid"
+	resultExpired     = "expired"
+	resultForbidden   = "forbidden"
+	resultRateLimited = "rate_limited"
+)
+
+// Auth checks the API keys of the requests. The keys are grouped by source, a
+// config file or a database table, and every source can be set, replaced or removed
+// while the server runs. The API is open while no source is set, except for the
+// dao-admin scope which always requires a key.
+type Auth struct {
+	header string
+	// now is the clock, replaced by tests.
+	now func() time.Time
+
+	// Protects the fields below.
+	mu      sync.RWMutex
+	sources map[string][]idb.APIKey
+	// keys are the keys of every source by hash.
+	keys map[[sha256.Size]byte]*authKey
+}
+
+// authKey is an accepted key and its rate limiter, nil if unlimited.
+type authKey struct {
+	key     idb.APIKey
+	limiter *rateLimiter
+}
+
+// MakeAuth constructs the auth middleware. Requests must provide a key in the
+// `header` header or as a bearer token in the Authorization header. The API is open
+// until SetKeys() is called.
+func MakeAuth(header string) *Auth {
+	return &Auth{
+		header:  header,
+		now:     time.Now,
+		sources: make(map[string][]idb.APIKey),
+		keys:    make(map[[sha256.Size]byte]*authKey),
+	}
+}
+
+// SetKeys replaces the keys of `source`. The rate limiter state of the keys that keep
+// their name and limits is preserved. An error is returned and the current keys are
+// kept if a name or a key is used twice across the sources.
+func (a *Auth) SetKeys(source string, keys []idb.APIKey) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sources := make(map[string][]idb.APIKey, len(a.sources)+1)
+	for s, k := range a.sources {
+		sources[s] = k
+	}
+	sources[source] = keys
+	err := a.setSources(sources)
+	if err != nil {
+		return fmt.Errorf("SetKeys() err: %w", err)
+	}
+	return nil
+}
+
+// RemoveKeys removes `source` and its keys. The API is open once every source is
+// removed.
+func (a *Auth) RemoveKeys(source string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sources := make(map[string][]idb.APIKey, len(a.sources))
+	for s, k := range a.sources {
+		if s != source {
+			sources[s] = k
+		}
+	}
+	// The remaining sources were checked when they were set.
+	a.setSources(sources)
+}
+
+// Open returns true if no source of keys is set.
+func (a *Auth) Open() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.sources) == 0
+}
+
+// setSources replaces the keys with the keys of `sources`. It must be called with
+// `mu` locked.
+func (a *Auth) setSources(sources map[string][]idb.APIKey) error {
+	limiters := make(map[string]*authKey, len(a.keys))
+	for _, k := range a.keys {
+		limiters[k.key.Name] = k
+	}
+
+	res := make(map[[sha256.Size]byte]*authKey)
+	names := make(map[string]struct{})
+	for s, sourceKeys := range sources {
+		for _, key := range sourceKeys {
+			if _, ok := names[key.Name]; ok {
+				return fmt.Errorf("setSources() duplicate key name %s in %s", key.Name, s)
+			}
+			names[key.Name] = struct{}{}
+			if _, ok := res[key.Hash]; ok {
+				return fmt.Errorf("setSources() key %s of %s is already used", key.Name, s)
+			}
+
+			entry := &authKey{key: key}
+			if old, ok := limiters[key.Name]; ok && old.limiter != nil &&
+				old.key.RateLimit == key.RateLimit && old.key.Burst == key.Burst {
+				entry.limiter = old.limiter
+			} else if key.RateLimit > 0 {
+				entry.limiter = makeRateLimiter(key.RateLimit, key.Burst, a.now())
+			}
+			res[key.Hash] = entry
+		}
+	}
+
+	a.sources = sources
+	a.keys = res
+	return nil
+}
+
+// lookup returns the key with the hash of `token`, nil if there is none.
+func (a *Auth) lookup(token string) *authKey {
+	// The keys are looked up by hash, which does not leak the keys through timing.
+	hash := idb.HashAPIKey(token)
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.keys[hash]
+}
+
+// token returns the API token of the request, empty if there is none.
+func (a *Auth) token(ctx echo.Context) string {
+	header := ctx.Request().Header
+	if token := header.Get(a.header); token != "" {
+		return token
+	}
+	if auth := header.Get(echo.HeaderAuthorization); strings.HasPrefix(auth, bearerPrefix) {
+		return auth[len(bearerPrefix):]
+	}
+	return ""
+}
+
+// Require returns a middleware which rejects the requests without a valid key
+// granting `scope`, or going over the rate limit of their key. While the API is
+// open, only the requests of the dao-admin scope are rejected.
+func (a *Auth) Require(scope idb.APIKeyScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if a.Open() {
+				if scope == idb.APIKeyScopeDAOAdmin {
+					return echo.NewHTTPError(http.StatusUnauthorized, ErrInvalidToken)
+				}
+				return next(ctx)
+			}
+
+			token := a.token(ctx)
+			var k *authKey
+			if token != "" {
+				k = a.lookup(token)
+			}
+			if k == nil {
+				metrics.APIKeyRequests.WithLabelValues("", resultInvalid).Inc()
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrInvalidToken)
+			}
+
+			name := k.key.Name
+			now := a.now()
+			if !k.key.ExpiresAt.IsZero() && !now.Before(k.key.ExpiresAt) {
+				metrics.APIKeyRequests.WithLabelValues(name, resultExpired).Inc()
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrExpiredToken)
+			}
+			if !k.key.HasScope(scope) {
+				metrics.APIKeyRequests.WithLabelValues(name, resultForbidden).Inc()
+				return echo.NewHTTPError(http.StatusForbidden, ErrMissingScope)
+			}
+			if k.limiter != nil {
+				if wait := k.limiter.take(now); wait > 0 {
+					seconds := int(math.Ceil(wait.Seconds()))
+					ctx.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+					metrics.APIKeyRequests.WithLabelValues(name, resultRateLimited).Inc()
+					return echo.NewHTTPError(http.StatusTooManyRequests, ErrRateLimited)
+				}
+			}
+
+			metrics.APIKeyRequests.WithLabelValues(name, resultAccepted).Inc()
+			return next(ctx)
+		}
+	}
+}
+
+// rateLimiter is a token bucket refilled at `rate` tokens per second, holding up
+// to `burst` tokens.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	// Protects the fields below.
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func makeRateLimiter(rate float64, burst uint32, now time.Time) *rateLimiter {
+	b := float64(burst)
+	if burst == 0 {
+		b = math.Ceil(rate)
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  b,
+		tokens: b,
+		last:   now,
+	}
+}
+
+// take takes a token from the bucket. If the bucket is empty, it returns how long
+// until a token is available, zero otherwise.
+func (l *rateLimiter) take(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
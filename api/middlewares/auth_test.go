@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := makeRateLimiter(2, 0, now)
+
+	// The burst defaults to one second of requests.
+	assert.Zero(t, l.take(now))
+	assert.Zero(t, l.take(now))
+	assert.Equal(t, 500*time.Millisecond, l.take(now))
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, l.take(now))
+	now = now.Add(250 * time.Millisecond)
+	assert.Zero(t, l.take(now))
+
+	// The bucket does not fill past the burst.
+	now = now.Add(time.Hour)
+	assert.Zero(t, l.take(now))
+	assert.Zero(t, l.take(now))
+	assert.NotZero(t, l.take(now))
+}
+
+func TestSetKeys(t *testing.T) {
+	auth := MakeAuth("X-Token")
+	a := idb.APIKey{Name: "a", Hash: idb.HashAPIKey("a"), RateLimit: 1}
+	b := idb.APIKey{Name: "b", Hash: idb.HashAPIKey("b")}
+
+	require.NoError(t, auth.SetKeys("config", []idb.APIKey{a}))
+	require.NoError(t, auth.SetKeys("database", []idb.APIKey{b}))
+	require.NotNil(t, auth.lookup("a"))
+	require.NotNil(t, auth.lookup("b"))
+	assert.Nil(t, auth.lookup("c"))
+	assert.Nil(t, auth.lookup("b").limiter)
+
+	// Names and keys are unique across the sources.
+	dup := b
+	dup.Hash = idb.HashAPIKey("c")
+	assert.Error(t, auth.SetKeys("config", []idb.APIKey{a, dup}))
+	dup = a
+	dup.Name = "c"
+	assert.Error(t, auth.SetKeys("database", []idb.APIKey{b, dup}))
+	assert.Nil(t, auth.lookup("c"))
+
+	// The limiter is kept along with the limits.
+	limiter := auth.lookup("a").limiter
+	require.NoError(t, auth.SetKeys("config", []idb.APIKey{a}))
+	assert.Same(t, limiter, auth.lookup("a").limiter)
+	a.RateLimit = 2
+	require.NoError(t, auth.SetKeys("config", []idb.APIKey{a}))
+	assert.NotSame(t, limiter, auth.lookup("a").limiter)
+
+	// Removing a source removes its keys.
+	require.NoError(t, auth.SetKeys("database", nil))
+	assert.Nil(t, auth.lookup("b"))
+}
+
+func TestOpen(t *testing.T) {
+	auth := MakeAuth("X-Token")
+	serve := func(scope idb.APIKeyScope, token string) int {
+		e := echo.New()
+		e.GET("/", func(ctx echo.Context) error {
+			return ctx.NoContent(http.StatusNoContent)
+		}, auth.Require(scope))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			req.Header.Set("X-Token", token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Without a source, only the dao-admin scope requires a key.
+	assert.True(t, auth.Open())
+	assert.Equal(t, http.StatusNoContent, serve(idb.APIKeyScopePublic, ""))
+	assert.Equal(t, http.StatusUnauthorized, serve(idb.APIKeyScopeDAOAdmin, ""))
+
+	// A source without keys locks the API.
+	require.NoError(t, auth.SetKeys("config", nil))
+	assert.False(t, auth.Open())
+	assert.Equal(t, http.StatusUnauthorized, serve(idb.APIKeyScopePublic, ""))
+
+	a := idb.APIKey{Name: "a", Hash: idb.HashAPIKey("a"), Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic}}
+	require.NoError(t, auth.SetKeys("database", []idb.APIKey{a}))
+	assert.Equal(t, http.StatusNoContent, serve(idb.APIKeyScopePublic, "a"))
+
+	auth.RemoveKeys("database")
+	assert.False(t, auth.Open())
+	assert.Equal(t, http.StatusUnauthorized, serve(idb.APIKeyScopePublic, "a"))
+	auth.RemoveKeys("config")
+	assert.True(t, auth.Open())
+	assert.Equal(t, http.StatusNoContent, serve(idb.APIKeyScopePublic, ""))
+}
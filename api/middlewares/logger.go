@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// LoggerMiddleware logs every request with its status and latency.
+type LoggerMiddleware struct {
+	log *log.Logger
+}
+
+// MakeLogger constructs the logger middleware.
+func MakeLogger(log *log.Logger) echo.MiddlewareFunc {
+	mw := LoggerMiddleware{
+		log: log,
+	}
+
+	return mw.handler
+}
+
+func (lm *LoggerMiddleware) handler(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		start := time.Now()
+
+		err := next(ctx)
+		if err != nil {
+			// Let echo write the error response so that the status is known.
+			ctx.Error(err)
+		}
+
+		req := ctx.Request()
+		res := ctx.Response()
+		lm.log.WithFields(log.Fields{
+			"method":  req.Method,
+			"uri":     req.RequestURI,
+			"remote":  ctx.RealIP(),
+			"status":  res.Status,
+			"bytes":   res.Size,
+			"latency": time.Since(start).String(),
+		}).Info("served request")
+
+		// The error was already handled.
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/algorand/indexer/api/generated/common"
+	generated "github.com/algorand/indexer/api/generated/v2"
+	"github.com/algorand/indexer/api/middlewares"
+	"github.com/algorand/indexer/idb"
+)
+
+// TokenHeader is the header that can hold the API token, instead of the
+// Authorization header.
+const TokenHeader = "X-Indexer-API-Token"
+
+// shutdownTimeout is how long in-flight requests have to finish once the server
+// is stopped.
+const shutdownTimeout = 5 * time.Second
+
+// ExtraOptions are options which change the behavior or the HTTP server.
+type ExtraOptions struct {
+	// Auth checks the API keys and their scopes, the API is open while it has no
+	// key source. If nil, the API is open and the administration endpoints are not
+	// served.
+	Auth *middlewares.Auth
+
+	// MetricsEndpoint enables the /metrics endpoint.
+	MetricsEndpoint bool
+
+	// Reload reloads the configuration of the daemon. It is called by the reload
+	// endpoint, which is not served if nil.
+	Reload func(reason string) error
+
+	// DeveloperMode enables performance intensive operations, like searching for
+	// accounts at a particular round.
+	DeveloperMode bool
+
+	// WriteTimeout is the maximum duration before timing out writes of a response.
+	// The handler timeout is computed off of it.
+	WriteTimeout time.Duration
+
+	// ReadTimeout is the maximum duration for reading the entire request, including
+	// the body.
+	ReadTimeout time.Duration
+
+	// MaxAPIResourcesPerAccount is the maximum number of combined AppParams,
+	// AppLocalState, AssetParams and AssetHolding resources per address that can be
+	// returned by the /v2/accounts endpoints. If an address exceeds this number, a
+	// 400 error is returned. Zero means unlimited.
+	MaxAPIResourcesPerAccount uint64
+
+	// Accounts
+	MaxAccountsLimit     uint64
+	DefaultAccountsLimit uint64
+
+	// Assets
+	MaxAssetsLimit     uint64
+	DefaultAssetsLimit uint64
+
+	// Applications, also used for application local states and the SigmaDAO
+	// endpoints.
+	MaxApplicationsLimit     uint64
+	DefaultApplicationsLimit uint64
+
+	// DisabledMapConfig is the configuration of the disabled parameters. If nil, every
+	// parameter is enabled.
+	DisabledMapConfig *DisabledMapConfig
+
+	// DAOEventHub publishes the SigmaDAO events of the committed rounds to the event
+	// streams. If nil, the streams are not served.
+	DAOEventHub *idb.DAOEventHub
+}
+
+// handlerTimeout returns the timeout of database queries, which must end before the
+// server stops writing the response.
+func (e ExtraOptions) handlerTimeout() time.Duration {
+	// If the write timeout is 2 seconds or greater, subtract a second. Otherwise
+	// subtract 10% as a safety valve.
+	if e.WriteTimeout >= 2*time.Second {
+		return e.WriteTimeout - time.Second
+	}
+
+	return e.WriteTimeout - time.Duration(0.1*float64(e.WriteTimeout))
+}
+
+// makeEcho creates the echo instance serving the API. An error is returned if the
+// disabled parameters configuration doesn't match the specification.
+func makeEcho(db idb.IndexerDb, fetcherError error, log *log.Logger, options ExtraOptions) (*echo.Echo, error) {
+	swag, err := generated.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("makeEcho() err: %w", err)
+	}
+	disabledMap, err := MakeDisabledMapFromOA3(swag, options.DisabledMapConfig)
+	if err != nil {
+		return nil, fmt.Errorf("makeEcho() disabled parameters err: %w", err)
+	}
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	e.Use(middlewares.MakeLogger(log))
+
+	migration := middlewares.MakeMigrationMiddleware(db)
+	// require returns the middleware of the endpoints granted by `scope`.
+	require := func(scope idb.APIKeyScope) []echo.MiddlewareFunc {
+		if options.Auth == nil {
+			return nil
+		}
+		return []echo.MiddlewareFunc{options.Auth.Require(scope)}
+	}
+	middleware := append([]echo.MiddlewareFunc{migration}, require(idb.APIKeyScopePublic)...)
+
+	api := ServerImplementation{
+		EnableAddressSearchRoundRewind: options.DeveloperMode,
+		db:                             db,
+		fetcher:                        fetcherError,
+		timeout:                        options.handlerTimeout(),
+		log:                            log,
+		opts:                           options,
+		disabledParams:                 disabledMap,
+	}
+
+	generated.RegisterHandlers(e, &api, middleware...)
+	// The SigmaDAO event streams are excluded from the generated code by their
+	// `stream` tag.
+	e.GET("/v2/daos/events", api.StreamDAOEvents, middleware...)
+	e.GET("/v2/daos/events/ws", api.StreamDAOEventsWebSocket, middleware...)
+	if options.Auth != nil {
+		e.POST("/v2/admin/reload", api.ReloadConfig,
+			append([]echo.MiddlewareFunc{migration}, require(idb.APIKeyScopeDAOAdmin)...)...)
+	}
+	if options.MetricsEndpoint {
+		e.GET("/metrics", echo.WrapHandler(promhttp.Handler()), require(idb.APIKeyScopeMetrics)...)
+	}
+	// The health check is available without a token and during migrations.
+	common.RegisterHandlers(e, &api)
+
+	return e, nil
+}
+
+// Serve starts an http server for the indexer API. It blocks until `ctx` is done and
+// the server is shut down. `fetcherError` reports the block fetcher errors in the
+// health check, it may be nil.
+func Serve(ctx context.Context, serveAddr string, db idb.IndexerDb, fetcherError error, log *log.Logger, options ExtraOptions) error {
+	e, err := makeEcho(db, fetcherError, log, options)
+	if err != nil {
+		return fmt.Errorf("Serve() err: %w", err)
+	}
+
+	s := &http.Server{
+		Addr:           serveAddr,
+		ReadTimeout:    options.ReadTimeout,
+		WriteTimeout:   options.WriteTimeout,
+		MaxHeaderBytes: 1 << 20,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- e.StartServer(s)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("Serve() err: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Info("shutting down the api server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("Serve() shutdown err: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("Serve() err: %w", err)
+	}
+	return nil
+}
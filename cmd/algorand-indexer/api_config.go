@@ -4,38 +4,60 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
+
 	"github.com/algorand/indexer/api"
+	generated "github.com/algorand/indexer/api/generated/v2"
 	"github.com/algorand/indexer/config"
-	"github.com/spf13/cobra"
 )
 
 var (
 	suppliedAPIConfigFile string
 	showAllDisabled       bool
+	apiConfigDataDir      string
+	apiConfigEnableAll    bool
 )
 
 var apiConfigCmd = &cobra.Command{
 	Use:   "api-config",
 	Short: "api configuration",
-	Long:  "api configuration",
+	Long:  "show the api parameter configuration used by the daemon. Only the disabled parameters are shown unless --all is provided.",
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 		config.BindFlagSet(cmd.Flags())
+		// Keep standard out for the configuration.
+		logger.SetOutput(os.Stderr)
 		err = configureLogger()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "failed to configure logger: %v", err)
 			panic(exit{1})
 		}
 
+		cfg := &daemonConfig{
+			indexerDataDir:        apiConfigDataDir,
+			suppliedAPIConfigFile: suppliedAPIConfigFile,
+			enableAllParameters:   apiConfigEnableAll,
+		}
+		if cfg.indexerDataDir != "" {
+			if err = loadIndexerParamConfig(cfg); err != nil {
+				panic(exit{1})
+			}
+		}
+
+		swag, err := generated.GetSwagger()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "failed to get swagger: %v", err)
 			panic(exit{1})
 		}
 
-		var displayDisabledMapConfig *api.DisplayDisabledMap
-
-		output, err := displayDisabledMapConfig.String()
+		disabledMapConfig, err := makeDisabledMapConfig(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load api config: %v", err)
+			panic(exit{1})
+		}
 
+		displayDisabledMap := api.MakeDisplayDisabledMapFromConfig(swag, disabledMapConfig, !showAllDisabled)
+		output, err := displayDisabledMap.String()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "failed to output yaml: %v", err)
 			panic(exit{1})
@@ -43,6 +65,35 @@ var apiConfigCmd = &cobra.Command{
 
 		fmt.Fprint(os.Stdout, output)
 		panic(exit{0})
-
 	},
 }
+
+// makeDisabledMapConfig returns the api parameter configuration of `cfg`. Every
+// parameter is enabled with --enable-all-parameters, otherwise the supplied (or
+// auto-loaded) configuration file is used, falling back to the default
+// configuration.
+func makeDisabledMapConfig(cfg *daemonConfig) (*api.DisabledMapConfig, error) {
+	if cfg.enableAllParameters {
+		return api.MakeDisabledMapConfig(), nil
+	}
+	if cfg.suppliedAPIConfigFile == "" {
+		return api.GetDefaultDisabledMapConfigForPostgres(), nil
+	}
+
+	swag, err := generated.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("makeDisabledMapConfig() err: %w", err)
+	}
+	disabledMapConfig, err := api.MakeDisabledMapConfigFromFile(swag, cfg.suppliedAPIConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("makeDisabledMapConfig() file %s err: %w", cfg.suppliedAPIConfigFile, err)
+	}
+	return disabledMapConfig, nil
+}
+
+func init() {
+	apiConfigCmd.Flags().BoolVar(&showAllDisabled, "all", false, "show all api parameters, enabled and disabled")
+	apiConfigCmd.Flags().StringVar(&suppliedAPIConfigFile, "api-config-file", "", "supply an API config file to enable/disable parameters")
+	apiConfigCmd.Flags().BoolVar(&apiConfigEnableAll, "enable-all-parameters", false, "override default configuration and enable all parameters. Can't be used with --api-config-file")
+	apiConfigCmd.Flags().StringVarP(&apiConfigDataDir, "data-dir", "i", "", "path to indexer data dir, or $INDEXER_DATA. An api_config file in it is loaded like the daemon does")
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/algorand/indexer/api"
+	"github.com/algorand/indexer/api/middlewares"
+	"github.com/algorand/indexer/idb"
+)
+
+// apiKeysConfigKey is the indexer.yml section listing the REST API keys:
+//
+//	api-keys:
+//	  - name: frontend
+//	    key: <secret>
+//	    scopes: [public]
+//	    rate-limit: 20
+//	    burst: 50
+//	    expires: 2027-01-01T00:00:00Z
+//
+// `rate-limit` is in requests per second. Omitting it, or `expires`, means no limit
+// or no expiry.
+const apiKeysConfigKey = "api-keys"
+
+// Sources of the API keys.
+const (
+	apiKeysConfigSource = "config"
+	apiKeysTableSource  = "api_key table"
+)
+
+// apiTokenKeyName is the name of the key given with the deprecated --token.
+const apiTokenKeyName = "token"
+
+type apiKeyConfig struct {
+	Name      string   `mapstructure:"name"`
+	Key       string   `mapstructure:"key"`
+	Scopes    []string `mapstructure:"scopes"`
+	RateLimit float64  `mapstructure:"rate-limit"`
+	Burst     uint32   `mapstructure:"burst"`
+	Expires   string   `mapstructure:"expires"`
+}
+
+// loadAPIKeys reads the API keys from the config `v`. `ok` is false if the config has
+// no api-keys section.
+func loadAPIKeys(v *viper.Viper) (keys []idb.APIKey, ok bool, err error) {
+	if !v.IsSet(apiKeysConfigKey) {
+		return nil, false, nil
+	}
+
+	var configs []apiKeyConfig
+	err = v.UnmarshalKey(apiKeysConfigKey, &configs)
+	if err != nil {
+		return nil, false, fmt.Errorf("loadAPIKeys() unable to parse %s err: %w", apiKeysConfigKey, err)
+	}
+
+	keys = make([]idb.APIKey, 0, len(configs))
+	for i, config := range configs {
+		if config.Name == "" {
+			return nil, false, fmt.Errorf("loadAPIKeys() key %d has no name", i)
+		}
+		if config.Key == "" {
+			return nil, false, fmt.Errorf("loadAPIKeys() key %s is empty", config.Name)
+		}
+		if len(config.Scopes) == 0 {
+			return nil, false, fmt.Errorf("loadAPIKeys() key %s has no scopes", config.Name)
+		}
+		if config.RateLimit < 0 {
+			return nil, false, fmt.Errorf("loadAPIKeys() key %s has a negative rate limit", config.Name)
+		}
+
+		key := idb.APIKey{
+			Name:      config.Name,
+			Hash:      idb.HashAPIKey(config.Key),
+			RateLimit: config.RateLimit,
+			Burst:     config.Burst,
+		}
+		for _, s := range config.Scopes {
+			scope := idb.APIKeyScope(s)
+			if !idb.IsAPIKeyScopeValid(scope) {
+				return nil, false, fmt.Errorf("loadAPIKeys() key %s has an unknown scope %s", config.Name, s)
+			}
+			key.Scopes = append(key.Scopes, scope)
+		}
+		if config.Expires != "" {
+			key.ExpiresAt, err = time.Parse(time.RFC3339, config.Expires)
+			if err != nil {
+				return nil, false, fmt.Errorf("loadAPIKeys() key %s bad expiry err: %w", config.Name, err)
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, true, nil
+}
+
+// apiKeyLoader loads the API keys into the auth middleware.
+type apiKeyLoader struct {
+	auth *middlewares.Auth
+	// token is the deprecated --token, accepted with every scope.
+	token string
+	// table is true if the keys of the api_key table are accepted as well.
+	table bool
+}
+
+// makeAPIKeyLoader creates the auth middleware and loads the keys of the config `v`.
+// The API is open, except for the dao-admin endpoints, while no key source is
+// configured: no api-keys section, no --token and no api_key table. A section added
+// by a reload locks the API, removing it opens the API again.
+func makeAPIKeyLoader(v *viper.Viper, token string, table bool) (*apiKeyLoader, error) {
+	if token != "" {
+		logger.Warnf("--token is deprecated, use the %s config section instead", apiKeysConfigKey)
+	}
+
+	l := &apiKeyLoader{
+		auth:  middlewares.MakeAuth(api.TokenHeader),
+		token: token,
+		table: table,
+	}
+	if table {
+		// No key is accepted until the table is read.
+		err := l.auth.SetKeys(apiKeysTableSource, nil)
+		if err != nil {
+			return nil, fmt.Errorf("makeAPIKeyLoader() err: %w", err)
+		}
+	}
+	err := l.loadConfig(v)
+	if err != nil {
+		return nil, fmt.Errorf("makeAPIKeyLoader() err: %w", err)
+	}
+	if l.auth.Open() {
+		logger.Warn("no API key source is configured, the API is open except for the dao-admin endpoints")
+	}
+	return l, nil
+}
+
+// loadConfig replaces the keys of the config `v` and the --token key. The config
+// source is removed if `v` has no api-keys section and there is no --token.
+func (l *apiKeyLoader) loadConfig(v *viper.Viper) error {
+	keys, ok, err := loadAPIKeys(v)
+	if err != nil {
+		return fmt.Errorf("loadConfig() err: %w", err)
+	}
+	wasOpen := l.auth.Open()
+	if !ok && l.token == "" {
+		l.auth.RemoveKeys(apiKeysConfigSource)
+		if !wasOpen && l.auth.Open() {
+			logger.Warnf("the %s section was removed, the API is open except for the dao-admin endpoints", apiKeysConfigKey)
+		}
+		return nil
+	}
+	if l.token != "" {
+		keys = append(keys, idb.APIKey{
+			Name: apiTokenKeyName,
+			Hash: idb.HashAPIKey(l.token),
+			Scopes: []idb.APIKeyScope{
+				idb.APIKeyScopePublic, idb.APIKeyScopeDAOAdmin, idb.APIKeyScopeMetrics},
+		})
+	}
+
+	err = l.auth.SetKeys(apiKeysConfigSource, keys)
+	if err != nil {
+		return fmt.Errorf("loadConfig() err: %w", err)
+	}
+	logger.Infof("loaded %d API keys from the config", len(keys))
+	if wasOpen {
+		logger.Info("the API requires keys")
+	}
+	return nil
+}
+
+// loadTable replaces the keys of the api_key table, if they are accepted.
+func (l *apiKeyLoader) loadTable(ctx context.Context, db idb.IndexerDb) error {
+	if !l.table {
+		return nil
+	}
+
+	keys, err := db.APIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("loadTable() err: %w", err)
+	}
+	err = l.auth.SetKeys(apiKeysTableSource, keys)
+	if err != nil {
+		return fmt.Errorf("loadTable() err: %w", err)
+	}
+	logger.Debugf("loaded %d API keys from the api_key table", len(keys))
+	return nil
+}
+
+// reloadHook returns the hook reloading the keys of the config, and polling the keys
+// of the api_key table in `db`.
+func (l *apiKeyLoader) reloadHook(db idb.IndexerDb) configReloadHook {
+	return configReloadHook{
+		subsystem: apiKeysSubsystem,
+		reload: func(v *viper.Viper, reason string) error {
+			return l.reload(v, db)
+		},
+		poll: func(ctx context.Context) error {
+			return l.loadTable(ctx, db)
+		},
+	}
+}
+
+// reload replaces the keys of the config `v` and of the api_key table.
+func (l *apiKeyLoader) reload(v *viper.Viper, db idb.IndexerDb) error {
+	err := l.loadConfig(v)
+	if err != nil {
+		return fmt.Errorf("reload() err: %w", err)
+	}
+	err = l.loadTable(context.Background(), db)
+	if err != nil {
+		return fmt.Errorf("reload() err: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/mocks"
+)
+
+func TestLoadAPIKeys(t *testing.T) {
+	defer viper.Reset()
+
+	readTestConfig(t, "")
+	_, ok, err := loadAPIKeys(viper.GetViper())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	readTestConfig(t,
+		"api-keys:\n"+
+			"  - name: frontend\n"+
+			"    key: s3cret\n"+
+			"    scopes: [public]\n"+
+			"    rate-limit: 2.5\n"+
+			"    burst: 10\n"+
+			"  - name: ops\n"+
+			"    key: other\n"+
+			"    scopes: [dao-admin, metrics]\n"+
+			"    expires: 2027-01-02T03:04:05Z\n")
+	keys, ok, err := loadAPIKeys(viper.GetViper())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	expected := []idb.APIKey{
+		{
+			Name:      "frontend",
+			Hash:      idb.HashAPIKey("s3cret"),
+			Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic},
+			RateLimit: 2.5,
+			Burst:     10,
+		},
+		{
+			Name:      "ops",
+			Hash:      idb.HashAPIKey("other"),
+			Scopes:    []idb.APIKeyScope{idb.APIKeyScopeDAOAdmin, idb.APIKeyScopeMetrics},
+			ExpiresAt: time.Date(2027, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+	assert.Equal(t, expected, keys)
+}
+
+func TestLoadAPIKeysInvalid(t *testing.T) {
+	defer viper.Reset()
+
+	testcases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "no name",
+			config: "api-keys:\n  - key: k\n    scopes: [public]\n",
+			err:    "key 0 has no name",
+		},
+		{
+			name:   "no key",
+			config: "api-keys:\n  - name: a\n    scopes: [public]\n",
+			err:    "key a is empty",
+		},
+		{
+			name:   "no scopes",
+			config: "api-keys:\n  - name: a\n    key: k\n",
+			err:    "key a has no scopes",
+		},
+		{
+			name:   "scope",
+			config: "api-keys:\n  - name: a\n    key: k\n    scopes: [admin]\n",
+			err:    "key a has an unknown scope admin",
+		},
+		{
+			name:   "rate limit",
+			config: "api-keys:\n  - name: a\n    key: k\n    scopes: [public]\n    rate-limit: -1\n",
+			err:    "key a has a negative rate limit",
+		},
+		{
+			name:   "expiry",
+			config: "api-keys:\n  - name: a\n    key: k\n    scopes: [public]\n    expires: tomorrow\n",
+			err:    "key a bad expiry",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			readTestConfig(t, tc.config)
+			_, _, err := loadAPIKeys(viper.GetViper())
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestAPIKeyLoader(t *testing.T) {
+	defer viper.Reset()
+
+	// Without a key source the API is open.
+	readTestConfig(t, "")
+	loader, err := makeAPIKeyLoader(viper.GetViper(), "", false)
+	require.NoError(t, err)
+	assert.True(t, loader.auth.Open())
+
+	// A section added by a reload locks the API, removing it opens the API again.
+	db := &mocks.IndexerDb{}
+	readTestConfig(t, "api-keys: []\n")
+	require.NoError(t, loader.reload(viper.GetViper(), db))
+	assert.False(t, loader.auth.Open())
+	readTestConfig(t, "")
+	require.NoError(t, loader.reload(viper.GetViper(), db))
+	assert.True(t, loader.auth.Open())
+
+	// The API is locked until the table is read.
+	loader, err = makeAPIKeyLoader(viper.GetViper(), "", true)
+	require.NoError(t, err)
+	assert.False(t, loader.auth.Open())
+
+	readTestConfig(t, "api-keys:\n  - name: frontend\n    key: s3cret\n    scopes: [public]\n")
+	loader, err = makeAPIKeyLoader(viper.GetViper(), "legacy", true)
+	require.NoError(t, err)
+	assert.False(t, loader.auth.Open())
+
+	db.On("APIKeys", mock.Anything).Return([]idb.APIKey{{
+		Name:   "team",
+		Hash:   idb.HashAPIKey("team-key"),
+		Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic},
+	}}, nil).Once()
+	require.NoError(t, loader.loadTable(context.Background(), db))
+
+	// A table key reusing a config name is rejected, the current keys are kept.
+	db.On("APIKeys", mock.Anything).Return([]idb.APIKey{{
+		Name:   apiTokenKeyName,
+		Hash:   idb.HashAPIKey("other"),
+		Scopes: []idb.APIKeyScope{idb.APIKeyScopePublic},
+	}}, nil).Once()
+	assert.ErrorContains(t, loader.loadTable(context.Background(), db), "duplicate key name token")
+	db.AssertExpectations(t)
+}
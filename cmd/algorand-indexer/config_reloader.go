@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/algorand/indexer/util/metrics"
+)
+
+// configPollInterval is how often the files the configuration is read from are
+// checked for changes.
+const configPollInterval = 5 * time.Second
+
+// Subsystems reloaded by the configReloader, they label the reload metric.
+const (
+	daoProgramsSubsystem = "dao-programs"
+	webhooksSubsystem    = "webhooks"
+	apiKeysSubsystem     = "api-keys"
+)
+
+// configReloadHook reloads the configuration of a subsystem.
+type configReloadHook struct {
+	// subsystem names the subsystem in the logs and the metrics.
+	subsystem string
+	// files are the files the subsystem reads besides the config file.
+	files []string
+	// reload reads the configuration of the subsystem from the config `v` and
+	// applies it. The current configuration is kept if it fails.
+	reload func(v *viper.Viper, reason string) error
+	// poll, if set, refreshes the state of the subsystem that cannot be watched for
+	// changes. It is called on every poll.
+	poll func(ctx context.Context) error
+}
+
+// configReloader reloads the configuration of the daemon subsystems on SIGHUP and
+// when the config file or the files of a subsystem change. Every subsystem is
+// reloaded by its own hook: a configuration that fails to load is logged and the
+// subsystem keeps its current one, the other subsystems are still reloaded.
+type configReloader struct {
+	// configPath is the indexer config file, empty if there is none.
+	configPath string
+	hooks      []configReloadHook
+
+	// Protects the fields below and serializes reloads.
+	mu sync.Mutex
+	// modTimes are the modification times of the watched files when they were last
+	// read, zero for missing files.
+	modTimes map[string]time.Time
+}
+
+func makeConfigReloader(configPath string, hooks ...configReloadHook) *configReloader {
+	r := &configReloader{
+		configPath: configPath,
+		hooks:      hooks,
+	}
+	r.modTimes = r.readModTimes()
+	return r
+}
+
+// watchedFiles returns the files the configuration is read from.
+func (r *configReloader) watchedFiles() []string {
+	var files []string
+	if r.configPath != "" {
+		files = append(files, r.configPath)
+	}
+	for _, hook := range r.hooks {
+		files = append(files, hook.files...)
+	}
+	return files
+}
+
+func (r *configReloader) readModTimes() map[string]time.Time {
+	res := make(map[string]time.Time)
+	for _, file := range r.watchedFiles() {
+		if info, err := os.Stat(file); err == nil {
+			res[file] = info.ModTime()
+		}
+	}
+	return res
+}
+
+// filesChanged returns true if a watched file was created, modified or removed
+// since it was last read.
+func (r *configReloader) filesChanged() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes := r.readModTimes()
+	for _, file := range r.watchedFiles() {
+		if !modTimes[file].Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// readConfig reads the config file into a new viper instance, the global one is
+// only written at startup. The instance is empty if there is no config file.
+func (r *configReloader) readConfig() (*viper.Viper, error) {
+	v := viper.New()
+	if r.configPath == "" {
+		return v, nil
+	}
+
+	configs, err := os.Open(r.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("readConfig() err: %w", err)
+	}
+	defer configs.Close()
+	v.SetConfigType("yaml")
+	err = v.ReadConfig(configs)
+	if err != nil {
+		return nil, fmt.Errorf("readConfig() invalid config file %s err: %w", r.configPath, err)
+	}
+	return v, nil
+}
+
+// reload reads the config file and reloads every subsystem. The results are
+// recorded in the logs and the metrics, the returned error names the subsystems
+// that failed.
+func (r *configReloader) reload(reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.modTimes = r.readModTimes()
+	v, err := r.readConfig()
+	if err != nil {
+		logger.WithError(err).Errorf(
+			"failed to read the config after %s, keeping the current configuration", reason)
+		for _, hook := range r.hooks {
+			metrics.ConfigReloads.WithLabelValues(hook.subsystem, "failure").Inc()
+		}
+		return fmt.Errorf("reload() err: %w", err)
+	}
+
+	var failures []string
+	for _, hook := range r.hooks {
+		err := hook.reload(v, reason)
+		if err != nil {
+			logger.WithError(err).Errorf(
+				"failed to reload the %s after %s, keeping the current ones", hook.subsystem, reason)
+			metrics.ConfigReloads.WithLabelValues(hook.subsystem, "failure").Inc()
+			failures = append(failures, fmt.Sprintf("%s: %v", hook.subsystem, err))
+			continue
+		}
+		metrics.ConfigReloads.WithLabelValues(hook.subsystem, "success").Inc()
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("reload() failed %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// poll refreshes the subsystems with state that cannot be watched. A failure is
+// logged and the current state is kept.
+func (r *configReloader) poll(ctx context.Context) {
+	for _, hook := range r.hooks {
+		if hook.poll == nil {
+			continue
+		}
+		err := hook.poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.WithError(err).Errorf("failed to refresh the %s, keeping the current ones", hook.subsystem)
+		}
+	}
+}
+
+// run reloads the configuration until `ctx` is canceled.
+func (r *configReloader) run(ctx context.Context) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	r.poll(ctx)
+	for {
+		var reason string
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			reason = "SIGHUP"
+		case <-ticker.C:
+			r.poll(ctx)
+			if !r.filesChanged() {
+				continue
+			}
+			reason = "file change"
+		}
+
+		r.reload(reason)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/mocks"
+	"github.com/algorand/indexer/util/metrics"
+)
+
+func TestConfigReloader(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	indexerDataDir := createTempDir(t)
+	defer os.RemoveAll(indexerDataDir)
+
+	configPath := filepath.Join(indexerDataDir, "indexer.yml")
+	writeConfig := func(content string, modTime time.Time) {
+		err := os.WriteFile(configPath, []byte(content), 0644)
+		require.NoError(t, err)
+		err = os.Chtimes(configPath, modTime, modTime)
+		require.NoError(t, err)
+	}
+	start := time.Now().Add(-time.Hour)
+	writeConfig("", start)
+
+	db := &mocks.IndexerDb{}
+	reloader := makeConfigReloader(configPath,
+		makeDAOProgramsReloadHook(db, indexerDataDir), makeWebhooksReloadHook(db))
+	assert.False(t, reloader.filesChanged())
+	reloads := func(subsystem string, result string) float64 {
+		return testutil.ToFloat64(metrics.ConfigReloads.WithLabelValues(subsystem, result))
+	}
+	programFailures := reloads(daoProgramsSubsystem, "failure")
+	webhookSuccesses := reloads(webhooksSubsystem, "success")
+
+	// A valid registry is handed to the database.
+	writeConfig(
+		"sigmadao-programs:\n"+
+			"  - version: v2\n"+
+			"    approval-program: "+base64.StdEncoding.EncodeToString(testDAOProgram)+"\n",
+		start.Add(time.Minute))
+	assert.True(t, reloader.filesChanged())
+
+	expected := []idb.DAOProgram{{Version: "v2", ApprovalProgram: testDAOProgram}}
+	db.On("SetDAOPrograms", expected).Return(nil).Once()
+	err := reloader.reload("test")
+	require.NoError(t, err)
+	assert.False(t, reloader.filesChanged())
+	// The config is read into a local viper instance.
+	assert.False(t, viper.IsSet(daoProgramsConfigKey))
+
+	// An invalid registry is rejected before reaching the database, the webhooks are
+	// reloaded anyway.
+	writeConfig(
+		"sigmadao-programs:\n"+
+			"  - version: v3\n"+
+			"    approval-program: '!!!'\n"+
+			"webhooks: []\n",
+		start.Add(2*time.Minute))
+	db.On("SetWebhooks", mock.Anything).Return(nil).Once()
+	err = reloader.reload("test")
+	assert.ErrorContains(t, err, "dao-programs: ")
+	assert.ErrorContains(t, err, "version v3 bad approval program")
+	assert.NotContains(t, err.Error(), "webhooks")
+	assert.False(t, reloader.filesChanged())
+	assert.Equal(t, programFailures+1, reloads(daoProgramsSubsystem, "failure"))
+	assert.Equal(t, webhookSuccesses+2, reloads(webhooksSubsystem, "success"))
+
+	db.AssertExpectations(t)
+	db.AssertNumberOfCalls(t, "SetDAOPrograms", 1)
+}
@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime/pprof"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/algorand/go-algorand/rpcs"
 	"github.com/algorand/go-algorand/util"
 
+	"github.com/algorand/indexer/api"
 	"github.com/algorand/indexer/config"
 	"github.com/algorand/indexer/fetcher"
 	"github.com/algorand/indexer/idb"
@@ -27,6 +29,7 @@ import (
 	"github.com/algorand/indexer/processor/blockprocessor"
 	iutil "github.com/algorand/indexer/util"
 	"github.com/algorand/indexer/util/metrics"
+	"github.com/algorand/indexer/webhook"
 )
 
 // GetConfigFromDataDir Given the data directory, configuration filename and a list of types, see if
@@ -66,6 +69,7 @@ type daemonConfig struct {
 	allowMigration            bool
 	metricsMode               string
 	tokenString               string
+	apiKeysTable              bool
 	writeTimeout              time.Duration
 	readTimeout               time.Duration
 	maxConn                   uint32
@@ -89,6 +93,7 @@ type daemonConfig struct {
 	configFile                string
 	suppliedAPIConfigFile     string
 	genesisJSONPath           string
+	fullIndexing              bool
 }
 
 // DaemonCmd creates the main cobra command, initializes flags, and viper aliases
@@ -112,7 +117,8 @@ func DaemonCmd() *cobra.Command {
 	cfg.flags.StringVarP(&cfg.genesisJSONPath, "genesis", "g", "", "path to genesis.json (defaults to genesis.json in algod data dir if that was set)")
 	cfg.flags.StringVarP(&cfg.daemonServerAddr, "server", "S", ":8980", "host:port to serve API on (default :8980)")
 	cfg.flags.BoolVarP(&cfg.noAlgod, "no-algod", "", false, "disable connecting to algod for block following")
-	cfg.flags.StringVarP(&cfg.tokenString, "token", "t", "", "an optional auth token, when set REST calls must use this token in a bearer format, or in a 'X-Indexer-API-Token' header")
+	cfg.flags.StringVarP(&cfg.tokenString, "token", "t", "", "deprecated, use the api-keys config section. An optional auth token granting every scope, when set REST calls must use an API key in a bearer format, or in a 'X-Indexer-API-Token' header")
+	cfg.flags.BoolVar(&cfg.apiKeysTable, "api-keys-table", false, "accept the API keys of the api_key table as well, they are reloaded every few seconds")
 	cfg.flags.BoolVarP(&cfg.developerMode, "dev-mode", "", false, "allow performance intensive operations like searching for accounts at a particular round")
 	cfg.flags.BoolVarP(&cfg.allowMigration, "allow-migration", "", false, "allow migrations to happen even when no algod connected")
 	cfg.flags.StringVarP(&cfg.metricsMode, "metrics-mode", "", "OFF", "configure the /metrics endpoint to [ON, OFF, VERBOSE]")
@@ -134,6 +140,8 @@ func DaemonCmd() *cobra.Command {
 	cfg.flags.Uint32VarP(&cfg.maxApplicationsLimit, "max-applications-limit", "", 1000, "set the maximum allowed Limit parameter for querying applications")
 	cfg.flags.Uint32VarP(&cfg.defaultApplicationsLimit, "default-applications-limit", "", 100, "set the default Limit parameter for querying applications, if none is provided")
 
+	cfg.flags.BoolVar(&cfg.fullIndexing, "full-indexing", false, "write every account, asset and asset holding instead of only the SigmaDAO related ones")
+
 	cfg.flags.StringVarP(&cfg.indexerDataDir, "data-dir", "i", "", "path to indexer data dir, or $INDEXER_DATA")
 	cfg.flags.BoolVar(&cfg.initLedger, "init-ledger", true, "initialize local ledger using sequential mode")
 	cfg.flags.StringVarP(&cfg.catchpoint, "catchpoint", "", "", "initialize local ledger using fast catchup")
@@ -166,13 +174,15 @@ func configureIndexerDataDir(indexerDataDir string) error {
 	return err
 }
 
-func loadIndexerConfig(indexerDataDir string, configFile string) error {
+// loadIndexerConfig reads the indexer configuration file into viper and returns its
+// path, empty if there is none.
+func loadIndexerConfig(indexerDataDir string, configFile string) (string, error) {
 	var err error
 	var resolvedConfigPath string
 	potentialIndexerConfigPath, err := GetConfigFromDataDir(indexerDataDir, autoLoadIndexerConfigFileName, config.FileTypes[:])
 	if err != nil {
 		logger.Error(err)
-		return err
+		return "", err
 	}
 	indexerConfigFound := potentialIndexerConfigPath != ""
 
@@ -182,7 +192,7 @@ func loadIndexerConfig(indexerDataDir string, configFile string) error {
 			err = fmt.Errorf("indexer configuration was found in data directory (%s) as well as supplied via command line.  Only provide one",
 				potentialIndexerConfigPath)
 			logger.Error(err)
-			return err
+			return "", err
 		}
 		resolvedConfigPath = potentialIndexerConfigPath
 	} else if configFile != "" {
@@ -190,21 +200,21 @@ func loadIndexerConfig(indexerDataDir string, configFile string) error {
 		resolvedConfigPath = configFile
 	} else {
 		// neither autoload nor user specified
-		return err
+		return "", err
 	}
 	configs, err := os.Open(resolvedConfigPath)
 	if err != nil {
 		logger.WithError(err).Errorf("File Does Not Exist Error: %v", err)
-		return err
+		return "", err
 	}
 	defer configs.Close()
 	err = viper.ReadConfig(configs)
 	if err != nil {
 		logger.WithError(err).Errorf("invalid config file (%s): %v", viper.ConfigFileUsed(), err)
-		return err
+		return "", err
 	}
 	logger.Infof("Using configuration file: %s\n", resolvedConfigPath)
-	return err
+	return resolvedConfigPath, err
 }
 
 func loadIndexerParamConfig(cfg *daemonConfig) error {
@@ -231,7 +241,7 @@ func loadIndexerParamConfig(cfg *daemonConfig) error {
 			return err
 		}
 		cfg.suppliedAPIConfigFile = potentialParamConfigPath
-		logger.Infof("Auto-loading parameter configuration file: %s", suppliedAPIConfigFile)
+		logger.Infof("Auto-loading parameter configuration file: %s", cfg.suppliedAPIConfigFile)
 	}
 	return err
 }
@@ -275,7 +285,8 @@ func runDaemon(daemonConfig *daemonConfig) error {
 	}
 
 	// Detect the various auto-loading configs from data directory
-	if err = loadIndexerConfig(daemonConfig.indexerDataDir, daemonConfig.configFile); err != nil {
+	configPath, err := loadIndexerConfig(daemonConfig.indexerDataDir, daemonConfig.configFile)
+	if err != nil {
 		return err
 	}
 	// We need to re-run this because loading the config file could change these
@@ -285,6 +296,11 @@ func runDaemon(daemonConfig *daemonConfig) error {
 	if err = loadIndexerParamConfig(daemonConfig); err != nil {
 		return err
 	}
+	disabledMapConfig, err := makeDisabledMapConfig(daemonConfig)
+	if err != nil {
+		logger.WithError(err).Errorf("API Parameter Error: %v", err)
+		return err
+	}
 
 	if daemonConfig.pidFilePath != "" {
 		err = createIndexerPidFile(daemonConfig.pidFilePath)
@@ -346,6 +362,16 @@ func runDaemon(daemonConfig *daemonConfig) error {
 		// no algod was found
 		daemonConfig.noAlgod = true
 	}
+	// The block importer owns the local ledger and the caches of the database state,
+	// `dao backfill` must not change them meanwhile.
+	if bot != nil {
+		dataDirLock, err := lockDataDir(daemonConfig.indexerDataDir)
+		if err != nil {
+			logger.WithError(err).Error("indexer data directory error")
+			return err
+		}
+		defer dataDirLock.Close()
+	}
 	opts := idb.IndexerDbOptions{}
 	if daemonConfig.noAlgod && !daemonConfig.allowMigration {
 		opts.ReadOnly = true
@@ -356,24 +382,96 @@ func runDaemon(daemonConfig *daemonConfig) error {
 	opts.AlgodDataDir = daemonConfig.algodDataDir
 	opts.AlgodToken = daemonConfig.algodToken
 	opts.AlgodAddr = daemonConfig.algodAddr
+	opts.FullIndexing = daemonConfig.fullIndexing
+	opts.DAOPrograms, err = loadDAOPrograms(viper.GetViper(), daemonConfig.indexerDataDir)
+	if err != nil {
+		logger.WithError(err).Error("failed to load SigmaDAO programs")
+		return err
+	}
+	webhooks, webhooksSet, err := loadWebhooks(viper.GetViper())
+	if err != nil {
+		logger.WithError(err).Error("failed to load webhooks")
+		return err
+	}
+	apiKeys, err := makeAPIKeyLoader(viper.GetViper(), daemonConfig.tokenString, daemonConfig.apiKeysTable)
+	if err != nil {
+		logger.WithError(err).Error("failed to load API keys")
+		return err
+	}
+	// The SigmaDAO event streams are fed by the block importer of this process.
+	if bot != nil {
+		opts.DAOEventHub = idb.MakeDAOEventHub()
+	}
 
 	db, availableCh := indexerDbFromFlags(opts)
 	defer db.Close()
+	reloadHooks := []configReloadHook{
+		makeDAOProgramsReloadHook(db, daemonConfig.indexerDataDir),
+		makeWebhooksReloadHook(db),
+		apiKeys.reloadHook(db),
+	}
+	reloader := makeConfigReloader(configPath, reloadHooks...)
+	go func() {
+		// Need to redefine exitHandler() for every go-routine
+		defer exitHandler()
+		// Wait until the database is available.
+		select {
+		case <-ctx.Done():
+			return
+		case <-availableCh:
+		}
+		reloader.run(ctx)
+	}()
 	var wg sync.WaitGroup
 	if bot != nil {
 		wg.Add(1)
 		go runBlockImporter(ctx, daemonConfig, &wg, db, availableCh, bot, opts)
+		// The webhook deliveries are written by the block importer.
+		wg.Add(1)
+		go runWebhookWorker(ctx, &wg, db, availableCh, webhooks, webhooksSet)
 	} else {
 		logger.Info("No block importer configured.")
 	}
 
 	fmt.Printf("serving on %s\n", daemonConfig.daemonServerAddr)
 	logger.Infof("serving on %s", daemonConfig.daemonServerAddr)
+	wg.Add(1)
+	go func() {
+		// Need to redefine exitHandler() for every go-routine
+		defer exitHandler()
+		defer wg.Done()
+		// The server stops when the daemon context is canceled.
+		options := makeOptions(daemonConfig)
+		options.DisabledMapConfig = disabledMapConfig
+		options.DAOEventHub = opts.DAOEventHub
+		options.Auth = apiKeys.auth
+		options.Reload = reloader.reload
+		err := api.Serve(ctx, daemonConfig.daemonServerAddr, db, bot, logger, options)
+		maybeFail(err, "api server error, %v", err)
+	}()
 
 	wg.Wait()
 	return err
 }
 
+// makeOptions converts the daemon configuration into the api server options.
+func makeOptions(daemonConfig *daemonConfig) (options api.ExtraOptions) {
+	options.DeveloperMode = daemonConfig.developerMode
+	options.MetricsEndpoint = !strings.EqualFold(daemonConfig.metricsMode, "OFF")
+	options.WriteTimeout = daemonConfig.writeTimeout
+	options.ReadTimeout = daemonConfig.readTimeout
+
+	options.MaxAPIResourcesPerAccount = uint64(daemonConfig.maxAPIResourcesPerAccount)
+	options.MaxAccountsLimit = uint64(daemonConfig.maxAccountsLimit)
+	options.DefaultAccountsLimit = uint64(daemonConfig.defaultAccountsLimit)
+	options.MaxAssetsLimit = uint64(daemonConfig.maxAssetsLimit)
+	options.DefaultAssetsLimit = uint64(daemonConfig.defaultAssetsLimit)
+	options.MaxApplicationsLimit = uint64(daemonConfig.maxApplicationsLimit)
+	options.DefaultApplicationsLimit = uint64(daemonConfig.defaultApplicationsLimit)
+
+	return
+}
+
 func runBlockImporter(ctx context.Context, cfg *daemonConfig, wg *sync.WaitGroup, db idb.IndexerDb, dbAvailable chan struct{}, bot fetcher.Fetcher, opts idb.IndexerDbOptions) {
 	// Need to redefine exitHandler() for every go-routine
 	defer exitHandler()
@@ -402,6 +500,8 @@ func runBlockImporter(ctx context.Context, cfg *daemonConfig, wg *sync.WaitGroup
 	if err != nil {
 		maybeFail(err, "blockprocessor.MakeProcessor() err %v", err)
 	}
+	// Newly relevant accounts and assets are backfilled from the local ledger.
+	db.SetLedgerState(proc.LedgerState())
 
 	bot.SetNextRound(proc.NextRoundToProcess())
 	handler := blockHandler(proc, 1*time.Second)
@@ -418,6 +518,29 @@ func runBlockImporter(ctx context.Context, cfg *daemonConfig, wg *sync.WaitGroup
 	}
 }
 
+// runWebhookWorker registers the configured webhooks, if any, and delivers the
+// webhook outbox until `ctx` is canceled.
+func runWebhookWorker(ctx context.Context, wg *sync.WaitGroup, db idb.IndexerDb, dbAvailable chan struct{}, webhooks []idb.Webhook, webhooksSet bool) {
+	// Need to redefine exitHandler() for every go-routine
+	defer exitHandler()
+	defer wg.Done()
+
+	// Wait until the database is available.
+	select {
+	case <-ctx.Done():
+		return
+	case <-dbAvailable:
+	}
+
+	if webhooksSet {
+		err := db.SetWebhooks(webhooks)
+		maybeFail(err, "failed to set webhooks, %v", err)
+	}
+
+	logger.Info("Starting webhook worker.")
+	webhook.MakeWorker(db, logger, webhook.DefaultOptions()).Run(ctx)
+}
+
 // blockHandler creates a handler complying to the fetcher block handler interface. In case of a failure it keeps
 // attempting to add the block until the fetcher shuts down.
 func blockHandler(proc processor.Processor, retryDelay time.Duration) func(context.Context, *rpcs.EncodedBlockCert) error {
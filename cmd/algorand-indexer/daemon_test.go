@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -21,6 +22,7 @@ import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/rpcs"
 
+	"github.com/algorand/indexer/api"
 	"github.com/algorand/indexer/config"
 	"github.com/algorand/indexer/processor/blockprocessor"
 	itest "github.com/algorand/indexer/util/test"
@@ -242,6 +244,35 @@ func TestLoadAPIConfigGivenAutoLoadExpectSuccess(t *testing.T) {
 	}
 }
 
+func TestMakeDisabledMapConfig(t *testing.T) {
+	indexerDataDir := createTempDir(t)
+	defer os.RemoveAll(indexerDataDir)
+
+	// Default configuration.
+	dmc, err := makeDisabledMapConfig(&daemonConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, api.GetDefaultDisabledMapConfigForPostgres(), dmc)
+
+	// Everything enabled.
+	dmc, err = makeDisabledMapConfig(&daemonConfig{enableAllParameters: true})
+	assert.NoError(t, err)
+	assert.Empty(t, dmc.Data)
+
+	// Supplied configuration.
+	configPath := filepath.Join(indexerDataDir, "api_config.yml")
+	configStr := "/v2/daos:\n  optional:\n    - name: disabled\n    - creator: enabled\n"
+	assert.NoError(t, os.WriteFile(configPath, []byte(configStr), fs.ModePerm))
+	dmc, err = makeDisabledMapConfig(&daemonConfig{suppliedAPIConfigFile: configPath})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string][]string{"/v2/daos": {http.MethodGet: {"name"}}}, dmc.Data)
+
+	// Unknown parameter.
+	configStr = "/v2/daos:\n  optional:\n    - foo: disabled\n"
+	assert.NoError(t, os.WriteFile(configPath, []byte(configStr), fs.ModePerm))
+	_, err = makeDisabledMapConfig(&daemonConfig{suppliedAPIConfigFile: configPath})
+	assert.Error(t, err)
+}
+
 func TestIndexerDataDirNotProvidedExpectError(t *testing.T) {
 	errorStr := "indexer data directory was not provided"
 
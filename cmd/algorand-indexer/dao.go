@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/protocol"
+	dbutil "github.com/algorand/go-algorand/util/db"
+
+	"github.com/algorand/indexer/config"
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/importer"
+	iutil "github.com/algorand/indexer/util"
+)
+
+var daoCmd = &cobra.Command{
+	Use:   "dao",
+	Short: "SigmaDAO database maintenance",
+	Long:  "SigmaDAO database maintenance. These commands work on the database directly and do not need a running daemon.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.HelpFunc()(cmd, args)
+	},
+}
+
+var daoCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "delete the local state of non SigmaDAO applications",
+	Long:  "delete the local state of non SigmaDAO applications. Databases created before application local state was restricted to SigmaDAO applications contain the local state of every application on the network; this command purges it once.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.BindFlagSet(cmd.Flags())
+		err := configureLogger()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to configure logger: %v", err)
+			panic(exit{1})
+		}
+
+		db, availableCh := indexerDbFromFlags(idb.IndexerDbOptions{})
+		defer db.Close()
+		<-availableCh
+
+		deleted, err := db.DeleteNonDAOAppRows(context.Background())
+		maybeFail(err, "dao cleanup failed")
+		logger.Infof("dao cleanup deleted %d account_app rows", deleted)
+	},
+}
+
+var (
+	backfillDataDir         string
+	backfillGenesisJSONPath string
+)
+
+var daoBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "write the SigmaDAO applications created before their program was registered",
+	Long:  "write the SigmaDAO applications created before their program was registered. The applications are read from the local ledger in the indexer data directory, which must be at the last round in the database. The command refuses to run while the daemon uses the data directory; the daemon loads the written applications when it starts. The local state of every account opted into the applications is written.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.BindFlagSet(cmd.Flags())
+		err := configureLogger()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to configure logger: %v", err)
+			panic(exit{1})
+		}
+
+		if backfillDataDir == "" {
+			logger.Error("indexer data directory was not provided")
+			panic(exit{1})
+		}
+		dataDirLock, err := lockDataDir(backfillDataDir)
+		maybeFail(err, "dao backfill cannot run while the daemon is running")
+		defer dataDirLock.Close()
+
+		_, err = loadIndexerConfig(backfillDataDir, "")
+		maybeFail(err, "failed to load the indexer config")
+
+		opts := idb.IndexerDbOptions{IndexerDatadir: backfillDataDir}
+		opts.DAOPrograms, err = loadDAOPrograms(viper.GetViper(), backfillDataDir)
+		maybeFail(err, "failed to load SigmaDAO programs")
+
+		db, availableCh := indexerDbFromFlags(opts)
+		defer db.Close()
+		<-availableCh
+
+		genesisReader := importer.GetGenesisFile(backfillGenesisJSONPath, nil, logger)
+		genesis, err := iutil.ReadGenesis(genesisReader)
+		maybeFail(err, "Error reading genesis file")
+
+		l, err := iutil.MakeLedger(logger, false, &genesis, backfillDataDir)
+		maybeFail(err, "failed to open the local ledger")
+		defer l.Close()
+
+		header, err := l.BlockHdr(l.Latest())
+		maybeFail(err, "failed to read the latest block header")
+
+		tracker, err := dbutil.MakeAccessor(
+			filepath.Join(backfillDataDir, ledgerTrackerDBName), true, false)
+		maybeFail(err, "failed to open the local ledger accounts")
+		defer tracker.Close()
+
+		count, err := db.BackfillDAOApps(
+			context.Background(),
+			&ledgerDAOBackfill{ledger: l, tracker: tracker, header: header})
+		maybeFail(err, "dao backfill failed")
+		logger.Infof("dao backfill wrote %d SigmaDAO apps at round %d", count, header.Round)
+	},
+}
+
+// ledgerAppPageSize is the number of apps listed from the ledger at a time.
+const ledgerAppPageSize = 1000
+
+// ledgerTrackerDBName is the database of the local ledger with the account state,
+// see util.MakeLedger().
+const ledgerTrackerDBName = "ledger.tracker.sqlite"
+
+// ledgerDAOBackfill reads the state of the local ledger for `dao backfill`.
+type ledgerDAOBackfill struct {
+	ledger *ledger.Ledger
+	// The account database of `ledger`, it lists the accounts of an app.
+	tracker dbutil.Accessor
+	header  bookkeeping.BlockHeader
+}
+
+// BlockHeader is part of idb.DAOBackfillLedger.
+func (l *ledgerDAOBackfill) BlockHeader() bookkeeping.BlockHeader {
+	return l.header
+}
+
+// Apps is part of idb.DAOBackfillLedger.
+func (l *ledgerDAOBackfill) Apps(f func(appID basics.AppIndex, creator basics.Address, params *basics.AppParams) error) error {
+	// The ledger lists apps in decreasing id order.
+	maxAppIdx := basics.AppIndex(math.MaxUint64)
+	for {
+		locators, err := l.ledger.ListApplications(maxAppIdx, ledgerAppPageSize)
+		if err != nil {
+			return fmt.Errorf("Apps() list applications err: %w", err)
+		}
+
+		for _, locator := range locators {
+			appID := basics.AppIndex(locator.Index)
+			resource, err := l.ledger.LookupApplication(l.header.Round, locator.Creator, appID)
+			if err != nil {
+				return fmt.Errorf("Apps() lookup app %d err: %w", appID, err)
+			}
+			if resource.AppParams == nil {
+				continue
+			}
+			err = f(appID, locator.Creator, resource.AppParams)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(locators) < ledgerAppPageSize {
+			return nil
+		}
+		last := basics.AppIndex(locators[len(locators)-1].Index)
+		if last <= 1 {
+			return nil
+		}
+		maxAppIdx = last - 1
+	}
+}
+
+// LocalStates is part of idb.DAOBackfillLedger.
+func (l *ledgerDAOBackfill) LocalStates(appID basics.AppIndex, f func(address basics.Address, localState *basics.AppLocalState) error) error {
+	candidates, dbRound, err := l.trackedAppAccounts(appID)
+	if err != nil {
+		return fmt.Errorf("LocalStates() err: %w", err)
+	}
+	// The ledger writes its account database some rounds behind, accounts that
+	// opted in since are found in the blocks.
+	for round := dbRound + 1; round <= l.header.Round; round++ {
+		block, err := l.ledger.Block(round)
+		if err != nil {
+			return fmt.Errorf("LocalStates() block %d err: %w", round, err)
+		}
+		payset, err := block.DecodePaysetFlat()
+		if err != nil {
+			return fmt.Errorf("LocalStates() decode block %d err: %w", round, err)
+		}
+		for i := range payset {
+			addAppCallers(&payset[i], appID, candidates)
+		}
+	}
+
+	// The candidates may have closed out, the ledger has the current local state.
+	for address := range candidates {
+		resource, err := l.ledger.LookupApplication(l.header.Round, address, appID)
+		if err != nil {
+			return fmt.Errorf("LocalStates() account %s err: %w", address, err)
+		}
+		if resource.AppLocalState == nil {
+			continue
+		}
+		err = f(address, resource.AppLocalState)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackedAppAccounts returns the accounts with state for `appID` in the account
+// database of the ledger and the round the database is at.
+func (l *ledgerDAOBackfill) trackedAppAccounts(appID basics.AppIndex) (map[basics.Address]struct{}, basics.Round, error) {
+	res := make(map[basics.Address]struct{})
+	var dbRound basics.Round
+	f := func(ctx context.Context, tx *sql.Tx) error {
+		err := tx.QueryRowContext(
+			ctx, "SELECT rnd FROM acctrounds WHERE id = 'acctbase'").Scan(&dbRound)
+		if err != nil {
+			return fmt.Errorf("query round err: %w", err)
+		}
+
+		rows, err := tx.QueryContext(
+			ctx,
+			`SELECT ab.address FROM resources r JOIN accountbase ab ON ab.rowid = r.addrid
+			WHERE r.aidx = ?`,
+			uint64(appID))
+		if err != nil {
+			return fmt.Errorf("query accounts err: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var addr []byte
+			err = rows.Scan(&addr)
+			if err != nil {
+				return fmt.Errorf("scan account err: %w", err)
+			}
+			var address basics.Address
+			copy(address[:], addr)
+			res[address] = struct{}{}
+		}
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("accounts rows err: %w", err)
+		}
+		return nil
+	}
+	err := l.tracker.Atomic(f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("trackedAppAccounts() err: %w", err)
+	}
+	return res, dbRound, nil
+}
+
+// addAppCallers adds the senders of the calls to `appID` in `stxnad` and its inner
+// transactions to `callers`.
+func addAppCallers(stxnad *transactions.SignedTxnWithAD, appID basics.AppIndex, callers map[basics.Address]struct{}) {
+	if stxnad.Txn.Type == protocol.ApplicationCallTx {
+		// App creations have the app id in their apply data.
+		id := stxnad.Txn.ApplicationID
+		if id == 0 {
+			id = stxnad.ApplyData.ApplicationID
+		}
+		if id == appID {
+			callers[stxnad.Txn.Sender] = struct{}{}
+		}
+	}
+	for i := range stxnad.EvalDelta.InnerTxns {
+		addAppCallers(&stxnad.EvalDelta.InnerTxns[i], appID, callers)
+	}
+}
+
+func init() {
+	daoBackfillCmd.Flags().StringVarP(&backfillDataDir, "data-dir", "i", "", "path to indexer data dir, or $INDEXER_DATA")
+	daoBackfillCmd.Flags().StringVarP(&backfillGenesisJSONPath, "genesis", "g", "", "path to genesis.json")
+
+	daoCmd.AddCommand(daoCleanupCmd)
+	daoCmd.AddCommand(daoBackfillCmd)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/algorand/go-algorand/util"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// daoProgramsConfigKey is the indexer.yml section listing the known SigmaDAO program versions:
+//
+//	sigmadao-programs:
+//	  - version: v1
+//	    approval-program: <base64 encoded approval program>
+//	    mask-constants: true
+const daoProgramsConfigKey = "sigmadao-programs"
+
+// legacyDAOProgramFile is the single program file used before the registry existed.
+// It is looked up in the indexer data directory.
+const legacyDAOProgramFile = "SigmaDAOApp.txt"
+
+type daoProgramConfig struct {
+	Version         string `mapstructure:"version"`
+	ApprovalProgram string `mapstructure:"approval-program"`
+	MaskConstants   bool   `mapstructure:"mask-constants"`
+}
+
+// loadDAOPrograms reads the SigmaDAO program registry from the config `v`. If the
+// config has no registry, the legacy SigmaDAOApp.txt file in `indexerDataDir` is
+// used as version "v1". An empty result means the registry persisted in the
+// database will be used.
+func loadDAOPrograms(v *viper.Viper, indexerDataDir string) ([]idb.DAOProgram, error) {
+	var configs []daoProgramConfig
+	err := v.UnmarshalKey(daoProgramsConfigKey, &configs)
+	if err != nil {
+		return nil, fmt.Errorf("loadDAOPrograms() unable to parse %s err: %w", daoProgramsConfigKey, err)
+	}
+
+	legacyPath := filepath.Join(indexerDataDir, legacyDAOProgramFile)
+	if len(configs) == 0 && util.FileExists(legacyPath) {
+		content, err := os.ReadFile(legacyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loadDAOPrograms() err: %w", err)
+		}
+		logger.Warnf("%s is deprecated, use the %s config section instead", legacyDAOProgramFile, daoProgramsConfigKey)
+		configs = append(configs, daoProgramConfig{
+			Version:         "v1",
+			ApprovalProgram: string(content),
+		})
+	}
+
+	programs := make([]idb.DAOProgram, 0, len(configs))
+	versions := make(map[string]struct{}, len(configs))
+	for i, config := range configs {
+		if config.Version == "" {
+			return nil, fmt.Errorf("loadDAOPrograms() program %d has no version", i)
+		}
+		if _, ok := versions[config.Version]; ok {
+			return nil, fmt.Errorf("loadDAOPrograms() duplicate version %s", config.Version)
+		}
+		versions[config.Version] = struct{}{}
+
+		program, err := base64.StdEncoding.DecodeString(strings.TrimSpace(config.ApprovalProgram))
+		if err != nil {
+			return nil, fmt.Errorf("loadDAOPrograms() version %s bad approval program err: %w", config.Version, err)
+		}
+		if len(program) == 0 {
+			return nil, fmt.Errorf("loadDAOPrograms() version %s has an empty approval program", config.Version)
+		}
+
+		programs = append(programs, idb.DAOProgram{
+			Version:         config.Version,
+			ApprovalProgram: program,
+			MaskConstants:   config.MaskConstants,
+		})
+	}
+
+	return programs, nil
+}
+
+// makeDAOProgramsReloadHook returns the hook reloading the SigmaDAO program
+// registry into `db`. The legacy program file in `indexerDataDir` is watched too.
+func makeDAOProgramsReloadHook(db idb.IndexerDb, indexerDataDir string) configReloadHook {
+	return configReloadHook{
+		subsystem: daoProgramsSubsystem,
+		files:     []string{filepath.Join(indexerDataDir, legacyDAOProgramFile)},
+		reload: func(v *viper.Viper, reason string) error {
+			return reloadDAOPrograms(v, db, indexerDataDir, reason)
+		},
+	}
+}
+
+// reloadDAOPrograms reads the registry from the config `v` and hands it to `db`.
+func reloadDAOPrograms(v *viper.Viper, db idb.IndexerDb, indexerDataDir string, reason string) error {
+	programs, err := loadDAOPrograms(v, indexerDataDir)
+	if err != nil {
+		return fmt.Errorf("reloadDAOPrograms() err: %w", err)
+	}
+	err = db.SetDAOPrograms(programs)
+	if err != nil {
+		return fmt.Errorf("reloadDAOPrograms() err: %w", err)
+	}
+	logger.Infof("reloaded %d SigmaDAO program versions after %s", len(programs), reason)
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+)
+
+var testDAOProgram = []byte{6, 0x81, 0x01, 0x43}
+
+func TestLoadDAOProgramsLegacyFileInDataDir(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	indexerDataDir := createTempDir(t)
+	defer os.RemoveAll(indexerDataDir)
+
+	// Nothing configured, the persisted registry is used.
+	programs, err := loadDAOPrograms(viper.GetViper(), indexerDataDir)
+	require.NoError(t, err)
+	assert.Empty(t, programs)
+
+	err = os.WriteFile(
+		filepath.Join(indexerDataDir, legacyDAOProgramFile),
+		[]byte(base64.StdEncoding.EncodeToString(testDAOProgram)+"\n"), 0644)
+	require.NoError(t, err)
+
+	programs, err = loadDAOPrograms(viper.GetViper(), indexerDataDir)
+	require.NoError(t, err)
+	expected := []idb.DAOProgram{{Version: "v1", ApprovalProgram: testDAOProgram}}
+	assert.Equal(t, expected, programs)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+
+	itest "github.com/algorand/indexer/util/test"
+)
+
+func TestDAOBackfillFlags(t *testing.T) {
+	defer func() {
+		postgresAddr = ""
+		logLevel = "info"
+		logFile = ""
+		backfillDataDir = ""
+	}()
+
+	cmd, args, err := rootCmd.Find([]string{
+		"dao", "backfill", "--postgres", "host=localhost dbname=indexer", "--loglevel", "debug",
+		"--logfile", "indexer.log", "--data-dir", "/tmp/indexer"})
+	require.NoError(t, err)
+	assert.Equal(t, daoBackfillCmd, cmd)
+
+	err = cmd.ParseFlags(args)
+	require.NoError(t, err)
+	assert.Equal(t, "host=localhost dbname=indexer", postgresAddr)
+	assert.Equal(t, "debug", logLevel)
+	assert.Equal(t, "indexer.log", logFile)
+	assert.Equal(t, "/tmp/indexer", backfillDataDir)
+	assert.NotNil(t, cmd.Flags().Lookup("dummydb"))
+}
+
+func TestAddAppCallers(t *testing.T) {
+	appCall := func(sender basics.Address, appID basics.AppIndex) transactions.SignedTxnWithAD {
+		var stxnad transactions.SignedTxnWithAD
+		stxnad.Txn.Type = protocol.ApplicationCallTx
+		stxnad.Txn.Sender = sender
+		stxnad.Txn.ApplicationID = appID
+		return stxnad
+	}
+
+	// App 7 is created, then app 9 calls apps 7 and 8.
+	creation := appCall(itest.AccountA, 0)
+	creation.ApplyData.ApplicationID = 7
+	call := appCall(itest.AccountB, 9)
+	call.EvalDelta.InnerTxns = []transactions.SignedTxnWithAD{
+		appCall(basics.AppIndex(9).Address(), 8),
+		appCall(basics.AppIndex(9).Address(), 7),
+	}
+
+	callers := make(map[basics.Address]struct{})
+	addAppCallers(&creation, 7, callers)
+	addAppCallers(&call, 7, callers)
+	expected := map[basics.Address]struct{}{
+		itest.AccountA:               {},
+		basics.AppIndex(9).Address(): {},
+	}
+	assert.Equal(t, expected, callers)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// dataDirLockFileName is the file in the indexer data directory locked by the process
+// using it.
+const dataDirLockFileName = "indexer.lock"
+
+// lockDataDir takes an exclusive lock on the indexer data directory, so that the local
+// ledger and the caches of the daemon are not changed by another process. It fails if
+// another process holds the lock. The lock is held until the returned file is closed
+// or the process exits.
+func lockDataDir(dataDir string) (*os.File, error) {
+	path := filepath.Join(dataDir, dataDirLockFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("lockDataDir() open err: %w", err)
+	}
+
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf(
+				"lockDataDir() indexer data directory %s is used by another process", dataDir)
+		}
+		return nil, fmt.Errorf("lockDataDir() lock err: %w", err)
+	}
+	return f, nil
+}
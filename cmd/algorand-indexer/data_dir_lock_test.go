@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockDataDir(t *testing.T) {
+	dataDir := t.TempDir()
+
+	lock, err := lockDataDir(dataDir)
+	require.NoError(t, err)
+
+	_, err = lockDataDir(dataDir)
+	assert.ErrorContains(t, err, "is used by another process")
+
+	require.NoError(t, lock.Close())
+	lock, err = lockDataDir(dataDir)
+	require.NoError(t, err)
+	lock.Close()
+}
@@ -117,6 +117,7 @@ func init() {
 	daemonCmd := DaemonCmd()
 	rootCmd.AddCommand(daemonCmd)
 	rootCmd.AddCommand(apiConfigCmd)
+	rootCmd.AddCommand(daoCmd)
 
 	// Version should be available globally
 	rootCmd.Flags().BoolVarP(&doVersion, "version", "v", false, "print version and exit")
@@ -130,6 +131,8 @@ func init() {
 	}
 	addFlags(daemonCmd)
 	addFlags(importCmd)
+	addFlags(daoCleanupCmd)
+	addFlags(daoBackfillCmd)
 
 	viper.RegisterAlias("postgres", "postgres-connection-string")
 
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/viper"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// webhooksConfigKey is the indexer.yml section listing the webhooks notified of the
+// SigmaDAO events:
+//
+//	webhooks:
+//	  - name: discord-bot
+//	    url: https://example.com/sigmadao
+//	    secret: <HMAC-SHA256 key>
+//	    application-ids: [1234]
+//	    events: [proposal-created, status-changed]
+//
+// Omitting `application-ids` or `events` selects every dao app or event kind.
+//
+// Deliveries are at least once: an event can be posted again after a restart or a
+// request timeout, with the same X-SigmaDAO-Delivery header. Receivers discard the
+// delivery IDs they already processed.
+const webhooksConfigKey = "webhooks"
+
+type webhookConfig struct {
+	Name           string   `mapstructure:"name"`
+	URL            string   `mapstructure:"url"`
+	Secret         string   `mapstructure:"secret"`
+	ApplicationIDs []uint64 `mapstructure:"application-ids"`
+	Events         []string `mapstructure:"events"`
+}
+
+// loadWebhooks reads the webhooks from the config `v`. `ok` is false if the config has
+// no webhooks section, the webhooks persisted in the database are kept then.
+func loadWebhooks(v *viper.Viper) (webhooks []idb.Webhook, ok bool, err error) {
+	if !v.IsSet(webhooksConfigKey) {
+		return nil, false, nil
+	}
+
+	var configs []webhookConfig
+	err = v.UnmarshalKey(webhooksConfigKey, &configs)
+	if err != nil {
+		return nil, false, fmt.Errorf("loadWebhooks() unable to parse %s err: %w", webhooksConfigKey, err)
+	}
+
+	webhooks = make([]idb.Webhook, 0, len(configs))
+	names := make(map[string]struct{}, len(configs))
+	for i, config := range configs {
+		if config.Name == "" {
+			return nil, false, fmt.Errorf("loadWebhooks() webhook %d has no name", i)
+		}
+		if _, ok := names[config.Name]; ok {
+			return nil, false, fmt.Errorf("loadWebhooks() duplicate webhook %s", config.Name)
+		}
+		names[config.Name] = struct{}{}
+
+		u, err := url.Parse(config.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, false, fmt.Errorf("loadWebhooks() webhook %s has an invalid url %q", config.Name, config.URL)
+		}
+		if config.Secret == "" {
+			return nil, false, fmt.Errorf("loadWebhooks() webhook %s has no secret", config.Name)
+		}
+
+		webhook := idb.Webhook{
+			Name:           config.Name,
+			URL:            config.URL,
+			Secret:         config.Secret,
+			ApplicationIDs: config.ApplicationIDs,
+		}
+		for _, event := range config.Events {
+			kind := idb.DAOEventKind(event)
+			if !idb.IsDAOEventKindValid(kind) {
+				return nil, false, fmt.Errorf("loadWebhooks() webhook %s has an unknown event %s", config.Name, event)
+			}
+			webhook.Kinds = append(webhook.Kinds, kind)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, true, nil
+}
+
+// makeWebhooksReloadHook returns the hook reloading the webhooks into `db`.
+func makeWebhooksReloadHook(db idb.IndexerDb) configReloadHook {
+	return configReloadHook{
+		subsystem: webhooksSubsystem,
+		reload: func(v *viper.Viper, reason string) error {
+			return reloadWebhooks(v, db, reason)
+		},
+	}
+}
+
+// reloadWebhooks reads the webhooks from the config `v` and hands them to `db`. The
+// stored webhooks are kept if the config has no webhooks section.
+func reloadWebhooks(v *viper.Viper, db idb.IndexerDb, reason string) error {
+	webhooks, ok, err := loadWebhooks(v)
+	if err != nil {
+		return fmt.Errorf("reloadWebhooks() err: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	err = db.SetWebhooks(webhooks)
+	if err != nil {
+		return fmt.Errorf("reloadWebhooks() err: %w", err)
+	}
+	logger.Infof("reloaded %d webhooks after %s", len(webhooks), reason)
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+)
+
+func readTestConfig(t *testing.T, content string) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	require.NoError(t, viper.ReadConfig(strings.NewReader(content)))
+}
+
+func TestLoadWebhooks(t *testing.T) {
+	defer viper.Reset()
+
+	// No section, the persisted webhooks are kept.
+	readTestConfig(t, "")
+	_, ok, err := loadWebhooks(viper.GetViper())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// An empty section removes every webhook.
+	readTestConfig(t, "webhooks: []\n")
+	webhooks, ok, err := loadWebhooks(viper.GetViper())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, webhooks)
+
+	readTestConfig(t,
+		"webhooks:\n"+
+			"  - name: bot\n"+
+			"    url: https://example.com/hook\n"+
+			"    secret: s3cret\n"+
+			"    application-ids: [3, 4]\n"+
+			"    events: [proposal-created, status-changed]\n"+
+			"  - name: all\n"+
+			"    url: http://localhost:8000\n"+
+			"    secret: other\n")
+	webhooks, ok, err = loadWebhooks(viper.GetViper())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	expected := []idb.Webhook{
+		{
+			Name:           "bot",
+			URL:            "https://example.com/hook",
+			Secret:         "s3cret",
+			ApplicationIDs: []uint64{3, 4},
+			Kinds:          []idb.DAOEventKind{idb.DAOEventProposalCreated, idb.DAOEventStatusChanged},
+		},
+		{Name: "all", URL: "http://localhost:8000", Secret: "other"},
+	}
+	assert.Equal(t, expected, webhooks)
+}
+
+func TestLoadWebhooksInvalid(t *testing.T) {
+	defer viper.Reset()
+
+	testcases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "no name",
+			config: "webhooks:\n  - url: http://a\n    secret: s\n",
+			err:    "webhook 0 has no name",
+		},
+		{
+			name: "duplicate",
+			config: "webhooks:\n  - name: a\n    url: http://a\n    secret: s\n" +
+				"  - name: a\n    url: http://b\n    secret: s\n",
+			err: "duplicate webhook a",
+		},
+		{
+			name:   "url",
+			config: "webhooks:\n  - name: a\n    url: ftp://a\n    secret: s\n",
+			err:    "webhook a has an invalid url",
+		},
+		{
+			name:   "secret",
+			config: "webhooks:\n  - name: a\n    url: http://a\n",
+			err:    "webhook a has no secret",
+		},
+		{
+			name:   "event",
+			config: "webhooks:\n  - name: a\n    url: http://a\n    secret: s\n    events: [vote]\n",
+			err:    "webhook a has an unknown event vote",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			readTestConfig(t, tc.config)
+			_, _, err := loadWebhooks(viper.GetViper())
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
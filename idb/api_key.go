@@ -0,0 +1,67 @@
+package idb
+
+import (
+	"crypto/sha256"
+	"time"
+)
+
+// APIKeyScope is a part of the REST API an API key grants access to.
+type APIKeyScope string
+
+// API key scopes.
+const (
+	// APIKeyScopePublic grants access to the read-only public API, including the
+	// SigmaDAO event streams.
+	APIKeyScopePublic APIKeyScope = "public"
+	// APIKeyScopeDAOAdmin grants access to the administration endpoints.
+	APIKeyScopeDAOAdmin APIKeyScope = "dao-admin"
+	// APIKeyScopeMetrics grants access to the /metrics endpoint.
+	APIKeyScopeMetrics APIKeyScope = "metrics"
+)
+
+// IsAPIKeyScopeValid returns true if `scope` is a known API key scope.
+func IsAPIKeyScopeValid(scope APIKeyScope) bool {
+	switch scope {
+	case APIKeyScopePublic, APIKeyScopeDAOAdmin, APIKeyScopeMetrics:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKey is a key accepted by the REST API. Only the hash of the key is kept.
+type APIKey struct {
+	// Name identifies the key in the logs and metrics.
+	Name string
+
+	// Hash is the SHA-256 hash of the key, see HashAPIKey().
+	Hash [sha256.Size]byte
+
+	// Scopes are the parts of the API the key grants access to.
+	Scopes []APIKeyScope
+
+	// RateLimit is the sustained number of requests per second, unlimited if zero.
+	RateLimit float64
+
+	// Burst is the number of requests allowed at once. If zero, it is the rate limit
+	// rounded up.
+	Burst uint32
+
+	// ExpiresAt is when the key stops being accepted, never if zero.
+	ExpiresAt time.Time
+}
+
+// HashAPIKey returns the hash identifying `key`.
+func HashAPIKey(key string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(key))
+}
+
+// HasScope returns true if the key grants `scope`.
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
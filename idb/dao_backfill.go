@@ -0,0 +1,19 @@
+package idb
+
+import (
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+)
+
+// DAOBackfillLedger is the ledger state read when backfilling SigmaDAO apps, see
+// IndexerDb.BackfillDAOApps().
+type DAOBackfillLedger interface {
+	// BlockHeader returns the header of the block the state is at.
+	BlockHeader() bookkeeping.BlockHeader
+	// Apps calls `f` for every app that exists at the block, stopping at the first
+	// error.
+	Apps(f func(appID basics.AppIndex, creator basics.Address, params *basics.AppParams) error) error
+	// LocalStates calls `f` with the local state of every account opted into
+	// `appID` at the block, stopping at the first error.
+	LocalStates(appID basics.AppIndex, f func(address basics.Address, localState *basics.AppLocalState) error) error
+}
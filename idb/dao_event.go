@@ -0,0 +1,222 @@
+package idb
+
+import (
+	"strings"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+// DAOMethod is a SigmaDAO app method, named by the first argument of the app call.
+type DAOMethod string
+
+// SigmaDAO app methods.
+const (
+	// DAOMethodOptinGovToken opts the DAO app into its governance token.
+	DAOMethodOptinGovToken DAOMethod = "optin_gov_token"
+	// DAOMethodAddProposal records a proposal in the local state of the calling
+	// proposal account.
+	DAOMethodAddProposal DAOMethod = "add_proposal"
+	// DAOMethodDepositVoteToken locks governance tokens for voting.
+	DAOMethodDepositVoteToken DAOMethod = "deposit_vote_token"
+	// DAOMethodRegisterVote votes on a proposal.
+	DAOMethodRegisterVote DAOMethod = "register_vote"
+	// DAOMethodExecute executes a passed proposal.
+	DAOMethodExecute DAOMethod = "execute"
+	// DAOMethodWithdrawVoteDeposit returns deposited governance tokens.
+	DAOMethodWithdrawVoteDeposit DAOMethod = "withdraw_vote_deposit"
+	// DAOMethodClearVoteRecord removes the vote record of a finished proposal.
+	DAOMethodClearVoteRecord DAOMethod = "clear_vote_record"
+	// DAOMethodCloseProposal removes a proposal and returns its deposit.
+	DAOMethodCloseProposal DAOMethod = "close_proposal"
+)
+
+var daoMethodEnumMap = map[DAOMethod]struct{}{
+	DAOMethodOptinGovToken:       {},
+	DAOMethodAddProposal:         {},
+	DAOMethodDepositVoteToken:    {},
+	DAOMethodRegisterVote:        {},
+	DAOMethodExecute:             {},
+	DAOMethodWithdrawVoteDeposit: {},
+	DAOMethodClearVoteRecord:     {},
+	DAOMethodCloseProposal:       {},
+}
+
+func makeDAOMethodEnumString() string {
+	keys := make([]string, 0, len(daoMethodEnumMap))
+	for k := range daoMethodEnumMap {
+		keys = append(keys, string(k))
+	}
+	return strings.Join(keys, ", ")
+}
+
+// DAOMethodEnumString is a comma-separated list of possible SigmaDAO methods.
+var DAOMethodEnumString = makeDAOMethodEnumString()
+
+// IsDAOMethodValid returns true if and only if `method` is one of the SigmaDAO
+// methods.
+func IsDAOMethodValid(method DAOMethod) bool {
+	_, ok := daoMethodEnumMap[method]
+	return ok
+}
+
+// DAOEventArgs are the decoded arguments of a SigmaDAO method call. Only the fields
+// taken by the method are set; addresses are in their base32 form.
+type DAOEventArgs struct {
+	// add_proposal
+	Name          *string `codec:"name,omitempty" json:"name,omitempty"`
+	URL           *string `codec:"url,omitempty" json:"url,omitempty"`
+	URLHash       []byte  `codec:"url-hash,omitempty" json:"url-hash,omitempty"`
+	HashAlgo      *string `codec:"hash-algo,omitempty" json:"hash-algo,omitempty"`
+	VotingStart   *uint64 `codec:"voting-start,omitempty" json:"voting-start,omitempty"`
+	VotingEnd     *uint64 `codec:"voting-end,omitempty" json:"voting-end,omitempty"`
+	ExecuteBefore *uint64 `codec:"execute-before,omitempty" json:"execute-before,omitempty"`
+	Type          *uint64 `codec:"type,omitempty" json:"type,omitempty"`
+	From          *string `codec:"from,omitempty" json:"from,omitempty"`
+	Recipient     *string `codec:"recipient,omitempty" json:"recipient,omitempty"`
+	AsaID         *uint64 `codec:"asa-id,omitempty" json:"asa-id,omitempty"`
+	Msg           []byte  `codec:"msg,omitempty" json:"msg,omitempty"`
+
+	// add_proposal transfer amount, deposit_vote_token deposit and
+	// withdraw_vote_deposit amount.
+	Amount *uint64 `codec:"amount,omitempty" json:"amount,omitempty"`
+
+	// register_vote
+	VoteOption *string `codec:"vote-option,omitempty" json:"vote-option,omitempty"`
+}
+
+// DAOEventKind is the kind of a streamed SigmaDAO event.
+type DAOEventKind string
+
+// Streamed SigmaDAO event kinds.
+const (
+	// DAOEventProposalCreated is an `add_proposal` call.
+	DAOEventProposalCreated DAOEventKind = "proposal-created"
+	// DAOEventVoteRegistered is a `register_vote` call.
+	DAOEventVoteRegistered DAOEventKind = "vote-registered"
+	// DAOEventDepositChanged is a `deposit_vote_token` or `withdraw_vote_deposit`
+	// call.
+	DAOEventDepositChanged DAOEventKind = "deposit-changed"
+	// DAOEventProposalExecuted is an `execute` call.
+	DAOEventProposalExecuted DAOEventKind = "proposal-executed"
+	// DAOEventStatusChanged is a change of the lifecycle status of a proposal. It is
+	// not tied to a transaction, the status also changes with the block time.
+	DAOEventStatusChanged DAOEventKind = "status-changed"
+)
+
+// IsDAOEventKindValid returns true if and only if `kind` is one of the streamed
+// event kinds.
+func IsDAOEventKindValid(kind DAOEventKind) bool {
+	switch kind {
+	case DAOEventProposalCreated, DAOEventVoteRegistered, DAOEventDepositChanged,
+		DAOEventProposalExecuted, DAOEventStatusChanged:
+		return true
+	default:
+		return false
+	}
+}
+
+var daoMethodEventKinds = map[DAOMethod]DAOEventKind{
+	DAOMethodAddProposal:         DAOEventProposalCreated,
+	DAOMethodRegisterVote:        DAOEventVoteRegistered,
+	DAOMethodDepositVoteToken:    DAOEventDepositChanged,
+	DAOMethodWithdrawVoteDeposit: DAOEventDepositChanged,
+	DAOMethodExecute:             DAOEventProposalExecuted,
+}
+
+// DAOMethodEventKind returns the streamed event kind of a call to `method`, false if
+// calls to `method` are not streamed.
+func DAOMethodEventKind(method DAOMethod) (DAOEventKind, bool) {
+	kind, ok := daoMethodEventKinds[method]
+	return kind, ok
+}
+
+// StreamedDAOMethods returns the SigmaDAO methods whose calls are streamed.
+func StreamedDAOMethods() []string {
+	res := make([]string, 0, len(daoMethodEventKinds))
+	for method := range daoMethodEventKinds {
+		res = append(res, string(method))
+	}
+	return res
+}
+
+// DAOEvent is a streamed SigmaDAO event of a committed round. Within a round, method
+// call events come first in transaction order, then status changes ordered by app
+// and proposal address.
+type DAOEvent struct {
+	Kind  DAOEventKind
+	Round uint64
+	AppID uint64
+	// Proposal is the proposal account address, nil if the event is not about a
+	// proposal.
+	Proposal *basics.Address
+
+	// Method call events only.
+	Intra  uint
+	Txid   string
+	Sender basics.Address
+	Method DAOMethod
+	Args   DAOEventArgs
+
+	// Status changes only, the new status.
+	Status ProposalStatus
+}
+
+// DAOEventRow is metadata relating to one SigmaDAO event in a DAOEvents query.
+type DAOEventRow struct {
+	Event DAOEvent
+	Error error
+}
+
+// DAOEventQuery is a parameter object with all of the SigmaDAO event filter options.
+type DAOEventQuery struct {
+	// ApplicationID filters by dao app, zero for all apps.
+	ApplicationID uint64
+	// MinRound and MaxRound are the inclusive range of rounds to return.
+	MinRound uint64
+	MaxRound uint64
+}
+
+// DAOEventPayload is the json form of a SigmaDAO event, sent to the event streams
+// and to the webhooks.
+type DAOEventPayload struct {
+	Kind     DAOEventKind  `json:"kind"`
+	Round    uint64        `json:"round"`
+	AppID    uint64        `json:"app-id"`
+	Proposal *string       `json:"proposal,omitempty"`
+	Intra    *uint         `json:"intra-round-offset,omitempty"`
+	Txid     *string       `json:"txid,omitempty"`
+	Sender   *string       `json:"sender,omitempty"`
+	Method   *DAOMethod    `json:"method,omitempty"`
+	Args     *DAOEventArgs `json:"args,omitempty"`
+	Status   *string       `json:"status,omitempty"`
+}
+
+// Payload returns the json form of the event.
+func (e *DAOEvent) Payload() DAOEventPayload {
+	res := DAOEventPayload{
+		Kind:  e.Kind,
+		Round: e.Round,
+		AppID: e.AppID,
+	}
+	if e.Proposal != nil {
+		proposal := e.Proposal.String()
+		res.Proposal = &proposal
+	}
+	if e.Kind == DAOEventStatusChanged {
+		status := string(e.Status)
+		res.Status = &status
+		return res
+	}
+
+	intra := e.Intra
+	txid := e.Txid
+	sender := e.Sender.String()
+	method := e.Method
+	args := e.Args
+	res.Intra = &intra
+	res.Txid = &txid
+	res.Sender = &sender
+	res.Method = &method
+	res.Args = &args
+	return res
+}
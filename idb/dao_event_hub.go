@@ -0,0 +1,84 @@
+package idb
+
+import (
+	"sync"
+)
+
+// daoEventSubscriptionBuffer is the number of rounds a subscriber can fall behind
+// before it is dropped.
+const daoEventSubscriptionBuffer = 64
+
+// DAOEventBatch holds the streamed SigmaDAO events of a committed round. A batch is
+// published for every round, even without events.
+type DAOEventBatch struct {
+	Round  uint64
+	Events []DAOEvent
+}
+
+// DAOEventHub is an in-process publish/subscribe hub of the SigmaDAO events of the
+// committed rounds. Publishing never blocks the block import: subscribers that fall
+// behind are dropped and need to catch up from the database.
+type DAOEventHub struct {
+	mu   sync.Mutex
+	subs map[*DAOEventSubscription]struct{}
+}
+
+// MakeDAOEventHub creates an empty hub.
+func MakeDAOEventHub() *DAOEventHub {
+	return &DAOEventHub{
+		subs: make(map[*DAOEventSubscription]struct{}),
+	}
+}
+
+// DAOEventSubscription receives the batches published after Subscribe() returns.
+type DAOEventSubscription struct {
+	// C receives the published batches in round order. It is closed when the
+	// subscriber falls behind or when the subscription is closed.
+	C <-chan DAOEventBatch
+
+	ch  chan DAOEventBatch
+	hub *DAOEventHub
+}
+
+// Subscribe returns a new subscription, which must be closed.
+func (h *DAOEventHub) Subscribe() *DAOEventSubscription {
+	ch := make(chan DAOEventBatch, daoEventSubscriptionBuffer)
+	sub := &DAOEventSubscription{
+		C:   ch,
+		ch:  ch,
+		hub: h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Publish sends `batch` to every subscriber. Subscribers whose buffer is full are
+// dropped.
+func (h *DAOEventHub) Publish(batch DAOEventBatch) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		select {
+		case sub.ch <- batch:
+		default:
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Close stops the subscription. It can be called more than once.
+func (s *DAOEventSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if _, ok := s.hub.subs[s]; ok {
+		delete(s.hub.subs, s)
+		close(s.ch)
+	}
+}
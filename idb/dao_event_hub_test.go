@@ -0,0 +1,52 @@
+package idb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/algorand/indexer/idb"
+)
+
+func TestDAOEventHub(t *testing.T) {
+	hub := idb.MakeDAOEventHub()
+
+	// Published before subscribing.
+	hub.Publish(idb.DAOEventBatch{Round: 1})
+
+	sub := hub.Subscribe()
+	defer sub.Close()
+	batch := idb.DAOEventBatch{
+		Round:  2,
+		Events: []idb.DAOEvent{{Kind: idb.DAOEventVoteRegistered, Round: 2, AppID: 3}},
+	}
+	hub.Publish(batch)
+	assert.Equal(t, batch, <-sub.C)
+
+	sub.Close()
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	// Closing twice and publishing after closing are fine.
+	sub.Close()
+	hub.Publish(batch)
+}
+
+func TestDAOEventHubSlowSubscriber(t *testing.T) {
+	hub := idb.MakeDAOEventHub()
+	slow := hub.Subscribe()
+	defer slow.Close()
+	fast := hub.Subscribe()
+	defer fast.Close()
+
+	// Publishing doesn't block on the slow subscriber, which gets dropped.
+	for round := uint64(0); round < 1000; round++ {
+		hub.Publish(idb.DAOEventBatch{Round: round})
+		assert.Equal(t, round, (<-fast.C).Round)
+	}
+
+	var rounds uint64
+	for range slow.C {
+		rounds++
+	}
+	assert.Less(t, rounds, uint64(1000))
+}
@@ -0,0 +1,17 @@
+package idb
+
+// DAOProgram describes one known build of the SigmaDAO approval program.
+// Applications whose approval program matches one of the registered programs
+// are indexed as SigmaDAO applications.
+type DAOProgram struct {
+	// Version is a label identifying the contract build, e.g. "v1".
+	Version string
+
+	// ApprovalProgram is the compiled approval program bytecode.
+	ApprovalProgram []byte
+
+	// MaskConstants makes applications match regardless of their TEAL version and
+	// the values in their leading intcblock and bytecblock, i.e. the template
+	// parameters baked in at compile time.
+	MaskConstants bool
+}
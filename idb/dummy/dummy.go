@@ -2,6 +2,7 @@ package dummy
 
 import (
 	"context"
+	"time"
 
 	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
@@ -78,11 +79,90 @@ func (db *dummyIndexerDb) AppLocalState(ctx context.Context, filter idb.Applicat
 	return nil, 0
 }
 
+// DAOs is part of idb.IndexerDB
+func (db *dummyIndexerDb) DAOs(ctx context.Context, filter idb.DAOQuery) (<-chan idb.DAORow, uint64) {
+	return nil, 0
+}
+
+// Proposals is part of idb.IndexerDB
+func (db *dummyIndexerDb) Proposals(ctx context.Context, filter idb.ProposalQuery) (<-chan idb.ProposalRow, uint64) {
+	return nil, 0
+}
+
+// Votes is part of idb.IndexerDB
+func (db *dummyIndexerDb) Votes(ctx context.Context, filter idb.VoteQuery) (<-chan idb.VoteRow, uint64) {
+	return nil, 0
+}
+
 // Health is part of idb.IndexerDB
 func (db *dummyIndexerDb) Health(ctx context.Context) (state idb.Health, err error) {
 	return idb.Health{}, nil
 }
 
+// ProposalTallies is part of idb.IndexerDB
+func (db *dummyIndexerDb) ProposalTallies(ctx context.Context, filter idb.ProposalTallyQuery) (<-chan idb.ProposalTallyRow, uint64) {
+	return nil, 0
+}
+
+// ProposalHolders is part of idb.IndexerDB
+func (db *dummyIndexerDb) ProposalHolders(ctx context.Context, filter idb.ProposalHolderQuery) (<-chan idb.ProposalHolderRow, uint64) {
+	return nil, 0
+}
+
+// TreasuryBalances is part of idb.IndexerDB
+func (db *dummyIndexerDb) TreasuryBalances(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryBalanceRow, uint64) {
+	return nil, 0
+}
+
+// TreasuryHistory is part of idb.IndexerDB
+func (db *dummyIndexerDb) TreasuryHistory(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryChangeRow, uint64) {
+	return nil, 0
+}
+
+// DAOEvents is part of idb.IndexerDB
+func (db *dummyIndexerDb) DAOEvents(ctx context.Context, filter idb.DAOEventQuery) (<-chan idb.DAOEventRow, uint64) {
+	return nil, 0
+}
+
+// DeleteNonDAOAppRows is part of idb.IndexerDB
+func (db *dummyIndexerDb) DeleteNonDAOAppRows(ctx context.Context) (uint64, error) {
+	return 0, nil
+}
+
+// SetDAOPrograms is part of idb.IndexerDB
+func (db *dummyIndexerDb) SetDAOPrograms(programs []idb.DAOProgram) error {
+	return nil
+}
+
+// SetLedgerState is part of idb.IndexerDB
+func (db *dummyIndexerDb) SetLedgerState(ledger idb.LedgerState) {
+}
+
+// BackfillDAOApps is part of idb.IndexerDB
+func (db *dummyIndexerDb) BackfillDAOApps(ctx context.Context, ledger idb.DAOBackfillLedger) (uint64, error) {
+	return 0, nil
+}
+
+// SetWebhooks is part of idb.IndexerDB
+func (db *dummyIndexerDb) SetWebhooks(webhooks []idb.Webhook) error {
+	return nil
+}
+
+// ClaimWebhookDeliveries is part of idb.IndexerDB
+func (db *dummyIndexerDb) ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]idb.WebhookDelivery, error) {
+	return nil, nil
+}
+
+// UpdateWebhookDelivery is part of idb.IndexerDB
+func (db *dummyIndexerDb) UpdateWebhookDelivery(ctx context.Context, update idb.WebhookDeliveryUpdate) error {
+	return nil
+}
+
+// APIKeys is part of idb.IndexerDB
+func (db *dummyIndexerDb) APIKeys(ctx context.Context) ([]idb.APIKey, error) {
+	return nil, nil
+}
+
 // GetNetworkState is part of idb.IndexerDB
 func (db *dummyIndexerDb) GetNetworkState() (state idb.NetworkState, err error) {
 	return idb.NetworkState{}, nil
@@ -180,8 +180,54 @@ type IndexerDb interface {
 	AssetBalances(ctx context.Context, abq AssetBalanceQuery) (<-chan AssetBalanceRow, uint64)
 	Applications(ctx context.Context, filter ApplicationQuery) (<-chan ApplicationRow, uint64)
 	AppLocalState(ctx context.Context, filter ApplicationQuery) (<-chan AppLocalStateRow, uint64)
+	DAOs(ctx context.Context, filter DAOQuery) (<-chan DAORow, uint64)
+	Proposals(ctx context.Context, filter ProposalQuery) (<-chan ProposalRow, uint64)
+	Votes(ctx context.Context, filter VoteQuery) (<-chan VoteRow, uint64)
+	ProposalTallies(ctx context.Context, filter ProposalTallyQuery) (<-chan ProposalTallyRow, uint64)
+	ProposalHolders(ctx context.Context, filter ProposalHolderQuery) (<-chan ProposalHolderRow, uint64)
+	TreasuryBalances(ctx context.Context, filter TreasuryQuery) (<-chan TreasuryBalanceRow, uint64)
+	TreasuryHistory(ctx context.Context, filter TreasuryQuery) (<-chan TreasuryChangeRow, uint64)
+	DAOEvents(ctx context.Context, filter DAOEventQuery) (<-chan DAOEventRow, uint64)
 
 	Health(ctx context.Context) (status Health, err error)
+
+	// DeleteNonDAOAppRows deletes the local state of applications that are not
+	// SigmaDAO applications and returns the number of deleted rows.
+	DeleteNonDAOAppRows(ctx context.Context) (uint64, error)
+
+	// SetDAOPrograms replaces the registry of known SigmaDAO program versions. An
+	// empty `programs` keeps the registry persisted in the database. Apps created
+	// with a newly added version before the call are not written, see
+	// BackfillDAOApps().
+	SetDAOPrograms(programs []DAOProgram) error
+
+	// SetLedgerState sets the state of the local ledger that AddBlock() reads the
+	// state of newly relevant accounts and assets and new SigmaDAO treasuries from.
+	// It must be at the round before the added block. Without it, only the changes
+	// of the added block are written for them.
+	SetLedgerState(ledger LedgerState)
+
+	// BackfillDAOApps writes the SigmaDAO apps in `ledger` that are not in the
+	// database yet, along with their local state, and returns their number. The
+	// ledger must be at the last round in the database. Only the caches of this
+	// IndexerDb are updated, other processes using the database load the apps when
+	// they open it.
+	BackfillDAOApps(ctx context.Context, ledger DAOBackfillLedger) (uint64, error)
+
+	// SetWebhooks replaces the configured webhooks. The pending deliveries of the
+	// removed webhooks are dropped.
+	SetWebhooks(webhooks []Webhook) error
+
+	// ClaimWebhookDeliveries returns up to `limit` pending deliveries that are due
+	// and postpones their next attempt by `lease`, so that they are attempted again
+	// if no update is recorded. A delivery is never claimed twice at once.
+	ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]WebhookDelivery, error)
+
+	// UpdateWebhookDelivery records the result of a delivery attempt.
+	UpdateWebhookDelivery(ctx context.Context, update WebhookDeliveryUpdate) error
+
+	// APIKeys returns the API keys of the api_key table.
+	APIKeys(ctx context.Context) ([]APIKey, error)
 }
 
 // GetBlockOptions contains the options when requesting to load a block from the database.
@@ -379,6 +425,245 @@ type AppLocalStateRow struct {
 	Error         error
 }
 
+// DAO is the configuration of a SigmaDAO app, decoded from its global state. Fields
+// are nil when the key is missing.
+type DAO struct {
+	AppID   uint64
+	Creator basics.Address
+	// Version is the label of the matched SigmaDAO program version.
+	Version     string
+	Name        *string
+	URL         *string
+	GovTokenID  *uint64
+	Deposit     *uint64
+	MinSupport  *uint64
+	MinDuration *uint64
+	MaxDuration *uint64
+	// ClosedRound is the round in which the app was deleted.
+	ClosedRound *uint64
+	Deleted     bool
+}
+
+// DAORow is one SigmaDAO app in a dao query.
+type DAORow struct {
+	DAO   DAO
+	Error error
+}
+
+// DAOQuery is a parameter object used for querying SigmaDAO apps. Rows are returned
+// in app id order.
+type DAOQuery struct {
+	ApplicationID uint64
+	// ApplicationIDGreaterThan is used for pagination.
+	ApplicationIDGreaterThan uint64
+	Creator                  []byte
+	// Member filters the apps that the account created, holds a deposit in or has
+	// voted in.
+	Member     []byte
+	GovTokenID *uint64
+	// Name is a case-insensitive substring of the dao name.
+	Name    string
+	Version string
+	// Deleted filters by deleted status, nil returns both deleted and live apps.
+	Deleted *bool
+	Limit   uint64
+}
+
+// Proposal is a SigmaDAO proposal, decoded from the local state of the proposal
+// account. Fields are nil when the key is missing.
+type Proposal struct {
+	AppID   uint64
+	Address basics.Address
+	// ProposalID is the "id" key.
+	ProposalID    *uint64
+	Name          *string
+	URL           *string
+	URLHash       []byte
+	HashAlgo      *string
+	VotingStart   *uint64
+	VotingEnd     *uint64
+	ExecuteBefore *uint64
+	Type          *uint64
+	From          *basics.Address
+	Recipient     *basics.Address
+	AsaID         *uint64
+	Amount        *uint64
+	Msg           []byte
+	Executed      bool
+	Yes           uint64
+	No            uint64
+	Abstain       uint64
+	// Status is empty until it is computed at the end of the round.
+	Status           ProposalStatus
+	SnapshotRound    *uint64
+	ExecutionRound   *uint64
+	ExecutionResult  *string
+	ExecutionDetails *string
+	// ClosedRound is the round in which the proposal or its app was closed.
+	ClosedRound *uint64
+	Deleted     bool
+}
+
+// ProposalRow is one proposal in a proposal query.
+type ProposalRow struct {
+	Proposal Proposal
+	Error    error
+}
+
+// ProposalQuery is a parameter object used for querying the proposals of a SigmaDAO
+// app. Rows are returned in address order.
+type ProposalQuery struct {
+	ApplicationID uint64
+	// Optional filters.
+	Address []byte
+	Status  ProposalStatus
+	Type    *uint64
+	// AddressGreaterThan is used for pagination.
+	AddressGreaterThan []byte
+	// Deleted filters by deleted status, nil returns both closed and live proposals.
+	Deleted *bool
+	Limit   uint64
+}
+
+// Vote is the vote of an account on a SigmaDAO proposal.
+type Vote struct {
+	AppID    uint64
+	Proposal basics.Address
+	Voter    basics.Address
+	// Option is nil if the vote was cast before it could be indexed.
+	Option *string
+	Weight *uint64
+	Round  uint64
+	// ClosedRound is the round in which the vote record or its app was cleared.
+	ClosedRound *uint64
+	Deleted     bool
+}
+
+// VoteRow is one vote in a vote query.
+type VoteRow struct {
+	Vote  Vote
+	Error error
+}
+
+// VoteQuery is a parameter object used for querying the votes on a SigmaDAO
+// proposal. Rows are returned in voter order.
+type VoteQuery struct {
+	ApplicationID uint64
+	// Proposal is the proposal account address.
+	Proposal []byte
+	// Optional filters.
+	Voter  []byte
+	Option string
+	// VoterGreaterThan is used for pagination.
+	VoterGreaterThan []byte
+	// Deleted filters by deleted status, nil returns both cleared and live votes.
+	Deleted *bool
+	Limit   uint64
+}
+
+// ProposalTally is the yes, no and abstain counts of a SigmaDAO proposal after the
+// changes made in Round.
+type ProposalTally struct {
+	Round   uint64
+	Yes     uint64
+	No      uint64
+	Abstain uint64
+}
+
+// ProposalTallyRow is one entry of the tally history of a proposal.
+type ProposalTallyRow struct {
+	Tally ProposalTally
+	Error error
+}
+
+// ProposalTallyQuery is a parameter object used for querying the tally history of a
+// proposal. Rows are returned in round order.
+type ProposalTallyQuery struct {
+	ApplicationID uint64
+	// Proposal is the proposal account address.
+	Proposal []byte
+	// MinRound and MaxRound are inclusive, 0 means no bound.
+	MinRound uint64
+	MaxRound uint64
+	Limit    uint64
+}
+
+// ProposalHolder is the governance token balance of an account in the round in which
+// voting on a SigmaDAO proposal started.
+type ProposalHolder struct {
+	Address basics.Address
+	Amount  uint64
+	Round   uint64
+}
+
+// ProposalHolderRow is one entry of the holder snapshot of a proposal.
+type ProposalHolderRow struct {
+	Holder ProposalHolder
+	Error  error
+}
+
+// ProposalHolderQuery is a parameter object used for querying the holder snapshot of
+// a proposal. Rows are returned in address order.
+type ProposalHolderQuery struct {
+	ApplicationID uint64
+	// Proposal is the proposal account address.
+	Proposal []byte
+	// AddressGreaterThan is used for pagination.
+	AddressGreaterThan []byte
+	Limit              uint64
+}
+
+// TreasuryBalance is the current balance of a SigmaDAO treasury in one asset.
+type TreasuryBalance struct {
+	Address basics.Address
+	// AssetID is 0 for ALGO.
+	AssetID uint64
+	Amount  uint64
+	// Round is the round of the last change.
+	Round uint64
+}
+
+// TreasuryBalanceRow is one balance of a treasury.
+type TreasuryBalanceRow struct {
+	Balance TreasuryBalance
+	Error   error
+}
+
+// TreasuryChange is the net change of a treasury balance in a round. Only one of
+// Inflow and Outflow is non-zero. Opening is set for the first row of a balance,
+// which records the balance when tracking started rather than a change.
+type TreasuryChange struct {
+	Address basics.Address
+	// AssetID is 0 for ALGO.
+	AssetID uint64
+	Round   uint64
+	// Amount is the balance after the round.
+	Amount  uint64
+	Inflow  uint64
+	Outflow uint64
+	Opening bool
+}
+
+// TreasuryChangeRow is one entry of the history of a treasury.
+type TreasuryChangeRow struct {
+	Change TreasuryChange
+	Error  error
+}
+
+// TreasuryQuery is a parameter object used for querying the treasuries of a SigmaDAO
+// app. Balances are returned in address and asset order, history rows in round
+// order.
+type TreasuryQuery struct {
+	ApplicationID uint64
+	// Optional filters.
+	Address []byte
+	AssetID *uint64
+	// MinRound and MaxRound are inclusive, 0 means no bound. Only used for history.
+	MinRound uint64
+	MaxRound uint64
+	Limit    uint64
+}
+
 // IndexerDbOptions are the options common to all indexer backends.
 type IndexerDbOptions struct {
 	ReadOnly bool
@@ -391,6 +676,17 @@ type IndexerDbOptions struct {
 	AlgodDataDir   string
 	AlgodToken     string
 	AlgodAddr      string
+
+	// DAOPrograms is the registry of known SigmaDAO program versions. If empty,
+	// the registry persisted by a previous run is used.
+	DAOPrograms []DAOProgram
+
+	// FullIndexing disables the relevance filter, every account, asset and asset
+	// holding is written instead of only the SigmaDAO related ones.
+	FullIndexing bool
+
+	// DAOEventHub receives the SigmaDAO events of every committed round, if set.
+	DAOEventHub *DAOEventHub
 }
 
 // Health is the response object that IndexerDb objects need to return from the Health method.
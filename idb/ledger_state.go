@@ -0,0 +1,22 @@
+package idb
+
+import (
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+)
+
+// LedgerState is the local ledger state read when adding a block, see
+// IndexerDb.SetLedgerState(). It is the state at the round before the block, the
+// block's own changes are taken from its state delta.
+type LedgerState interface {
+	// Account returns the account data of `address`, without rewards.
+	Account(address basics.Address) (ledgercore.AccountData, error)
+	// AssetHoldings returns all the asset holdings of `address`.
+	AssetHoldings(address basics.Address) (map[basics.AssetIndex]basics.AssetHolding, error)
+	// AssetHolding returns the holding of `assetID` by `address`, nil if it is not
+	// opted in.
+	AssetHolding(address basics.Address, assetID basics.AssetIndex) (*basics.AssetHolding, error)
+	// Asset returns the creator and the params of `assetID`, nil params if it does
+	// not exist.
+	Asset(assetID basics.AssetIndex) (basics.Address, *basics.AssetParams, error)
+}
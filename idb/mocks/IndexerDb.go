@@ -15,6 +15,8 @@ import (
 
 	testing "testing"
 
+	time "time"
+
 	transactions "github.com/algorand/go-algorand/data/transactions"
 )
 
@@ -23,6 +25,29 @@ type IndexerDb struct {
 	mock.Mock
 }
 
+// APIKeys provides a mock function with given fields: ctx
+func (_m *IndexerDb) APIKeys(ctx context.Context) ([]idb.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []idb.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []idb.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]idb.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddBlock provides a mock function with given fields: block
 func (_m *IndexerDb) AddBlock(block *ledgercore.ValidatedBlock) error {
 	ret := _m.Called(block)
@@ -129,11 +154,122 @@ func (_m *IndexerDb) Assets(ctx context.Context, filter idb.AssetsQuery) (<-chan
 	return r0, r1
 }
 
+// BackfillDAOApps provides a mock function with given fields: ctx, ledger
+func (_m *IndexerDb) BackfillDAOApps(ctx context.Context, ledger idb.DAOBackfillLedger) (uint64, error) {
+	ret := _m.Called(ctx, ledger)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, idb.DAOBackfillLedger) uint64); ok {
+		r0 = rf(ctx, ledger)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, idb.DAOBackfillLedger) error); ok {
+		r1 = rf(ctx, ledger)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimWebhookDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *IndexerDb) ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]idb.WebhookDelivery, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	var r0 []idb.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) []idb.WebhookDelivery); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]idb.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *IndexerDb) Close() {
 	_m.Called()
 }
 
+// DAOEvents provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) DAOEvents(ctx context.Context, filter idb.DAOEventQuery) (<-chan idb.DAOEventRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.DAOEventRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.DAOEventQuery) <-chan idb.DAOEventRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.DAOEventRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.DAOEventQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// DAOs provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) DAOs(ctx context.Context, filter idb.DAOQuery) (<-chan idb.DAORow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.DAORow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.DAOQuery) <-chan idb.DAORow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.DAORow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.DAOQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// DeleteNonDAOAppRows provides a mock function with given fields: ctx
+func (_m *IndexerDb) DeleteNonDAOAppRows(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccounts provides a mock function with given fields: ctx, opts
 func (_m *IndexerDb) GetAccounts(ctx context.Context, opts idb.AccountQueryOptions) (<-chan idb.AccountRow, uint64) {
 	ret := _m.Called(ctx, opts)
@@ -255,6 +391,94 @@ func (_m *IndexerDb) LoadGenesis(genesis bookkeeping.Genesis) error {
 	return r0
 }
 
+// ProposalHolders provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) ProposalHolders(ctx context.Context, filter idb.ProposalHolderQuery) (<-chan idb.ProposalHolderRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.ProposalHolderRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.ProposalHolderQuery) <-chan idb.ProposalHolderRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.ProposalHolderRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.ProposalHolderQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// ProposalTallies provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) ProposalTallies(ctx context.Context, filter idb.ProposalTallyQuery) (<-chan idb.ProposalTallyRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.ProposalTallyRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.ProposalTallyQuery) <-chan idb.ProposalTallyRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.ProposalTallyRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.ProposalTallyQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// Proposals provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) Proposals(ctx context.Context, filter idb.ProposalQuery) (<-chan idb.ProposalRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.ProposalRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.ProposalQuery) <-chan idb.ProposalRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.ProposalRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.ProposalQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// SetDAOPrograms provides a mock function with given fields: programs
+func (_m *IndexerDb) SetDAOPrograms(programs []idb.DAOProgram) error {
+	ret := _m.Called(programs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]idb.DAOProgram) error); ok {
+		r0 = rf(programs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLedgerState provides a mock function with given fields: ledger
+func (_m *IndexerDb) SetLedgerState(ledger idb.LedgerState) {
+	_m.Called(ledger)
+}
+
 // SetNetworkState provides a mock function with given fields: genesis
 func (_m *IndexerDb) SetNetworkState(genesis bookkeeping.Genesis) error {
 	ret := _m.Called(genesis)
@@ -269,6 +493,20 @@ func (_m *IndexerDb) SetNetworkState(genesis bookkeeping.Genesis) error {
 	return r0
 }
 
+// SetWebhooks provides a mock function with given fields: webhooks
+func (_m *IndexerDb) SetWebhooks(webhooks []idb.Webhook) error {
+	ret := _m.Called(webhooks)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]idb.Webhook) error); ok {
+		r0 = rf(webhooks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Transactions provides a mock function with given fields: ctx, tf
 func (_m *IndexerDb) Transactions(ctx context.Context, tf idb.TransactionFilter) (<-chan idb.TxnRow, uint64) {
 	ret := _m.Called(ctx, tf)
@@ -292,6 +530,89 @@ func (_m *IndexerDb) Transactions(ctx context.Context, tf idb.TransactionFilter)
 	return r0, r1
 }
 
+// TreasuryBalances provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) TreasuryBalances(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryBalanceRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.TreasuryBalanceRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.TreasuryQuery) <-chan idb.TreasuryBalanceRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.TreasuryBalanceRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.TreasuryQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// TreasuryHistory provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) TreasuryHistory(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryChangeRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.TreasuryChangeRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.TreasuryQuery) <-chan idb.TreasuryChangeRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.TreasuryChangeRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.TreasuryQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
+// UpdateWebhookDelivery provides a mock function with given fields: ctx, update
+func (_m *IndexerDb) UpdateWebhookDelivery(ctx context.Context, update idb.WebhookDeliveryUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, idb.WebhookDeliveryUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Votes provides a mock function with given fields: ctx, filter
+func (_m *IndexerDb) Votes(ctx context.Context, filter idb.VoteQuery) (<-chan idb.VoteRow, uint64) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 <-chan idb.VoteRow
+	if rf, ok := ret.Get(0).(func(context.Context, idb.VoteQuery) <-chan idb.VoteRow); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan idb.VoteRow)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, idb.VoteQuery) uint64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	return r0, r1
+}
+
 // NewIndexerDb creates a new instance of IndexerDb. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewIndexerDb(t testing.TB) *IndexerDb {
 	mock := &IndexerDb{}
@@ -738,3 +738,59 @@ func DecodeTrimmedLcAccountData(data []byte) (ledgercore.AccountData, error) {
 
 	return unconvertTrimmedLcAccountData(ba), nil
 }
+
+func convertDAOPrograms(programs []idb.DAOProgram) []daoProgram {
+	res := make([]daoProgram, len(programs))
+	for i, program := range programs {
+		res[i] = daoProgram{
+			Version:         program.Version,
+			ApprovalProgram: program.ApprovalProgram,
+			MaskConstants:   program.MaskConstants,
+		}
+	}
+	return res
+}
+
+func unconvertDAOPrograms(programs []daoProgram) []idb.DAOProgram {
+	res := make([]idb.DAOProgram, len(programs))
+	for i, program := range programs {
+		res[i] = idb.DAOProgram{
+			Version:         program.Version,
+			ApprovalProgram: program.ApprovalProgram,
+			MaskConstants:   program.MaskConstants,
+		}
+	}
+	return res
+}
+
+// EncodeDAOPrograms encodes the SigmaDAO program registry into json.
+func EncodeDAOPrograms(programs []idb.DAOProgram) []byte {
+	return encodeJSON(convertDAOPrograms(programs))
+}
+
+// DecodeDAOPrograms decodes the SigmaDAO program registry from json.
+func DecodeDAOPrograms(data []byte) ([]idb.DAOProgram, error) {
+	var programs []daoProgram
+	err := DecodeJSON(data, &programs)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeDAOPrograms() err: %w", err)
+	}
+
+	return unconvertDAOPrograms(programs), nil
+}
+
+// EncodeDAOEventArgs encodes the arguments of a SigmaDAO method call into json.
+func EncodeDAOEventArgs(args *idb.DAOEventArgs) []byte {
+	return encodeJSON(args)
+}
+
+// DecodeDAOEventArgs decodes the arguments of a SigmaDAO method call from json.
+func DecodeDAOEventArgs(data []byte) (idb.DAOEventArgs, error) {
+	var args idb.DAOEventArgs
+	err := DecodeJSON(data, &args)
+	if err != nil {
+		return idb.DAOEventArgs{}, fmt.Errorf("DecodeDAOEventArgs() err: %w", err)
+	}
+
+	return args, nil
+}
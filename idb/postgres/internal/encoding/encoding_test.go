@@ -599,3 +599,48 @@ func TestLcAccountDataEncoding(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, ad, decodedAd)
 }
+
+// Test that encoding of the SigmaDAO program registry is as expected and that
+// decoding results in the same object.
+func TestDAOProgramsEncoding(t *testing.T) {
+	programs := []idb.DAOProgram{
+		{
+			Version:         "v1",
+			ApprovalProgram: []byte{6, 32, 1, 1},
+		},
+		{
+			Version:         "v2",
+			ApprovalProgram: []byte{7},
+			MaskConstants:   true,
+		},
+	}
+
+	buf := EncodeDAOPrograms(programs)
+
+	expectedString :=
+		`[{"approval-program":"BiABAQ==","version":"v1"},{"approval-program":"Bw==","mask-constants":true,"version":"v2"}]`
+	assert.Equal(t, expectedString, string(buf))
+
+	decodedPrograms, err := DecodeDAOPrograms(buf)
+	require.NoError(t, err)
+	assert.Equal(t, programs, decodedPrograms)
+}
+
+func TestDAOEventArgsEncoding(t *testing.T) {
+	name := "my proposal"
+	votingStart := uint64(100)
+	args := idb.DAOEventArgs{
+		Name:        &name,
+		URLHash:     []byte{1, 2},
+		VotingStart: &votingStart,
+	}
+
+	buf := EncodeDAOEventArgs(&args)
+
+	expectedString := `{"name":"my proposal","url-hash":"AQI=","voting-start":100}`
+	assert.Equal(t, expectedString, string(buf))
+
+	decodedArgs, err := DecodeDAOEventArgs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, args, decodedArgs)
+}
@@ -133,3 +133,9 @@ type baseAccountData struct {
 
 	baseOnlineAccountData
 }
+
+type daoProgram struct {
+	Version         string `codec:"version"`
+	ApprovalProgram []byte `codec:"approval-program"`
+	MaskConstants   bool   `codec:"mask-constants,omitempty"`
+}
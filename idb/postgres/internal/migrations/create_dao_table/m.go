@@ -0,0 +1,182 @@
+package createdaotable
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+	"github.com/algorand/indexer/util"
+)
+
+var createQueries = []string{
+	`CREATE TABLE IF NOT EXISTS dao (
+		app bigint PRIMARY KEY,
+		creator bytea NOT NULL,
+		name text,
+		url text,
+		gov_token_id bigint,
+		deposit numeric(20),
+		min_support numeric(20),
+		min_duration bigint,
+		max_duration bigint,
+		deleted bool NOT NULL
+	)`,
+	"CREATE INDEX IF NOT EXISTS dao_by_gov_token_id ON dao(gov_token_id)",
+	// Deleted apps have no global state left, keep what the old columns say.
+	`INSERT INTO dao (app, creator, name, gov_token_id, deleted)
+		SELECT index, creator, rtrim(dao_name), asset_id, deleted FROM app
+		ON CONFLICT (app) DO NOTHING`,
+}
+
+// upsertDAOQuery writes the `dao` row of an app. It matches the `dao` table created
+// above, later migrations must not change it.
+const upsertDAOQuery = `INSERT INTO dao
+	(app, creator, name, url, gov_token_id, deposit, min_support, min_duration,
+	max_duration, deleted)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE) ON CONFLICT (app) DO UPDATE SET
+	creator = EXCLUDED.creator, name = EXCLUDED.name, url = EXCLUDED.url,
+	gov_token_id = EXCLUDED.gov_token_id, deposit = EXCLUDED.deposit,
+	min_support = EXCLUDED.min_support, min_duration = EXCLUDED.min_duration,
+	max_duration = EXCLUDED.max_duration, deleted = FALSE`
+
+var dropQueries = []string{
+	"ALTER TABLE app DROP COLUMN IF EXISTS dao_name",
+	"ALTER TABLE app DROP COLUMN IF EXISTS asset_id",
+}
+
+type app struct {
+	index   basics.AppIndex
+	creator basics.Address
+	params  basics.AppParams
+}
+
+func getApps(tx pgx.Tx) ([]app, error) {
+	rows, err := tx.Query(
+		context.Background(), "SELECT index, creator, params FROM app WHERE NOT deleted")
+	if err != nil {
+		return nil, fmt.Errorf("getApps() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var res []app
+	for rows.Next() {
+		var index uint64
+		var creator []byte
+		var params []byte
+		err = rows.Scan(&index, &creator, &params)
+		if err != nil {
+			return nil, fmt.Errorf("getApps() scan err: %w", err)
+		}
+
+		res = append(res, app{index: basics.AppIndex(index)})
+		e := &res[len(res)-1]
+		copy(e.creator[:], creator)
+		e.params, err = encoding.DecodeAppParams(params)
+		if err != nil {
+			return nil, fmt.Errorf("getApps() decode err: %w", err)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("getApps() rows error err: %w", err)
+	}
+
+	return res, nil
+}
+
+// tealUint returns the uint value of `key` or nil if the key is missing.
+func tealUint(kv basics.TealKeyValue, key string) *uint64 {
+	tv, ok := kv[key]
+	if !ok || tv.Type != basics.TealUintType {
+		return nil
+	}
+	res := new(uint64)
+	*res = tv.Uint
+	return res
+}
+
+// tealNumeric returns the uint value of `key` formatted for a numeric(20) column or
+// nil if the key is missing.
+func tealNumeric(kv basics.TealKeyValue, key string) *string {
+	value := tealUint(kv, key)
+	if value == nil {
+		return nil
+	}
+	res := new(string)
+	*res = strconv.FormatUint(*value, 10)
+	return res
+}
+
+// tealString returns the bytes value of `key` as a printable string or nil if the
+// key is missing.
+func tealString(kv basics.TealKeyValue, key string) *string {
+	tv, ok := kv[key]
+	if !ok || tv.Type != basics.TealBytesType {
+		return nil
+	}
+	res := new(string)
+	*res = util.PrintableUTF8OrEmpty(tv.Bytes)
+	return res
+}
+
+func writeDAOs(tx pgx.Tx, apps []app) error {
+	var batch pgx.Batch
+	for i := range apps {
+		gs := apps[i].params.GlobalState
+		batch.Queue(
+			upsertDAOQuery, uint64(apps[i].index), apps[i].creator[:], tealString(gs, "dao_name"),
+			tealString(gs, "url"), tealUint(gs, "gov_token_id"), tealNumeric(gs, "deposit"),
+			tealNumeric(gs, "min_support"), tealUint(gs, "min_duration"),
+			tealUint(gs, "max_duration"))
+	}
+
+	results := tx.SendBatch(context.Background(), &batch)
+	// Clean the results off the connection's queue. Without this, weird things happen.
+	for i := 0; i < batch.Len(); i++ {
+		_, err := results.Exec()
+		if err != nil {
+			results.Close()
+			return fmt.Errorf("writeDAOs() exec err: %w", err)
+		}
+	}
+	err := results.Close()
+	if err != nil {
+		return fmt.Errorf("writeDAOs() close results err: %w", err)
+	}
+
+	return nil
+}
+
+// RunMigration creates the `dao` table, fills it from the global state of the apps
+// in the `app` table and drops the old `app.dao_name` and `app.asset_id` columns.
+func RunMigration(tx pgx.Tx) error {
+	for _, query := range createQueries {
+		_, err := tx.Exec(context.Background(), query)
+		if err != nil {
+			return fmt.Errorf("RunMigration() exec err: %w", err)
+		}
+	}
+
+	apps, err := getApps(tx)
+	if err != nil {
+		return fmt.Errorf("RunMigration() err: %w", err)
+	}
+	err = writeDAOs(tx, apps)
+	if err != nil {
+		return fmt.Errorf("RunMigration() err: %w", err)
+	}
+
+	for _, query := range dropQueries {
+		_, err := tx.Exec(context.Background(), query)
+		if err != nil {
+			return fmt.Errorf("RunMigration() exec err: %w", err)
+		}
+	}
+
+	return nil
+}
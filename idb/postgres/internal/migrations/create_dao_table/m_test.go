@@ -0,0 +1,116 @@
+package createdaotable_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+	cdt "github.com/algorand/indexer/idb/postgres/internal/migrations/create_dao_table"
+	pgtest "github.com/algorand/indexer/idb/postgres/internal/testing"
+	pgutil "github.com/algorand/indexer/idb/postgres/internal/util"
+	"github.com/algorand/indexer/util/test"
+)
+
+func TestCreateDAOTable(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgres(t)
+	defer shutdownFunc()
+
+	// The app table before the migration.
+	_, err := db.Exec(
+		context.Background(),
+		`CREATE TABLE app (
+			index bigint PRIMARY KEY,
+			creator bytea NOT NULL,
+			params jsonb NOT NULL,
+			dao_name CHAR(255),
+			asset_id BIGINT,
+			version text,
+			deleted bool NOT NULL
+		)`)
+	require.NoError(t, err)
+
+	params := basics.AppParams{
+		GlobalState: basics.TealKeyValue{
+			"dao_name":     {Type: basics.TealBytesType, Bytes: "my dao"},
+			"url":          {Type: basics.TealBytesType, Bytes: "www.example.com"},
+			"gov_token_id": {Type: basics.TealUintType, Uint: 12},
+			"deposit":      {Type: basics.TealUintType, Uint: 15},
+			"min_support":  {Type: basics.TealUintType, Uint: 5},
+			"min_duration": {Type: basics.TealUintType, Uint: 60},
+			"max_duration": {Type: basics.TealUintType, Uint: 600},
+		},
+	}
+	query := `INSERT INTO app (index, creator, params, dao_name, asset_id, deleted)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = db.Exec(
+		context.Background(), query, 1, test.AccountA[:], encoding.EncodeAppParams(params),
+		"my dao", 12, false)
+	require.NoError(t, err)
+	_, err = db.Exec(
+		context.Background(), query, 2, test.AccountB[:], "null", "old dao", 13, true)
+	require.NoError(t, err)
+
+	f := func(tx pgx.Tx) error {
+		return cdt.RunMigration(tx)
+	}
+	err = pgutil.TxWithRetry(db, pgx.TxOptions{IsoLevel: pgx.Serializable}, f, nil)
+	require.NoError(t, err)
+
+	var creator []byte
+	var name string
+	var url *string
+	var govTokenID uint64
+	var deposit, minSupport *string
+	var minDuration, maxDuration *uint64
+	var deleted bool
+
+	daoQuery := `SELECT creator, name, url, gov_token_id, deposit::text, min_support::text,
+		min_duration, max_duration, deleted FROM dao WHERE app = $1`
+	row := db.QueryRow(context.Background(), daoQuery, 1)
+	err = row.Scan(
+		&creator, &name, &url, &govTokenID, &deposit, &minSupport, &minDuration, &maxDuration,
+		&deleted)
+	require.NoError(t, err)
+	assert.Equal(t, test.AccountA[:], creator)
+	assert.Equal(t, "my dao", name)
+	require.NotNil(t, url)
+	assert.Equal(t, "www.example.com", *url)
+	assert.Equal(t, uint64(12), govTokenID)
+	require.NotNil(t, deposit)
+	assert.Equal(t, "15", *deposit)
+	require.NotNil(t, minSupport)
+	assert.Equal(t, "5", *minSupport)
+	require.NotNil(t, minDuration)
+	assert.Equal(t, uint64(60), *minDuration)
+	require.NotNil(t, maxDuration)
+	assert.Equal(t, uint64(600), *maxDuration)
+	assert.False(t, deleted)
+
+	row = db.QueryRow(context.Background(), daoQuery, 2)
+	err = row.Scan(
+		&creator, &name, &url, &govTokenID, &deposit, &minSupport, &minDuration, &maxDuration,
+		&deleted)
+	require.NoError(t, err)
+	assert.Equal(t, test.AccountB[:], creator)
+	assert.Equal(t, "old dao", name)
+	assert.Nil(t, url)
+	assert.Equal(t, uint64(13), govTokenID)
+	assert.Nil(t, deposit)
+	assert.True(t, deleted)
+
+	// The old columns are gone.
+	var count int
+	row = db.QueryRow(
+		context.Background(),
+		`SELECT count(*) FROM information_schema.columns
+		WHERE table_name = 'app' AND column_name IN ('dao_name', 'asset_id')`)
+	err = row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
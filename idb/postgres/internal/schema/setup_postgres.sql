@@ -2,8 +2,31 @@
 --
 -- TODO? replace all 'addr bytea' with 'addr_id bigint' and a mapping table? makes addrs an 8 byte int that fits in a register instead of a 32 byte string
 
--- Optional, to make txn queries by asset fast:
--- CREATE INDEX CONCURRENTLY IF NOT EXISTS txn_asset ON txn (asset, round, intra);
+-- Transaction groups calling a SigmaDAO app, including inner transactions and the
+-- payments and asset transfers grouped with the calls
+CREATE TABLE IF NOT EXISTS dao_txn (
+  round bigint NOT NULL,
+  intra integer NOT NULL, -- offset in the block, inner transactions included
+  typeenum smallint NOT NULL,
+  asset bigint NOT NULL, -- 0 if unknown, otherwise the asset or app id
+  txid bytea, -- base32 of [32]byte hash, or NULL for inner transactions
+  txn jsonb NOT NULL, -- json encoding of signed txn with apply data; inner txns exclude nested inner txns
+  extra jsonb NOT NULL,
+  round_time timestamp with time zone NOT NULL, -- block timestamp
+  PRIMARY KEY (round, intra)
+);
+
+-- For looking up the transactions of a dao app or asset
+CREATE INDEX IF NOT EXISTS dao_txn_by_asset ON dao_txn (asset, round, intra);
+CREATE INDEX IF NOT EXISTS dao_txn_by_txid ON dao_txn (txid);
+
+-- Accounts referenced by dao_txn rows
+CREATE TABLE IF NOT EXISTS dao_txn_participation (
+  addr bytea NOT NULL,
+  round bigint NOT NULL,
+  intra integer NOT NULL,
+  PRIMARY KEY (addr, round, intra)
+);
 
 -- expand data.basics.AccountData
 CREATE TABLE IF NOT EXISTS account (
@@ -56,14 +79,31 @@ CREATE TABLE IF NOT EXISTS app (
   index bigint PRIMARY KEY,
   creator bytea NOT NULL, -- account address
   params jsonb NOT NULL, -- json string "null" iff app is deleted
-  dao_name CHAR(255), -- dao name
-  asset_id BIGINT, -- token id
+  version text, -- label of the matched SigmaDAO program version
   deleted bool NOT NULL -- whether or not it is currently deleted
 );
 
 -- For account lookup
 CREATE INDEX IF NOT EXISTS app_by_creator_deleted ON app(creator, deleted);
 
+-- SigmaDAO configuration, decoded from the global state of SigmaDAO apps
+CREATE TABLE IF NOT EXISTS dao (
+  app bigint PRIMARY KEY, -- dao app id
+  creator bytea NOT NULL, -- account address
+  name text, -- "dao_name" key
+  url text,
+  gov_token_id bigint, -- governance token asset id
+  deposit numeric(20), -- deposit required to add a proposal
+  min_support numeric(20), -- minimum number of yes votes for a proposal to pass
+  min_duration bigint, -- minimum voting duration in seconds
+  max_duration bigint, -- maximum voting duration in seconds
+  closed_round bigint, -- round in which the dao app was deleted
+  deleted bool NOT NULL -- whether or not the dao app is currently deleted
+);
+
+-- For looking up daos by governance token
+CREATE INDEX IF NOT EXISTS dao_by_gov_token_id ON dao(gov_token_id);
+
 -- per-account app local state
 CREATE TABLE IF NOT EXISTS account_app (
   addr bytea,
@@ -77,3 +117,180 @@ CREATE TABLE IF NOT EXISTS account_app (
 
 -- For looking up existing app local states by account
 CREATE INDEX IF NOT EXISTS account_app_by_addr_partial ON account_app(addr) WHERE NOT deleted;
+
+-- SigmaDAO proposals, decoded from the local state of proposal accounts
+CREATE TABLE IF NOT EXISTS proposal (
+  app bigint NOT NULL, -- dao app id
+  addr bytea NOT NULL, -- proposal account address
+  proposal_id bigint, -- "id" key
+  name text,
+  url text,
+  url_hash bytea,
+  hash_algo text,
+  voting_start bigint, -- unix timestamp
+  voting_end bigint, -- unix timestamp
+  execute_before bigint, -- unix timestamp
+  type bigint, -- 1: algo transfer, 2: asa transfer, 3: message
+  from_addr bytea, -- "from" key, account paying a transfer
+  recipient bytea,
+  asa_id bigint,
+  amount numeric(20),
+  msg bytea,
+  executed bool NOT NULL,
+  yes numeric(20) NOT NULL,
+  no numeric(20) NOT NULL,
+  abstain numeric(20) NOT NULL,
+  status text, -- pending, voting, passed, rejected, executed or expired; recomputed every round
+  snapshot_round bigint, -- round of the holder snapshot; NULL until voting starts, 0 if voting started before snapshots existed
+  execution_round bigint, -- round of the execute call
+  execution_result text, -- "match" or "mismatch" of the execute payout against the proposal terms
+  execution_details text, -- description of the mismatch
+  closed_round bigint, -- round in which the proposal or its dao app was closed
+  deleted bool NOT NULL, -- whether or not the proposal has been closed or its dao app deleted
+  PRIMARY KEY (app, addr)
+);
+
+-- Governance token balances at the round in which voting on a proposal started
+CREATE TABLE IF NOT EXISTS proposal_holder_snapshot (
+  app bigint NOT NULL, -- dao app id
+  proposal bytea NOT NULL, -- proposal account address
+  holder bytea NOT NULL,
+  amount numeric(20) NOT NULL,
+  round bigint NOT NULL,
+  PRIMARY KEY (app, proposal, holder)
+);
+
+-- SigmaDAO votes, from the "p_" + proposal address keys of voter local state
+CREATE TABLE IF NOT EXISTS vote (
+  app bigint NOT NULL, -- dao app id
+  proposal bytea NOT NULL, -- proposal account address
+  voter bytea NOT NULL, -- voter account address
+  vote_option text, -- "yes", "no" or "abstain"; NULL if the vote was cast before it was indexed
+  weight numeric(20), -- voter deposit when voting; NULL if unknown
+  round bigint NOT NULL, -- round at which the vote was recorded
+  closed_round bigint, -- round in which the vote record or its dao app was cleared
+  deleted bool NOT NULL, -- whether or not the vote record has been cleared or its dao app deleted
+  PRIMARY KEY (app, proposal, voter)
+);
+
+-- For looking up the votes of an account
+CREATE INDEX IF NOT EXISTS vote_by_voter ON vote(voter);
+
+-- One row per change of the yes, no or abstain counts of a proposal
+CREATE TABLE IF NOT EXISTS proposal_tally_history (
+  app bigint NOT NULL,
+  addr bytea NOT NULL, -- proposal account address
+  round bigint NOT NULL,
+  yes numeric(20) NOT NULL,
+  no numeric(20) NOT NULL,
+  abstain numeric(20) NOT NULL,
+  PRIMARY KEY (app, addr, round)
+);
+
+-- SigmaDAO vote token deposits, from the "deposit" and "deposit_lock" keys of member local state
+CREATE TABLE IF NOT EXISTS dao_deposit (
+  app bigint NOT NULL, -- dao app id
+  addr bytea NOT NULL, -- member account address
+  amount numeric(20) NOT NULL, -- deposited governance tokens
+  deposit_lock bigint, -- unix timestamp until which the deposit is locked
+  round bigint NOT NULL, -- round of the last change
+  closed_round bigint, -- round in which the member cleared out or the dao app was deleted
+  deleted bool NOT NULL, -- whether or not the member has cleared out of the dao or it was deleted
+  PRIMARY KEY (app, addr)
+);
+
+-- One row per change of a dao_deposit row
+CREATE TABLE IF NOT EXISTS dao_deposit_history (
+  app bigint NOT NULL,
+  addr bytea NOT NULL,
+  round bigint NOT NULL,
+  amount numeric(20) NOT NULL,
+  deposit_lock bigint,
+  PRIMARY KEY (app, addr, round)
+);
+
+-- Balances of SigmaDAO treasuries, the "from" accounts of proposals
+CREATE TABLE IF NOT EXISTS dao_treasury (
+  app bigint NOT NULL, -- dao app id
+  addr bytea NOT NULL, -- treasury account address
+  asset bigint NOT NULL, -- asset id, 0 for ALGO
+  amount numeric(20) NOT NULL,
+  round bigint NOT NULL, -- round of the last change
+  PRIMARY KEY (app, addr, asset)
+);
+
+-- One row per round in which a dao_treasury balance changes
+CREATE TABLE IF NOT EXISTS dao_treasury_history (
+  app bigint NOT NULL,
+  addr bytea NOT NULL,
+  asset bigint NOT NULL,
+  round bigint NOT NULL,
+  amount numeric(20) NOT NULL, -- balance after the round
+  change numeric(21), -- net inflow (positive) or outflow (negative) in the round; NULL for the opening balance
+  PRIMARY KEY (app, addr, asset, round)
+);
+
+-- Decoded SigmaDAO method calls, including inner transactions
+CREATE TABLE IF NOT EXISTS dao_event (
+  round bigint NOT NULL,
+  intra integer NOT NULL, -- offset in the block, same as dao_txn
+  app bigint NOT NULL, -- dao app id
+  txid bytea NOT NULL, -- base32 txid; the root transaction id for inner transactions
+  sender bytea NOT NULL,
+  proposal bytea, -- proposal account address, NULL if the method is not about a proposal
+  method text NOT NULL, -- first app call argument, e.g. "register_vote"
+  args jsonb NOT NULL, -- decoded method arguments, idb.DAOEventArgs
+  PRIMARY KEY (round, intra)
+);
+
+-- For looking up the events of a dao app or of a proposal
+CREATE INDEX IF NOT EXISTS dao_event_by_app ON dao_event (app, round, intra);
+CREATE INDEX IF NOT EXISTS dao_event_by_proposal ON dao_event (proposal) WHERE proposal IS NOT NULL;
+
+-- One row per round in which the status of a proposal changes
+CREATE TABLE IF NOT EXISTS proposal_status_history (
+  app bigint NOT NULL,
+  addr bytea NOT NULL, -- proposal account address
+  round bigint NOT NULL,
+  status text NOT NULL, -- new status, idb.ProposalStatus
+  PRIMARY KEY (app, addr, round)
+);
+
+-- For streaming the status changes of every dao from a round
+CREATE INDEX IF NOT EXISTS proposal_status_history_by_round ON proposal_status_history (round);
+
+-- Webhooks notified of the SigmaDAO events, from the webhooks config section
+CREATE TABLE IF NOT EXISTS webhook (
+  name text PRIMARY KEY,
+  url text NOT NULL,
+  secret text NOT NULL, -- HMAC-SHA256 key signing the request bodies
+  apps bigint[], -- dao app ids, NULL for all apps
+  kinds text[] -- idb.DAOEventKind values, NULL for all kinds
+);
+
+-- Outbox of the webhook deliveries, written in the same transaction as the block of the event
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+  id bigserial PRIMARY KEY,
+  webhook text NOT NULL REFERENCES webhook (name) ON DELETE CASCADE,
+  round bigint NOT NULL,
+  kind text NOT NULL, -- idb.DAOEventKind
+  payload jsonb NOT NULL, -- request body
+  status text NOT NULL, -- idb.WebhookDeliveryStatus
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt timestamp with time zone NOT NULL,
+  last_error text,
+  delivered_at timestamp with time zone
+);
+
+-- For claiming the due deliveries
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending ON webhook_delivery (next_attempt, id) WHERE status = 'pending';
+
+-- REST API keys, in addition to the api-keys config section
+CREATE TABLE IF NOT EXISTS api_key (
+  name text PRIMARY KEY,
+  key_hash bytea NOT NULL UNIQUE, -- SHA-256 hash of the key
+  scopes text[] NOT NULL, -- idb.APIKeyScope values
+  rate_limit double precision NOT NULL DEFAULT 0, -- requests per second, 0 for unlimited
+  burst integer NOT NULL DEFAULT 0, -- 0 for the rate limit rounded up
+  expires_at timestamp with time zone -- NULL for never
+);
@@ -0,0 +1,35 @@
+package writer
+
+import (
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/jackc/pgx/v4"
+)
+
+// daoArgs returns the arguments of the upsert dao statement decoded from the global
+// state of a SigmaDAO app.
+func daoArgs(appID basics.AppIndex, creator basics.Address, params *basics.AppParams) []interface{} {
+	gs := params.GlobalState
+	return []interface{}{
+		appID, creator[:], tealString(gs, DAOName), tealString(gs, URL),
+		tealUint(gs, GovTokenId), tealNumeric(gs, Deposit), tealNumeric(gs, MinSupport),
+		tealUint(gs, MinDuration), tealUint(gs, MaxDuration),
+	}
+}
+
+func writeDAO(appID basics.AppIndex, creator basics.Address, params *basics.AppParams, batch *pgx.Batch) {
+	batch.Queue(upsertDAOStmtName, daoArgs(appID, creator, params)...)
+}
+
+// writeDAODeletions marks the SigmaDAO apps deleted in `changes` and all their
+// proposals, votes and deposits as deleted, closed at `round`.
+func writeDAODeletions(round basics.Round, changes DAOAppChanges, batch *pgx.Batch) {
+	for appID, created := range changes {
+		if created {
+			continue
+		}
+		batch.Queue(deleteDAOStmtName, appID, uint64(round))
+		batch.Queue(closeDAOProposalsStmtName, appID, uint64(round))
+		batch.Queue(closeDAOVotesStmtName, appID, uint64(round))
+		batch.Queue(closeDAODepositsStmtName, appID, uint64(round))
+	}
+}
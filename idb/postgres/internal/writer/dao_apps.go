@@ -0,0 +1,71 @@
+package writer
+
+import (
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+// DAOAppCache is the set of ids of the SigmaDAO apps in the `app` table. It lets the
+// writer skip the state of other apps without querying the database. It is not
+// thread-safe, a nil cache is empty.
+type DAOAppCache struct {
+	apps map[basics.AppIndex]struct{}
+}
+
+// MakeDAOAppCache creates a cache containing `apps`.
+func MakeDAOAppCache(apps []basics.AppIndex) *DAOAppCache {
+	c := &DAOAppCache{
+		apps: make(map[basics.AppIndex]struct{}, len(apps)),
+	}
+	for _, app := range apps {
+		c.apps[app] = struct{}{}
+	}
+	return c
+}
+
+// Contains returns true if `app` is a SigmaDAO app.
+func (c *DAOAppCache) Contains(app basics.AppIndex) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.apps[app]
+	return ok
+}
+
+// Len returns the number of SigmaDAO apps.
+func (c *DAOAppCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.apps)
+}
+
+// Apply adds the apps created and removes the apps deleted in `changes`. It must only
+// be called once the database transaction that made the changes is committed.
+func (c *DAOAppCache) Apply(changes DAOAppChanges) {
+	for app, created := range changes {
+		if created {
+			c.apps[app] = struct{}{}
+		} else {
+			delete(c.apps, app)
+		}
+	}
+}
+
+// DAOAppChanges maps the SigmaDAO apps created or deleted by a block to true if the
+// app was created and false if it was deleted.
+type DAOAppChanges map[basics.AppIndex]bool
+
+// daoAppView is the set of SigmaDAO apps as seen while writing a block.
+type daoAppView struct {
+	cache   *DAOAppCache
+	changes DAOAppChanges
+}
+
+// contains returns true if `app` is a SigmaDAO app at some point of the block. The
+// local state of an app deleted in the block is still written.
+func (v daoAppView) contains(app basics.AppIndex) bool {
+	if _, ok := v.changes[app]; ok {
+		return true
+	}
+	return v.cache.Contains(app)
+}
@@ -0,0 +1,27 @@
+package writer_test
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/algorand/indexer/idb/postgres/internal/writer"
+)
+
+func TestDAOAppCacheApply(t *testing.T) {
+	cache := writer.MakeDAOAppCache([]basics.AppIndex{1, 2})
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Apply(writer.DAOAppChanges{2: false, 3: true})
+	assert.True(t, cache.Contains(1))
+	assert.False(t, cache.Contains(2))
+	assert.True(t, cache.Contains(3))
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDAOAppCacheNil(t *testing.T) {
+	var cache *writer.DAOAppCache
+	assert.False(t, cache.Contains(1))
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,241 @@
+package writer
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+	"github.com/algorand/indexer/util"
+)
+
+// Proposal types, the `type` key of proposal local state.
+const (
+	proposalTypeAlgoTransfer = 1
+	proposalTypeASATransfer  = 2
+	proposalTypeMessage      = 3
+)
+
+// argUint decodes a TEAL `btoi` argument, nil if it is missing or too long.
+func argUint(args [][]byte, i int) *uint64 {
+	if (i >= len(args)) || (len(args[i]) > 8) {
+		return nil
+	}
+	var buf [8]byte
+	copy(buf[8-len(args[i]):], args[i])
+	res := new(uint64)
+	*res = binary.BigEndian.Uint64(buf[:])
+	return res
+}
+
+// argString decodes a string argument as printable utf8, nil if it is missing.
+func argString(args [][]byte, i int) *string {
+	if i >= len(args) {
+		return nil
+	}
+	res := new(string)
+	*res = util.PrintableUTF8OrEmpty(string(args[i]))
+	return res
+}
+
+// argBytes returns a bytes argument, nil if it is missing.
+func argBytes(args [][]byte, i int) []byte {
+	if i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
+
+// argAddress decodes an address argument into its base32 form, nil if it is missing
+// or is not 32 bytes long.
+func argAddress(args [][]byte, i int) *string {
+	if (i >= len(args)) || (len(args[i]) != len(basics.Address{})) {
+		return nil
+	}
+	var address basics.Address
+	copy(address[:], args[i])
+	res := new(string)
+	*res = address.String()
+	return res
+}
+
+// decodeAddProposalArgs decodes the `add_proposal` arguments, which follow the
+// layout of the proposal local state. The trailing arguments depend on the type.
+func decodeAddProposalArgs(args [][]byte) idb.DAOEventArgs {
+	res := idb.DAOEventArgs{
+		Name:          argString(args, 1),
+		URL:           argString(args, 2),
+		URLHash:       argBytes(args, 3),
+		HashAlgo:      argString(args, 4),
+		VotingStart:   argUint(args, 5),
+		VotingEnd:     argUint(args, 6),
+		ExecuteBefore: argUint(args, 7),
+		Type:          argUint(args, 8),
+	}
+	if res.Type == nil {
+		return res
+	}
+
+	switch *res.Type {
+	case proposalTypeAlgoTransfer:
+		res.From = argAddress(args, 9)
+		res.Recipient = argAddress(args, 10)
+		res.Amount = argUint(args, 11)
+	case proposalTypeASATransfer:
+		res.From = argAddress(args, 9)
+		res.AsaID = argUint(args, 10)
+		res.Recipient = argAddress(args, 11)
+		res.Amount = argUint(args, 12)
+	case proposalTypeMessage:
+		res.Msg = argBytes(args, 9)
+	}
+	return res
+}
+
+// groupDepositAmount returns the amount of the asset transfer to the app account of
+// `appID` in `group`, nil if there is none. The deposit is not an argument of
+// `deposit_vote_token`, it is sent next to the call.
+func groupDepositAmount(group []transactions.SignedTxnInBlock, appID basics.AppIndex) *uint64 {
+	escrow := appID.Address()
+	for i := range group {
+		txn := &group[i].Txn
+		if (txn.Type == protocol.AssetTransferTx) && (txn.AssetReceiver == escrow) {
+			res := new(uint64)
+			*res = txn.AssetAmount
+			return res
+		}
+	}
+	return nil
+}
+
+// decodeDAOEvent decodes the SigmaDAO method call `txn`. It returns false if `txn`
+// is not a SigmaDAO method call. `group` is the transaction group of root
+// transactions, nil for inner transactions.
+func decodeDAOEvent(txn *transactions.Transaction, group []transactions.SignedTxnInBlock, daoApps daoAppView) (idb.DAOMethod, *basics.Address, idb.DAOEventArgs, bool) {
+	if (txn.Type != protocol.ApplicationCallTx) || (len(txn.ApplicationArgs) == 0) ||
+		!daoApps.contains(txn.ApplicationID) {
+		return "", nil, idb.DAOEventArgs{}, false
+	}
+	method := idb.DAOMethod(txn.ApplicationArgs[0])
+	if !idb.IsDAOMethodValid(method) {
+		return "", nil, idb.DAOEventArgs{}, false
+	}
+
+	args := txn.ApplicationArgs
+	var proposal *basics.Address
+	var res idb.DAOEventArgs
+	switch method {
+	case idb.DAOMethodAddProposal:
+		// The proposal account adds itself.
+		proposal = new(basics.Address)
+		*proposal = txn.Sender
+		res = decodeAddProposalArgs(args)
+	case idb.DAOMethodDepositVoteToken:
+		res.Amount = groupDepositAmount(group, txn.ApplicationID)
+	case idb.DAOMethodRegisterVote:
+		res.VoteOption = argString(args, 1)
+	case idb.DAOMethodWithdrawVoteDeposit:
+		res.Amount = argUint(args, 1)
+	}
+
+	switch method {
+	case idb.DAOMethodRegisterVote, idb.DAOMethodExecute, idb.DAOMethodClearVoteRecord,
+		idb.DAOMethodCloseProposal:
+		if len(txn.Accounts) > 0 {
+			proposal = new(basics.Address)
+			*proposal = txn.Accounts[0] // Txn.Accounts[1] in TEAL
+		}
+	}
+
+	return method, proposal, res, true
+}
+
+// queueDAOEvents queues the `dao_event` rows of `stxnad` and its inner transactions,
+// which start at offset `intra`, and appends the streamed ones to `events`. The
+// offset of the next transaction and the events are returned.
+func queueDAOEvents(round uint64, stxnad *transactions.SignedTxnWithAD, intra uint, txid string, group []transactions.SignedTxnInBlock, daoApps daoAppView, batch *pgx.Batch, events []idb.DAOEvent) (uint, []idb.DAOEvent) {
+	txn := &stxnad.Txn
+	if method, proposal, args, ok := decodeDAOEvent(txn, group, daoApps); ok {
+		var proposalBytes []byte
+		if proposal != nil {
+			proposalBytes = proposal[:]
+		}
+		batch.Queue(
+			insertDAOEventStmtName, round, intra, txn.ApplicationID, txid, txn.Sender[:],
+			proposalBytes, string(method), encoding.EncodeDAOEventArgs(&args))
+
+		if kind, ok := idb.DAOMethodEventKind(method); ok {
+			events = append(events, idb.DAOEvent{
+				Kind:     kind,
+				Round:    round,
+				AppID:    uint64(txn.ApplicationID),
+				Proposal: proposal,
+				Intra:    intra,
+				Txid:     txid,
+				Sender:   txn.Sender,
+				Method:   method,
+				Args:     args,
+			})
+		}
+	}
+
+	next := intra + 1
+	for i := range stxnad.EvalDelta.InnerTxns {
+		// Inner transactions are recorded with the id of their root transaction.
+		next, events = queueDAOEvents(
+			round, &stxnad.EvalDelta.InnerTxns[i], next, txid, nil, daoApps, batch, events)
+	}
+	return next, events
+}
+
+// writeDAOEvents queues a `dao_event` row for every SigmaDAO method call in `block`,
+// including the calls in inner transactions. Rows use the intra round offsets of the
+// `dao_txn` table. The streamed events are returned in transaction order.
+func writeDAOEvents(block *bookkeeping.Block, daoApps daoAppView, batch *pgx.Batch) ([]idb.DAOEvent, error) {
+	payset := block.Payset
+	round := uint64(block.Round())
+
+	var events []idb.DAOEvent
+	intra := uint(0)
+	for start := 0; start < len(payset); {
+		end := groupEnd(payset, start)
+		group := payset[start:end]
+
+		for i := range group {
+			var txid string
+			if hasDAOEvent(&group[i].SignedTxnWithAD, daoApps) {
+				stxn, _, err := block.BlockHeader.DecodeSignedTxn(group[i])
+				if err != nil {
+					return nil, fmt.Errorf("writeDAOEvents() decode signed txn err: %w", err)
+				}
+				txid = stxn.Txn.ID().String()
+			}
+			intra, events = queueDAOEvents(
+				round, &group[i].SignedTxnWithAD, intra, txid, group, daoApps, batch, events)
+		}
+
+		start = end
+	}
+
+	return events, nil
+}
+
+// hasDAOEvent returns true if `stxnad` or one of its inner transactions is a
+// SigmaDAO method call.
+func hasDAOEvent(stxnad *transactions.SignedTxnWithAD, daoApps daoAppView) bool {
+	if _, _, _, ok := decodeDAOEvent(&stxnad.Txn, nil, daoApps); ok {
+		return true
+	}
+	for i := range stxnad.EvalDelta.InnerTxns {
+		if hasDAOEvent(&stxnad.EvalDelta.InnerTxns[i], daoApps) {
+			return true
+		}
+	}
+	return false
+}
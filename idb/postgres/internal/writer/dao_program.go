@@ -0,0 +1,142 @@
+package writer
+
+import (
+	"encoding/binary"
+
+	"github.com/algorand/go-algorand/crypto"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// TEAL opcodes of the constant blocks that templates bake parameters into.
+const (
+	intcblockOpcode  = 0x20
+	bytecblockOpcode = 0x26
+)
+
+// DAOProgramRegistry identifies SigmaDAO applications by the SHA-512/256 hash of
+// their approval program. The zero value matches nothing.
+type DAOProgramRegistry struct {
+	// hash of the full approval program -> version label
+	exact map[crypto.Digest]string
+	// hash of the masked approval program -> version label
+	masked map[crypto.Digest]string
+}
+
+// MakeDAOProgramRegistry creates a registry from the list of known program versions.
+// If two versions share the same hash, the first one wins.
+func MakeDAOProgramRegistry(programs []idb.DAOProgram) DAOProgramRegistry {
+	r := DAOProgramRegistry{
+		exact:  make(map[crypto.Digest]string),
+		masked: make(map[crypto.Digest]string),
+	}
+
+	for _, program := range programs {
+		versions := r.exact
+		if program.MaskConstants {
+			versions = r.masked
+		}
+
+		hash := DAOProgramHash(program.ApprovalProgram, program.MaskConstants)
+		if _, ok := versions[hash]; !ok {
+			versions[hash] = program.Version
+		}
+	}
+
+	return r
+}
+
+// Match returns the version label of the SigmaDAO program that `approvalProgram`
+// belongs to. An exact match takes precedence over a match with masked constants.
+// The second return value is false if the program is unknown.
+func (r DAOProgramRegistry) Match(approvalProgram []byte) (string, bool) {
+	if len(r.exact) > 0 {
+		if version, ok := r.exact[DAOProgramHash(approvalProgram, false)]; ok {
+			return version, true
+		}
+	}
+	if len(r.masked) > 0 {
+		if version, ok := r.masked[DAOProgramHash(approvalProgram, true)]; ok {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// Len returns the number of known program versions.
+func (r DAOProgramRegistry) Len() int {
+	return len(r.exact) + len(r.masked)
+}
+
+// DAOProgramHash returns the SHA-512/256 hash of the canonical form of a TEAL program.
+// Without masking, the canonical form is the bytecode itself. With masking, the
+// version prefix is dropped and the values of the leading intcblock and bytecblock
+// instructions are replaced by their count, so that programs compiled from the same
+// template with different parameters or TEAL versions hash the same.
+func DAOProgramHash(program []byte, maskConstants bool) crypto.Digest {
+	if !maskConstants {
+		return crypto.Hash(program)
+	}
+	return crypto.Hash(maskProgramConstants(program))
+}
+
+// maskProgramConstants returns the canonical form of `program` with masked constants.
+// Malformed constant blocks are left in place, which makes the program unlikely to
+// match anything.
+func maskProgramConstants(program []byte) []byte {
+	_, n := binary.Uvarint(program)
+	if n <= 0 {
+		return program
+	}
+	rest := program[n:]
+
+	res := make([]byte, 0, len(rest))
+	var buf [binary.MaxVarintLen64]byte
+	for len(rest) > 0 {
+		opcode := rest[0]
+		if opcode != intcblockOpcode && opcode != bytecblockOpcode {
+			break
+		}
+
+		count, size := skipConstantBlock(rest)
+		if size == 0 {
+			break
+		}
+		res = append(res, opcode)
+		res = append(res, buf[:binary.PutUvarint(buf[:], count)]...)
+		rest = rest[size:]
+	}
+
+	return append(res, rest...)
+}
+
+// skipConstantBlock parses the intcblock or bytecblock instruction at the start of
+// `program`. It returns the number of constants and the instruction's size in bytes,
+// or a zero size if the instruction is malformed.
+func skipConstantBlock(program []byte) (uint64, int) {
+	pos := 1
+
+	count, n := binary.Uvarint(program[pos:])
+	if n <= 0 {
+		return 0, 0
+	}
+	pos += n
+
+	for i := uint64(0); i < count; i++ {
+		value, n := binary.Uvarint(program[pos:])
+		if n <= 0 {
+			return 0, 0
+		}
+		pos += n
+
+		if program[0] == bytecblockOpcode {
+			// `value` is the length of the byte constant.
+			if value > uint64(len(program)-pos) {
+				return 0, 0
+			}
+			pos += int(value)
+		}
+	}
+
+	return count, pos
+}
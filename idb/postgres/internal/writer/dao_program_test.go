@@ -0,0 +1,114 @@
+package writer_test
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/writer"
+)
+
+func TestDAOProgramRegistryMatch(t *testing.T) {
+	registry := writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+		{Version: "v1", ApprovalProgram: []byte{6, 1, 2}},
+		{Version: "v2", ApprovalProgram: []byte{6, 3, 4}},
+		{Version: "v2-duplicate", ApprovalProgram: []byte{6, 3, 4}},
+	})
+
+	version, ok := registry.Match([]byte{6, 1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, "v1", version)
+
+	version, ok = registry.Match([]byte{6, 3, 4})
+	assert.True(t, ok)
+	assert.Equal(t, "v2", version)
+
+	_, ok = registry.Match([]byte{6, 1})
+	assert.False(t, ok)
+
+	_, ok = registry.Match(nil)
+	assert.False(t, ok)
+}
+
+func TestDAOProgramRegistryZeroValue(t *testing.T) {
+	var registry writer.DAOProgramRegistry
+
+	_, ok := registry.Match([]byte{6, 1, 2})
+	assert.False(t, ok)
+	assert.Equal(t, 0, registry.Len())
+}
+
+// makeTemplateProgram returns a program with the given TEAL version, intcblock and
+// bytecblock values followed by a fixed body.
+func makeTemplateProgram(version byte, ints []byte, bytes []string) []byte {
+	program := []byte{version, 0x20, byte(len(ints))}
+	program = append(program, ints...)
+	program = append(program, 0x26, byte(len(bytes)))
+	for _, b := range bytes {
+		program = append(program, byte(len(b)))
+		program = append(program, b...)
+	}
+	// intc_0; intc_1; +; bytec_0; pop; return
+	return append(program, 0x22, 0x23, 0x08, 0x28, 0x48, 0x43)
+}
+
+func TestDAOProgramRegistryMaskConstants(t *testing.T) {
+	template := makeTemplateProgram(6, []byte{1, 2}, []string{"abc"})
+	registry := writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+		{Version: "v1", ApprovalProgram: template, MaskConstants: true},
+	})
+
+	testcases := []struct {
+		name    string
+		program []byte
+		match   bool
+	}{
+		{"same program", template, true},
+		{"different ints", makeTemplateProgram(6, []byte{5, 100}, []string{"abc"}), true},
+		{"different bytes", makeTemplateProgram(6, []byte{1, 2}, []string{"a long value"}), true},
+		{"different version", makeTemplateProgram(7, []byte{1, 2}, []string{"abc"}), true},
+		{"different int count", makeTemplateProgram(6, []byte{1, 2, 3}, []string{"abc"}), false},
+		{"different byte count", makeTemplateProgram(6, []byte{1, 2}, []string{"abc", "d"}), false},
+		{"different body", append(makeTemplateProgram(6, []byte{1, 2}, []string{"abc"}), 0x43), false},
+		{"malformed", []byte{6, 0x26, 5, 1}, false},
+		{"empty", nil, false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, ok := registry.Match(tc.program)
+			assert.Equal(t, tc.match, ok)
+			if tc.match {
+				assert.Equal(t, "v1", version)
+			}
+		})
+	}
+}
+
+func TestDAOProgramRegistryExactMatchPreferred(t *testing.T) {
+	template := makeTemplateProgram(6, []byte{1, 2}, []string{"abc"})
+	registry := writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+		{Version: "generic", ApprovalProgram: template, MaskConstants: true},
+		{Version: "exact", ApprovalProgram: template},
+	})
+
+	version, ok := registry.Match(template)
+	assert.True(t, ok)
+	assert.Equal(t, "exact", version)
+
+	version, ok = registry.Match(makeTemplateProgram(6, []byte{3, 4}, []string{"abc"}))
+	assert.True(t, ok)
+	assert.Equal(t, "generic", version)
+}
+
+func TestDAOProgramHash(t *testing.T) {
+	program := makeTemplateProgram(6, []byte{1, 2}, []string{"abc"})
+
+	assert.Equal(t, crypto.Hash(program), writer.DAOProgramHash(program, false))
+	assert.NotEqual(t, writer.DAOProgramHash(program, false), writer.DAOProgramHash(program, true))
+	assert.NotEqual(
+		t, writer.DAOProgramHash(program, false),
+		writer.DAOProgramHash(makeTemplateProgram(6, []byte{1, 3}, []string{"abc"}), false))
+}
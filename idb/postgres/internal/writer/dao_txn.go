@@ -0,0 +1,303 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+)
+
+// daoTxnFilter decides which transaction groups are written to the `dao_txn` table.
+type daoTxnFilter struct {
+	daoPrograms DAOProgramRegistry
+	daoApps     *DAOAppCache
+	// SigmaDAO apps created by the transactions seen so far.
+	created map[basics.AppIndex]struct{}
+}
+
+// callsDAOApp returns true if `stxnad` or one of its inner transactions calls or
+// creates a SigmaDAO app. `appID` is the id of the app `stxnad` calls, it is needed
+// for app creations that are missing it in their apply data.
+func (f *daoTxnFilter) callsDAOApp(stxnad *transactions.SignedTxnWithAD, appID uint64) bool {
+	res := false
+	txn := &stxnad.Txn
+	if txn.Type == protocol.ApplicationCallTx {
+		if txn.ApplicationID == 0 {
+			if _, ok := f.daoPrograms.Match(txn.ApprovalProgram); ok {
+				f.created[basics.AppIndex(appID)] = struct{}{}
+				res = true
+			}
+		} else if _, ok := f.created[txn.ApplicationID]; ok || f.daoApps.Contains(txn.ApplicationID) {
+			res = true
+		}
+	}
+
+	for i := range stxnad.EvalDelta.InnerTxns {
+		inner := &stxnad.EvalDelta.InnerTxns[i]
+		// Inner app creations always have the app id in their apply data.
+		if f.callsDAOApp(inner, uint64(inner.ApplyData.ApplicationID)) {
+			res = true
+		}
+	}
+
+	return res
+}
+
+// transactionAssetID returns the id of the asset or app referenced in `stxnad`, or 0
+// if it is not an asset or app transaction. `block` is nil for inner transactions.
+func transactionAssetID(stxnad *transactions.SignedTxnWithAD, intra uint, block *bookkeeping.Block) (uint64, error) {
+	assetid := uint64(0)
+
+	switch stxnad.Txn.Type {
+	case protocol.ApplicationCallTx:
+		assetid = uint64(stxnad.Txn.ApplicationID)
+		if assetid == 0 {
+			assetid = uint64(stxnad.ApplyData.ApplicationID)
+		}
+		if assetid == 0 {
+			if block == nil {
+				return 0, fmt.Errorf(
+					"transactionAssetID() missing ApplicationID for transaction %s",
+					stxnad.ID())
+			}
+			// Transactions before inner transactions existed do not have
+			// ApplyData.ApplicationID, so the txn counter gives the app id.
+			assetid = block.TxnCounter - uint64(len(block.Payset)) + uint64(intra) + 1
+		}
+	case protocol.AssetConfigTx:
+		assetid = uint64(stxnad.Txn.ConfigAsset)
+		if assetid == 0 {
+			assetid = uint64(stxnad.ApplyData.ConfigAsset)
+		}
+		if assetid == 0 {
+			if block == nil {
+				return 0, fmt.Errorf(
+					"transactionAssetID() missing ConfigAsset for transaction %s",
+					stxnad.ID())
+			}
+			assetid = block.TxnCounter - uint64(len(block.Payset)) + uint64(intra) + 1
+		}
+	case protocol.AssetTransferTx:
+		assetid = uint64(stxnad.Txn.XferAsset)
+	case protocol.AssetFreezeTx:
+		assetid = uint64(stxnad.Txn.FreezeAsset)
+	}
+
+	return assetid, nil
+}
+
+// getTransactionParticipants calls `add` for every address referenced in `stxnad`,
+// possibly with repetition. Inner transactions are not included.
+func getTransactionParticipants(stxnad *transactions.SignedTxnWithAD, add func(address basics.Address)) {
+	txn := &stxnad.Txn
+	add(txn.Sender)
+	add(txn.Receiver)
+	add(txn.CloseRemainderTo)
+	add(txn.AssetSender)
+	add(txn.AssetReceiver)
+	add(txn.AssetCloseTo)
+	add(txn.FreezeAccount)
+	for _, address := range txn.ApplicationCallTxnFields.Accounts {
+		add(address)
+	}
+}
+
+// daoTxnRows accumulates the `dao_txn` and `dao_txn_participation` rows of a block.
+type daoTxnRows struct {
+	round         uint64
+	roundTime     time.Time
+	txns          [][]interface{}
+	participation [][]interface{}
+}
+
+func (r *daoTxnRows) add(stxnad *transactions.SignedTxnWithAD, intra uint, assetid uint64, txid interface{}, extra *idb.TxnExtra) error {
+	typeenum, ok := idb.GetTypeEnum(stxnad.Txn.Type)
+	if !ok {
+		return fmt.Errorf("add() unknown transaction type %s", stxnad.Txn.Type)
+	}
+	r.txns = append(r.txns, []interface{}{
+		r.round, intra, int(typeenum), assetid, txid,
+		encoding.EncodeSignedTxnWithAD(*stxnad), encoding.EncodeTxnExtra(extra),
+		r.roundTime})
+
+	participants := make(map[basics.Address]struct{})
+	getTransactionParticipants(stxnad, func(address basics.Address) {
+		if !address.IsZero() {
+			participants[address] = struct{}{}
+		}
+	})
+	for address := range participants {
+		address := address
+		r.participation = append(r.participation, []interface{}{address[:], r.round, intra})
+	}
+
+	return nil
+}
+
+// addInner adds the inner transaction tree of `stxnad` in preorder, which is how
+// intra round offsets are assigned. The offset of the next transaction is returned.
+func (r *daoTxnRows) addInner(stxnad *transactions.SignedTxnWithAD, intra, rootIntra uint, rootTxid string) (uint, error) {
+	for i := range stxnad.EvalDelta.InnerTxns {
+		itxn := stxnad.EvalDelta.InnerTxns[i]
+		assetid, err := transactionAssetID(&itxn, 0, nil)
+		if err != nil {
+			return 0, fmt.Errorf("addInner() err: %w", err)
+		}
+		extra := idb.TxnExtra{
+			AssetCloseAmount: itxn.ApplyData.AssetClosingAmount,
+			RootIntra:        idb.OptionalUint{Present: true, Value: rootIntra},
+			RootTxid:         rootTxid,
+		}
+
+		// Nested inner transactions are written as separate rows, the full tree is
+		// in the root transaction.
+		itxnNoInner := itxn
+		itxnNoInner.EvalDelta.InnerTxns = nil
+		// Inner transactions do not have a txid.
+		err = r.add(&itxnNoInner, intra, assetid, nil, &extra)
+		if err != nil {
+			return 0, fmt.Errorf("addInner() err: %w", err)
+		}
+
+		intra, err = r.addInner(&itxn, intra+1, rootIntra, rootTxid)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return intra, nil
+}
+
+// countTxns returns the number of intra round offsets used by `stxnad`, i.e. one plus
+// the size of its inner transaction tree.
+func countTxns(stxnad *transactions.SignedTxnWithAD) uint {
+	res := uint(1)
+	for i := range stxnad.EvalDelta.InnerTxns {
+		res += countTxns(&stxnad.EvalDelta.InnerTxns[i])
+	}
+	return res
+}
+
+// groupEnd returns the end of the transaction group starting at `start` in `payset`.
+// Groups are contiguous in the payset, ungrouped transactions are their own group.
+func groupEnd(payset []transactions.SignedTxnInBlock, start int) int {
+	end := start + 1
+	if group := payset[start].Txn.Group; !group.IsZero() {
+		for (end < len(payset)) && (payset[end].Txn.Group == group) {
+			end++
+		}
+	}
+	return end
+}
+
+// getDAOTxnRows returns the rows of the transaction groups in `block` that call a
+// SigmaDAO app, including the payments and asset transfers grouped with the calls.
+func getDAOTxnRows(block *bookkeeping.Block, modifiedTxns []transactions.SignedTxnInBlock, filter *daoTxnFilter) (daoTxnRows, error) {
+	rows := daoTxnRows{
+		round:     uint64(block.Round()),
+		roundTime: time.Unix(block.TimeStamp, 0).UTC(),
+	}
+	payset := block.Payset
+
+	intra := uint(0)
+	for start := 0; start < len(payset); {
+		end := groupEnd(payset, start)
+
+		groupIntra := intra
+		isDAO := false
+		for i := start; i < end; i++ {
+			appID, err := transactionAssetID(&payset[i].SignedTxnWithAD, groupIntra, block)
+			if err != nil {
+				return daoTxnRows{}, fmt.Errorf("getDAOTxnRows() err: %w", err)
+			}
+			if filter.callsDAOApp(&payset[i].SignedTxnWithAD, appID) {
+				isDAO = true
+			}
+			groupIntra += countTxns(&payset[i].SignedTxnWithAD)
+		}
+
+		for i := start; i < end; i++ {
+			if !isDAO {
+				intra += countTxns(&payset[i].SignedTxnWithAD)
+				continue
+			}
+
+			var stxnad transactions.SignedTxnWithAD
+			var err error
+			// This sets the genesis information so that the txid is correct.
+			stxnad.SignedTxn, stxnad.ApplyData, err = block.BlockHeader.DecodeSignedTxn(payset[i])
+			if err != nil {
+				return daoTxnRows{}, fmt.Errorf("getDAOTxnRows() decode signed txn err: %w", err)
+			}
+			assetid, err := transactionAssetID(&stxnad, intra, block)
+			if err != nil {
+				return daoTxnRows{}, fmt.Errorf("getDAOTxnRows() err: %w", err)
+			}
+			txid := stxnad.Txn.ID().String()
+			extra := idb.TxnExtra{
+				AssetCloseAmount: modifiedTxns[i].ApplyData.AssetClosingAmount,
+			}
+			err = rows.add(&stxnad, intra, assetid, txid, &extra)
+			if err != nil {
+				return daoTxnRows{}, fmt.Errorf("getDAOTxnRows() err: %w", err)
+			}
+
+			intra, err = rows.addInner(&payset[i].SignedTxnWithAD, intra+1, intra, txid)
+			if err != nil {
+				return daoTxnRows{}, fmt.Errorf("getDAOTxnRows() err: %w", err)
+			}
+		}
+
+		start = end
+	}
+
+	return rows, nil
+}
+
+// AddDAOTransactions writes the transaction groups in `block` that call a SigmaDAO
+// app to the `dao_txn` and `dao_txn_participation` tables. `daoPrograms` and
+// `daoApps` are the same as in MakeWriter(); SigmaDAO apps created in `block` are
+// recognised from their creation transaction. `modifiedTxns` contains enhanced apply
+// data generated by the evaluator.
+func AddDAOTransactions(block *bookkeeping.Block, modifiedTxns []transactions.SignedTxnInBlock, daoPrograms DAOProgramRegistry, daoApps *DAOAppCache, tx pgx.Tx) error {
+	filter := daoTxnFilter{
+		daoPrograms: daoPrograms,
+		daoApps:     daoApps,
+		created:     make(map[basics.AppIndex]struct{}),
+	}
+	rows, err := getDAOTxnRows(block, modifiedTxns, &filter)
+	if err != nil {
+		return fmt.Errorf("AddDAOTransactions() err: %w", err)
+	}
+	if len(rows.txns) == 0 {
+		return nil
+	}
+
+	_, err = tx.CopyFrom(
+		context.Background(),
+		pgx.Identifier{"dao_txn"},
+		[]string{"round", "intra", "typeenum", "asset", "txid", "txn", "extra", "round_time"},
+		pgx.CopyFromRows(rows.txns))
+	if err != nil {
+		return fmt.Errorf("AddDAOTransactions() copy from txns err: %w", err)
+	}
+
+	_, err = tx.CopyFrom(
+		context.Background(),
+		pgx.Identifier{"dao_txn_participation"},
+		[]string{"addr", "round", "intra"},
+		pgx.CopyFromRows(rows.participation))
+	if err != nil {
+		return fmt.Errorf("AddDAOTransactions() copy from participation err: %w", err)
+	}
+
+	return nil
+}
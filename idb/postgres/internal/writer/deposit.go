@@ -0,0 +1,37 @@
+package writer
+
+import (
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/jackc/pgx/v4"
+)
+
+// writeDeposit updates the `dao_deposit` and `dao_deposit_history` tables from the
+// `deposit` and `deposit_lock` keys of a DAO member's local state. A history row is
+// only added when the deposit or its lock changes.
+func writeDeposit(round basics.Round, resource *ledgercore.AppResourceRecord, batch *pgx.Batch) {
+	if resource.State.Deleted {
+		batch.Queue(deleteDepositStmtName, resource.Aidx, resource.Addr[:], uint64(round))
+		return
+	}
+	if (resource.State.LocalState == nil) || isProposal(resource.State.LocalState) {
+		return
+	}
+
+	kv := resource.State.LocalState.KeyValue
+	deposit := tealUint(kv, Deposit)
+	depositLock := tealUint(kv, DepositLock)
+	if (deposit == nil) && (depositLock == nil) {
+		return
+	}
+
+	amount := "0"
+	if deposit != nil {
+		amount = strconv.FormatUint(*deposit, 10)
+	}
+	batch.Queue(
+		upsertDepositStmtName, resource.Aidx, resource.Addr[:], amount, depositLock,
+		uint64(round))
+}
@@ -0,0 +1,229 @@
+package writer
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/algorand/go-algorand/protocol"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// ExecutionAudit is the result of checking an `execute` call against the terms of
+// the executed proposal.
+type ExecutionAudit struct {
+	App      basics.AppIndex
+	Proposal basics.Address
+	Txid     string
+	// Mismatch describes how the payout differs from the proposal terms, empty if it
+	// matches.
+	Mismatch string
+}
+
+// transfer is an ALGO or asset transfer; asset 0 stands for ALGO.
+type transfer struct {
+	sender   basics.Address
+	receiver basics.Address
+	asset    basics.AssetIndex
+	amount   uint64
+}
+
+func (t transfer) String() string {
+	if t.asset == 0 {
+		return fmt.Sprintf("%d microAlgos from %s to %s", t.amount, t.sender, t.receiver)
+	}
+	return fmt.Sprintf(
+		"%d of asset %d from %s to %s", t.amount, t.asset, t.sender, t.receiver)
+}
+
+// addTransfers appends the transfers made by `stxnad` and its inner transactions.
+func addTransfers(stxnad *transactions.SignedTxnWithAD, transfers []transfer) []transfer {
+	txn := &stxnad.Txn
+	switch txn.Type {
+	case protocol.PaymentTx:
+		transfers = append(transfers, transfer{
+			sender:   txn.Sender,
+			receiver: txn.Receiver,
+			amount:   txn.Amount.Raw,
+		})
+	case protocol.AssetTransferTx:
+		sender := txn.Sender
+		if !txn.AssetSender.IsZero() {
+			// Clawback.
+			sender = txn.AssetSender
+		}
+		transfers = append(transfers, transfer{
+			sender:   sender,
+			receiver: txn.AssetReceiver,
+			asset:    txn.XferAsset,
+			amount:   txn.AssetAmount,
+		})
+	}
+
+	for i := range stxnad.EvalDelta.InnerTxns {
+		transfers = addTransfers(&stxnad.EvalDelta.InnerTxns[i], transfers)
+	}
+	return transfers
+}
+
+// expectedTransfer returns the payout required by the proposal with local state
+// `kv`. It returns false if the proposal does not pay out, and an error if its terms
+// are incomplete.
+func expectedTransfer(kv basics.TealKeyValue) (transfer, bool, error) {
+	proposalType := tealUint(kv, Type)
+	if (proposalType == nil) ||
+		((*proposalType != proposalTypeAlgoTransfer) && (*proposalType != proposalTypeASATransfer)) {
+		return transfer{}, false, nil
+	}
+
+	from := tealAddress(kv, From)
+	recipient := tealAddress(kv, Recipient)
+	amount := tealUint(kv, Amount)
+	if (from == nil) || (recipient == nil) || (amount == nil) {
+		return transfer{}, false, fmt.Errorf("proposal is missing from, recipient or amount")
+	}
+	res := transfer{
+		sender:   *from,
+		receiver: *recipient,
+		amount:   *amount,
+	}
+	if *proposalType == proposalTypeASATransfer {
+		asaID := tealUint(kv, AsaID)
+		if asaID == nil {
+			return transfer{}, false, fmt.Errorf("proposal is missing asa_id")
+		}
+		res.asset = basics.AssetIndex(*asaID)
+	}
+	return res, true, nil
+}
+
+// auditExecution checks that `transfers` contain exactly the payout required by the
+// proposal with local state `kv`. It returns a description of the mismatch, or an
+// empty string.
+func auditExecution(kv basics.TealKeyValue, transfers []transfer) string {
+	expected, ok, err := expectedTransfer(kv)
+	if err != nil {
+		return err.Error()
+	}
+	if !ok {
+		return ""
+	}
+
+	// Payouts are the transfers of the proposal asset out of `from`.
+	var payouts []transfer
+	for _, t := range transfers {
+		if (t.sender == expected.sender) && (t.asset == expected.asset) &&
+			(t.receiver != t.sender) {
+			payouts = append(payouts, t)
+		}
+	}
+
+	switch len(payouts) {
+	case 0:
+		return fmt.Sprintf("expected %s, found no transfer", expected)
+	case 1:
+		if payouts[0] != expected {
+			return fmt.Sprintf("expected %s, found %s", expected, payouts[0])
+		}
+		return ""
+	default:
+		return fmt.Sprintf("expected %s, found %d transfers", expected, len(payouts))
+	}
+}
+
+// getExecutionAudits audits the `execute` calls in `block`. Proposal terms are read
+// from the proposal local state in `accountDeltas`, which `execute` always updates.
+func getExecutionAudits(block *bookkeeping.Block, accountDeltas *ledgercore.AccountDeltas, daoApps daoAppView) ([]ExecutionAudit, error) {
+	type proposalKey struct {
+		app  basics.AppIndex
+		addr basics.Address
+	}
+	proposals := make(map[proposalKey]*basics.AppLocalState)
+	appResources := accountDeltas.GetAllAppResources()
+	for i := range appResources {
+		resource := &appResources[i]
+		if resource.State.LocalState != nil {
+			proposals[proposalKey{resource.Aidx, resource.Addr}] = resource.State.LocalState
+		}
+	}
+
+	var audits []ExecutionAudit
+	payset := block.Payset
+	for start := 0; start < len(payset); {
+		end := groupEnd(payset, start)
+		group := payset[start:end]
+
+		var transfers []transfer
+		for i := range group {
+			transfers = addTransfers(&group[i].SignedTxnWithAD, transfers)
+		}
+
+		for i := range group {
+			var calls []*transactions.SignedTxnWithAD
+			var addCalls func(stxnad *transactions.SignedTxnWithAD)
+			addCalls = func(stxnad *transactions.SignedTxnWithAD) {
+				method, _, _, ok := decodeDAOEvent(&stxnad.Txn, nil, daoApps)
+				if ok && (method == idb.DAOMethodExecute) {
+					calls = append(calls, stxnad)
+				}
+				for j := range stxnad.EvalDelta.InnerTxns {
+					addCalls(&stxnad.EvalDelta.InnerTxns[j])
+				}
+			}
+			addCalls(&group[i].SignedTxnWithAD)
+			if len(calls) == 0 {
+				continue
+			}
+
+			stxn, _, err := block.BlockHeader.DecodeSignedTxn(group[i])
+			if err != nil {
+				return nil, fmt.Errorf("getExecutionAudits() decode signed txn err: %w", err)
+			}
+			txid := stxn.Txn.ID().String()
+
+			for _, call := range calls {
+				txn := &call.Txn
+				if len(txn.Accounts) == 0 {
+					continue
+				}
+				audit := ExecutionAudit{
+					App:      txn.ApplicationID,
+					Proposal: txn.Accounts[0], // Txn.Accounts[1] in TEAL
+					Txid:     txid,
+				}
+				localState := proposals[proposalKey{audit.App, audit.Proposal}]
+				if localState == nil {
+					audit.Mismatch = "proposal state was not updated"
+				} else {
+					audit.Mismatch = auditExecution(localState.KeyValue, transfers)
+				}
+				audits = append(audits, audit)
+			}
+		}
+
+		start = end
+	}
+
+	return audits, nil
+}
+
+// writeExecutionAudits records `audits` on the proposal rows. It must come after the
+// proposal updates of the block.
+func writeExecutionAudits(round basics.Round, audits []ExecutionAudit, batch *pgx.Batch) {
+	for _, audit := range audits {
+		result := idb.ExecutionMatch
+		var details *string
+		if audit.Mismatch != "" {
+			result = idb.ExecutionMismatch
+			details = new(string)
+			*details = audit.Mismatch
+		}
+		batch.Queue(
+			updateExecutionAuditStmtName, audit.App, audit.Proposal[:], uint64(round),
+			string(result), details)
+	}
+}
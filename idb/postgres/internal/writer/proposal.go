@@ -0,0 +1,109 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// isProposal returns true if `localState` is the local state of a SigmaDAO proposal
+// account. `add_proposal` always sets the proposal type, voters never have it.
+func isProposal(localState *basics.AppLocalState) bool {
+	_, ok := localState.KeyValue[Type]
+	return ok
+}
+
+// writeProposal updates the `proposal` table from the local state of a proposal
+// account. A proposal is deleted when its account opts out or when the proposal
+// keys are cleared by `close_proposal`. A `proposal_tally_history` row is added when
+// the yes, no or abstain counts differ from the ones in the `proposal` table.
+func writeProposal(round basics.Round, resource *ledgercore.AppResourceRecord, batch *pgx.Batch) {
+	if resource.State.Deleted {
+		batch.Queue(deleteProposalStmtName, resource.Aidx, resource.Addr[:], uint64(round))
+		return
+	}
+	if resource.State.LocalState == nil {
+		return
+	}
+	if !isProposal(resource.State.LocalState) {
+		batch.Queue(deleteProposalStmtName, resource.Aidx, resource.Addr[:], uint64(round))
+		return
+	}
+
+	kv := resource.State.LocalState.KeyValue
+	executed := false
+	if value := tealUint(kv, Executed); value != nil {
+		executed = *value != 0
+	}
+	tally := func(key string) string {
+		if value := tealNumeric(kv, key); value != nil {
+			return *value
+		}
+		return "0"
+	}
+	yes := tally(Yes)
+	no := tally(No)
+	abstain := tally(Abstain)
+
+	// Must come before the proposal upsert, which overwrites the previous counts.
+	batch.Queue(
+		insertProposalTallyStmtName, resource.Aidx, resource.Addr[:], uint64(round), yes, no,
+		abstain)
+	batch.Queue(
+		upsertProposalStmtName, resource.Aidx, resource.Addr[:],
+		tealUint(kv, ID), tealString(kv, Name), tealString(kv, URL), tealBytes(kv, URLHash),
+		tealString(kv, HashAlgo), tealUint(kv, VotingStart), tealUint(kv, VotingEnd),
+		tealUint(kv, ExecuteBefore), tealUint(kv, Type), tealBytes(kv, From),
+		tealBytes(kv, Recipient), tealUint(kv, AsaID), tealNumeric(kv, Amount),
+		tealBytes(kv, Msg), executed, yes, no, abstain)
+}
+
+// updateProposalStatus recomputes the status of the proposals as of the block time
+// `timestamp` and returns the status changes, ordered by app and proposal address.
+func updateProposalStatus(tx pgx.Tx, round basics.Round, timestamp int64) ([]idb.DAOEvent, error) {
+	rows, err := tx.Query(
+		context.Background(), updateProposalStatusStmtName, timestamp, uint64(round))
+	if err != nil {
+		return nil, fmt.Errorf("updateProposalStatus() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var res []idb.DAOEvent
+	for rows.Next() {
+		var app uint64
+		var addr []byte
+		var status string
+		err = rows.Scan(&app, &addr, &status)
+		if err != nil {
+			return nil, fmt.Errorf("updateProposalStatus() scan err: %w", err)
+		}
+
+		proposal := new(basics.Address)
+		copy(proposal[:], addr)
+		res = append(res, idb.DAOEvent{
+			Kind:     idb.DAOEventStatusChanged,
+			Round:    uint64(round),
+			AppID:    app,
+			Proposal: proposal,
+			Status:   idb.ProposalStatus(status),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("updateProposalStatus() rows err: %w", err)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].AppID != res[j].AppID {
+			return res[i].AppID < res[j].AppID
+		}
+		return bytes.Compare(res[i].Proposal[:], res[j].Proposal[:]) < 0
+	})
+	return res, nil
+}
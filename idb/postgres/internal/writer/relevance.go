@@ -0,0 +1,263 @@
+package writer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
+)
+
+// RelevanceChanges are the accounts and assets that become relevant in a block.
+type RelevanceChanges struct {
+	Accounts map[basics.Address]struct{}
+	Assets   map[basics.AssetIndex]struct{}
+}
+
+// MakeRelevanceChanges creates an empty RelevanceChanges object.
+func MakeRelevanceChanges() RelevanceChanges {
+	return RelevanceChanges{
+		Accounts: make(map[basics.Address]struct{}),
+		Assets:   make(map[basics.AssetIndex]struct{}),
+	}
+}
+
+// RelevancePolicy decides which accounts, assets and asset holdings are written to
+// the `account`, `asset` and `account_asset` tables. Implementations are not required
+// to be thread-safe.
+type RelevancePolicy interface {
+	// AccountRelevant returns true if the account `addr` is written. `changes` are the
+	// accounts and assets found relevant so far in the current block.
+	AccountRelevant(addr basics.Address, changes RelevanceChanges) bool
+	// AssetRelevant returns true if the asset `assetID` and its holdings are written.
+	AssetRelevant(assetID basics.AssetIndex, changes RelevanceChanges) bool
+	// Apply records `changes` once the block that made them is committed.
+	Apply(changes RelevanceChanges)
+}
+
+// FullIndexing is the relevance policy that writes every account and asset.
+type FullIndexing struct{}
+
+// AccountRelevant is part of RelevancePolicy.
+func (FullIndexing) AccountRelevant(addr basics.Address, changes RelevanceChanges) bool {
+	return true
+}
+
+// AssetRelevant is part of RelevancePolicy.
+func (FullIndexing) AssetRelevant(assetID basics.AssetIndex, changes RelevanceChanges) bool {
+	return true
+}
+
+// Apply is part of RelevancePolicy.
+func (FullIndexing) Apply(changes RelevanceChanges) {
+}
+
+// DAORelevance is the relevance policy that only writes SigmaDAO related records:
+// DAO app creators and escrows, proposal accounts and their funding accounts, voters,
+// holders of DAO governance tokens and the governance tokens themselves.
+//
+// When an account or asset becomes relevant, its full state is read from the ledger
+// and written along with the changes of the block, see writeRelevanceChanges(). The
+// ledger has no index of the holders of an asset, so the holders of a governance
+// token found when it becomes relevant are the relevant accounts the block changes.
+// The other holders are written once their holding changes; proposal holder
+// snapshots are only complete for them with full indexing.
+type DAORelevance struct {
+	accounts map[basics.Address]struct{}
+	assets   map[basics.AssetIndex]struct{}
+}
+
+// MakeDAORelevance creates a DAORelevance policy that already considers `accounts`
+// and `assets` relevant.
+func MakeDAORelevance(accounts []basics.Address, assets []basics.AssetIndex) *DAORelevance {
+	r := &DAORelevance{
+		accounts: make(map[basics.Address]struct{}, len(accounts)),
+		assets:   make(map[basics.AssetIndex]struct{}, len(assets)),
+	}
+	for _, addr := range accounts {
+		r.accounts[addr] = struct{}{}
+	}
+	for _, assetID := range assets {
+		r.assets[assetID] = struct{}{}
+	}
+	return r
+}
+
+// AccountRelevant is part of RelevancePolicy.
+func (r *DAORelevance) AccountRelevant(addr basics.Address, changes RelevanceChanges) bool {
+	if _, ok := r.accounts[addr]; ok {
+		return true
+	}
+	_, ok := changes.Accounts[addr]
+	return ok
+}
+
+// AssetRelevant is part of RelevancePolicy.
+func (r *DAORelevance) AssetRelevant(assetID basics.AssetIndex, changes RelevanceChanges) bool {
+	if _, ok := r.assets[assetID]; ok {
+		return true
+	}
+	_, ok := changes.Assets[assetID]
+	return ok
+}
+
+// Apply is part of RelevancePolicy.
+func (r *DAORelevance) Apply(changes RelevanceChanges) {
+	for addr := range changes.Accounts {
+		r.accounts[addr] = struct{}{}
+	}
+	for assetID := range changes.Assets {
+		r.assets[assetID] = struct{}{}
+	}
+}
+
+// getRelevanceChanges returns the accounts and assets that become relevant in a block
+// according to `policy`. It must be called after the SigmaDAO apps of the block are
+// known.
+func getRelevanceChanges(accountDeltas *ledgercore.AccountDeltas, policy RelevancePolicy, daoApps daoAppView) RelevanceChanges {
+	changes := MakeRelevanceChanges()
+	addAccount := func(addr basics.Address) {
+		if !policy.AccountRelevant(addr, changes) {
+			changes.Accounts[addr] = struct{}{}
+		}
+	}
+
+	appResources := accountDeltas.GetAllAppResources()
+	for i := range appResources {
+		resource := &appResources[i]
+		if !daoApps.contains(resource.Aidx) {
+			continue
+		}
+
+		if resource.Params.Params != nil {
+			addAccount(resource.Addr)
+			addAccount(resource.Aidx.Address())
+			if govTokenID := tealUint(resource.Params.Params.GlobalState, GovTokenId); govTokenID != nil {
+				assetID := basics.AssetIndex(*govTokenID)
+				if !policy.AssetRelevant(assetID, changes) {
+					changes.Assets[assetID] = struct{}{}
+				}
+			}
+		}
+
+		if localState := resource.State.LocalState; localState != nil {
+			addAccount(resource.Addr)
+			if isProposal(localState) {
+				if from := tealAddress(localState.KeyValue, From); from != nil {
+					addAccount(*from)
+				}
+			}
+		}
+	}
+
+	// Governance tokens are known at this point, add their holders.
+	assetResources := accountDeltas.GetAllAssetResources()
+	for i := range assetResources {
+		resource := &assetResources[i]
+		if (resource.Holding.Holding != nil) && policy.AssetRelevant(resource.Aidx, changes) {
+			addAccount(resource.Addr)
+		}
+	}
+
+	return changes
+}
+
+// writeRelevanceChanges writes the state of the accounts and assets in `changes` that
+// the block does not change, read from `ledger`: the rows of the accounts and assets,
+// the holdings of relevant assets by the new accounts and the holdings of the new
+// assets by the relevant accounts in the block. The ledger reads are bounded by the
+// size of the block. Without a ledger, only the changes of the block are written.
+func writeRelevanceChanges(round basics.Round, accountDeltas *ledgercore.AccountDeltas, policy RelevancePolicy, changes RelevanceChanges, ledger idb.LedgerState, batch *pgx.Batch) error {
+	if ledger == nil || (len(changes.Accounts) == 0 && len(changes.Assets) == 0) {
+		return nil
+	}
+
+	type holdingKey struct {
+		addr    basics.Address
+		assetID basics.AssetIndex
+	}
+	changedAccounts := make(map[basics.Address]struct{}, accountDeltas.Len())
+	for i := 0; i < accountDeltas.Len(); i++ {
+		address, _ := accountDeltas.GetByIdx(i)
+		changedAccounts[address] = struct{}{}
+	}
+	changedAssets := make(map[basics.AssetIndex]struct{})
+	changedHoldings := make(map[holdingKey]struct{})
+	assetResources := accountDeltas.GetAllAssetResources()
+	for i := range assetResources {
+		resource := &assetResources[i]
+		if resource.Params.Deleted || (resource.Params.Params != nil) {
+			changedAssets[resource.Aidx] = struct{}{}
+		}
+		if resource.Holding.Deleted || (resource.Holding.Holding != nil) {
+			changedHoldings[holdingKey{addr: resource.Addr, assetID: resource.Aidx}] = struct{}{}
+		}
+	}
+
+	writeHolding := func(addr basics.Address, assetID basics.AssetIndex, holding basics.AssetHolding) {
+		if _, ok := changedHoldings[holdingKey{addr: addr, assetID: assetID}]; !ok {
+			batch.Queue(
+				upsertAccountAssetStmtName, addr[:], assetID,
+				strconv.FormatUint(holding.Amount, 10), holding.Frozen)
+		}
+	}
+
+	// The holdings of a new account are read at once.
+	for addr := range changes.Accounts {
+		if _, ok := changedAccounts[addr]; !ok {
+			accountData, err := ledger.Account(addr)
+			if err != nil {
+				return fmt.Errorf("writeRelevanceChanges() err: %w", err)
+			}
+			if !accountData.IsZero() {
+				writeAccount(round, addr, accountData, optionalSigTypeDelta{}, batch)
+			}
+		}
+		holdings, err := ledger.AssetHoldings(addr)
+		if err != nil {
+			return fmt.Errorf("writeRelevanceChanges() err: %w", err)
+		}
+		for assetID, holding := range holdings {
+			if policy.AssetRelevant(assetID, changes) {
+				writeHolding(addr, assetID, holding)
+			}
+		}
+	}
+
+	// The holders of a new asset are only looked up among the relevant accounts the
+	// block changes, the others are written once their holding changes.
+	var holders []basics.Address
+	for addr := range changedAccounts {
+		if _, ok := changes.Accounts[addr]; !ok && policy.AccountRelevant(addr, changes) {
+			holders = append(holders, addr)
+		}
+	}
+	for assetID := range changes.Assets {
+		if _, ok := changedAssets[assetID]; !ok {
+			creator, params, err := ledger.Asset(assetID)
+			if err != nil {
+				return fmt.Errorf("writeRelevanceChanges() err: %w", err)
+			}
+			if params != nil {
+				batch.Queue(
+					upsertAssetStmtName, assetID, creator[:],
+					encoding.EncodeAssetParams(*params))
+			}
+		}
+		for _, addr := range holders {
+			holding, err := ledger.AssetHolding(addr, assetID)
+			if err != nil {
+				return fmt.Errorf("writeRelevanceChanges() err: %w", err)
+			}
+			if holding != nil {
+				writeHolding(addr, assetID, *holding)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package writer
+
+import (
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+
+	"github.com/algorand/indexer/util"
+)
+
+// tealUint returns the uint value of `key` or nil if the key is missing.
+func tealUint(kv basics.TealKeyValue, key string) *uint64 {
+	tv, ok := kv[key]
+	if !ok || tv.Type != basics.TealUintType {
+		return nil
+	}
+	res := new(uint64)
+	*res = tv.Uint
+	return res
+}
+
+// tealNumeric returns the uint value of `key` formatted for a numeric(20) column or
+// nil if the key is missing.
+func tealNumeric(kv basics.TealKeyValue, key string) *string {
+	value := tealUint(kv, key)
+	if value == nil {
+		return nil
+	}
+	res := new(string)
+	*res = strconv.FormatUint(*value, 10)
+	return res
+}
+
+// tealBytes returns the bytes value of `key` or nil if the key is missing.
+func tealBytes(kv basics.TealKeyValue, key string) []byte {
+	tv, ok := kv[key]
+	if !ok || tv.Type != basics.TealBytesType {
+		return nil
+	}
+	return []byte(tv.Bytes)
+}
+
+// tealString returns the bytes value of `key` as printable utf8 or nil if the key
+// is missing.
+func tealString(kv basics.TealKeyValue, key string) *string {
+	tv, ok := kv[key]
+	if !ok || tv.Type != basics.TealBytesType {
+		return nil
+	}
+	res := new(string)
+	*res = util.PrintableUTF8OrEmpty(tv.Bytes)
+	return res
+}
+
+// tealAddress returns the bytes value of `key` as an address or nil if the key is
+// missing or is not 32 bytes long.
+func tealAddress(kv basics.TealKeyValue, key string) *basics.Address {
+	value := tealBytes(kv, key)
+	if len(value) != len(basics.Address{}) {
+		return nil
+	}
+	res := new(basics.Address)
+	copy(res[:], value)
+	return res
+}
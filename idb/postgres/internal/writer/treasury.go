@@ -0,0 +1,187 @@
+package writer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// Treasury is the escrow account `Address` paying out the transfer proposals of the
+// SigmaDAO app `App`, i.e. the `from` key of its proposals.
+type Treasury struct {
+	App     basics.AppIndex
+	Address basics.Address
+}
+
+// TreasuryCache is the set of treasuries in the `dao_treasury` table. It is not
+// thread-safe, a nil cache is empty.
+type TreasuryCache struct {
+	accounts map[basics.Address]map[basics.AppIndex]struct{}
+}
+
+// MakeTreasuryCache creates a cache containing `treasuries`.
+func MakeTreasuryCache(treasuries []Treasury) *TreasuryCache {
+	c := &TreasuryCache{
+		accounts: make(map[basics.Address]map[basics.AppIndex]struct{}),
+	}
+	for _, treasury := range treasuries {
+		c.add(treasury)
+	}
+	return c
+}
+
+func (c *TreasuryCache) add(treasury Treasury) {
+	apps, ok := c.accounts[treasury.Address]
+	if !ok {
+		apps = make(map[basics.AppIndex]struct{})
+		c.accounts[treasury.Address] = apps
+	}
+	apps[treasury.App] = struct{}{}
+}
+
+// Apps returns the SigmaDAO apps that `addr` is the treasury of.
+func (c *TreasuryCache) Apps(addr basics.Address) map[basics.AppIndex]struct{} {
+	if c == nil {
+		return nil
+	}
+	return c.accounts[addr]
+}
+
+// Contains returns true if `treasury` is in the cache.
+func (c *TreasuryCache) Contains(treasury Treasury) bool {
+	_, ok := c.Apps(treasury.Address)[treasury.App]
+	return ok
+}
+
+// Apply adds the treasuries in `changes`. It must only be called once the database
+// transaction that made the changes is committed.
+func (c *TreasuryCache) Apply(changes TreasuryChanges) {
+	for treasury := range changes {
+		c.add(treasury)
+	}
+}
+
+// TreasuryChanges are the treasuries that start being tracked in a block.
+type TreasuryChanges map[Treasury]struct{}
+
+// getTreasuryChanges adds to `changes` the `from` accounts of the proposals in
+// `appResources` that are not tracked yet.
+func getTreasuryChanges(appResources []ledgercore.AppResourceRecord, daoApps daoAppView, treasuries *TreasuryCache, changes TreasuryChanges) {
+	for i := range appResources {
+		resource := &appResources[i]
+		localState := resource.State.LocalState
+		if !daoApps.contains(resource.Aidx) || (localState == nil) || !isProposal(localState) {
+			continue
+		}
+
+		if from := tealAddress(localState.KeyValue, From); from != nil {
+			treasury := Treasury{App: resource.Aidx, Address: *from}
+			if !treasuries.Contains(treasury) {
+				changes[treasury] = struct{}{}
+			}
+		}
+	}
+}
+
+// writeTreasuryAccount updates the ALGO balance of the treasuries of `address`. Asset
+// 0 stands for ALGO in the `dao_treasury` table.
+func writeTreasuryAccount(round basics.Round, address basics.Address, accountData *ledgercore.AccountData, treasuries *TreasuryCache, batch *pgx.Batch) {
+	for app := range treasuries.Apps(address) {
+		batch.Queue(
+			upsertTreasuryStmtName, app, address[:], uint64(0),
+			strconv.FormatUint(accountData.MicroAlgos.Raw, 10), uint64(round))
+	}
+}
+
+// writeTreasuryAsset updates the asset balance of the treasuries of `resource.Addr`.
+func writeTreasuryAsset(round basics.Round, resource *ledgercore.AssetResourceRecord, treasuries *TreasuryCache, batch *pgx.Batch) {
+	var amount uint64
+	if resource.Holding.Holding != nil {
+		amount = resource.Holding.Holding.Amount
+	} else if !resource.Holding.Deleted {
+		return
+	}
+
+	for app := range treasuries.Apps(resource.Addr) {
+		batch.Queue(
+			upsertTreasuryStmtName, app, resource.Addr[:], uint64(resource.Aidx),
+			strconv.FormatUint(amount, 10), uint64(round))
+	}
+}
+
+// getAccountBalances returns the ALGO and asset balances of `address` after the
+// block of `accountDeltas`, asset 0 stands for ALGO. Balances the block does not
+// change are read from `ledger`.
+func getAccountBalances(address basics.Address, accountDeltas *ledgercore.AccountDeltas, ledger idb.LedgerState) (map[basics.AssetIndex]uint64, error) {
+	holdings, err := ledger.AssetHoldings(address)
+	if err != nil {
+		return nil, fmt.Errorf("getAccountBalances() err: %w", err)
+	}
+	balances := make(map[basics.AssetIndex]uint64, len(holdings)+1)
+	for assetID, holding := range holdings {
+		balances[assetID] = holding.Amount
+	}
+
+	found := false
+	for i := 0; i < accountDeltas.Len(); i++ {
+		if addr, accountData := accountDeltas.GetByIdx(i); addr == address {
+			balances[0] = accountData.MicroAlgos.Raw
+			found = true
+			break
+		}
+	}
+	if !found {
+		accountData, err := ledger.Account(address)
+		if err != nil {
+			return nil, fmt.Errorf("getAccountBalances() err: %w", err)
+		}
+		balances[0] = accountData.MicroAlgos.Raw
+	}
+
+	assetResources := accountDeltas.GetAllAssetResources()
+	for i := range assetResources {
+		resource := &assetResources[i]
+		if resource.Addr != address {
+			continue
+		}
+		if resource.Holding.Holding != nil {
+			balances[resource.Aidx] = resource.Holding.Holding.Amount
+		} else if resource.Holding.Deleted {
+			delete(balances, resource.Aidx)
+		}
+	}
+
+	return balances, nil
+}
+
+// writeTreasuryChanges records the opening balances of the treasuries in `changes`.
+// They are read from `ledger` and `accountDeltas`, the treasury account usually
+// becomes relevant in the same block, so the `account` and `account_asset` tables do
+// not have its balances yet. Without a ledger, the balances are read from those
+// tables, which are only complete with full indexing, and it must come after their
+// updates of the block.
+func writeTreasuryChanges(round basics.Round, changes TreasuryChanges, accountDeltas *ledgercore.AccountDeltas, ledger idb.LedgerState, batch *pgx.Batch) error {
+	for treasury := range changes {
+		if ledger == nil {
+			batch.Queue(
+				seedTreasuryStmtName, treasury.App, treasury.Address[:], uint64(round))
+			continue
+		}
+
+		balances, err := getAccountBalances(treasury.Address, accountDeltas, ledger)
+		if err != nil {
+			return fmt.Errorf("writeTreasuryChanges() err: %w", err)
+		}
+		for assetID, amount := range balances {
+			batch.Queue(
+				seedTreasuryBalanceStmtName, treasury.App, treasury.Address[:],
+				uint64(assetID), strconv.FormatUint(amount, 10), uint64(round))
+		}
+	}
+	return nil
+}
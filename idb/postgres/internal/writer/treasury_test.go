@@ -0,0 +1,34 @@
+package writer_test
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/algorand/indexer/idb/postgres/internal/writer"
+	"github.com/algorand/indexer/util/test"
+)
+
+func TestTreasuryCacheApply(t *testing.T) {
+	cache := writer.MakeTreasuryCache([]writer.Treasury{{App: 1, Address: test.AccountA}})
+
+	cache.Apply(writer.TreasuryChanges{
+		{App: 2, Address: test.AccountA}: {},
+		{App: 2, Address: test.AccountB}: {},
+	})
+	assert.True(t, cache.Contains(writer.Treasury{App: 1, Address: test.AccountA}))
+	assert.True(t, cache.Contains(writer.Treasury{App: 2, Address: test.AccountA}))
+	assert.True(t, cache.Contains(writer.Treasury{App: 2, Address: test.AccountB}))
+	assert.False(t, cache.Contains(writer.Treasury{App: 1, Address: test.AccountB}))
+
+	expected := map[basics.AppIndex]struct{}{1: {}, 2: {}}
+	assert.Equal(t, expected, cache.Apps(test.AccountA))
+	assert.Empty(t, cache.Apps(test.AccountC))
+}
+
+func TestTreasuryCacheNil(t *testing.T) {
+	var cache *writer.TreasuryCache
+	assert.False(t, cache.Contains(writer.Treasury{App: 1, Address: test.AccountA}))
+	assert.Empty(t, cache.Apps(test.AccountA))
+}
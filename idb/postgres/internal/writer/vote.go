@@ -0,0 +1,102 @@
+package writer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/transactions"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/util"
+)
+
+// voteKey identifies the vote of `voter` on `proposal` in the DAO app `app`.
+type voteKey struct {
+	app      basics.AppIndex
+	proposal basics.Address
+	voter    basics.Address
+}
+
+// getRegisterVotes returns the vote option of every `register_vote` call in `payset`,
+// including inner transactions. The contract only stores the proposal id under the
+// voter's `p_` key, so the option has to be taken from the call arguments.
+func getRegisterVotes(payset []transactions.SignedTxnInBlock) map[voteKey]string {
+	res := make(map[voteKey]string)
+	for i := range payset {
+		addRegisterVotes(&payset[i].SignedTxnWithAD, res)
+	}
+	return res
+}
+
+func addRegisterVotes(stxnad *transactions.SignedTxnWithAD, res map[voteKey]string) {
+	txn := &stxnad.Txn
+	args := txn.ApplicationArgs
+	if (txn.ApplicationID != 0) && (len(args) >= 2) && (string(args[0]) == RegisterVote) &&
+		(len(txn.Accounts) >= 1) {
+		key := voteKey{
+			app:      txn.ApplicationID,
+			proposal: txn.Accounts[0], // Txn.Accounts[1] in TEAL
+			voter:    txn.Sender,
+		}
+		res[key] = util.PrintableUTF8OrEmpty(string(args[1]))
+	}
+
+	for i := range stxnad.EvalDelta.InnerTxns {
+		addRegisterVotes(&stxnad.EvalDelta.InnerTxns[i], res)
+	}
+}
+
+// getVoteProposals returns the proposals the local state `kv` has vote records for.
+func getVoteProposals(kv basics.TealKeyValue) [][]byte {
+	var res [][]byte
+	for key := range kv {
+		if strings.HasPrefix(key, VoteKeyPrefix) &&
+			(len(key) == len(VoteKeyPrefix)+len(basics.Address{})) {
+			res = append(res, []byte(key[len(VoteKeyPrefix):]))
+		}
+	}
+	return res
+}
+
+// writeVotes updates the `vote` table from the local state of a voter. Votes are
+// deleted when their `p_` key disappears, i.e. on `clear_vote_record`, or when the
+// voter clears out of the app.
+func writeVotes(round basics.Round, resource *ledgercore.AppResourceRecord, registerVotes map[voteKey]string, batch *pgx.Batch) {
+	if resource.State.Deleted {
+		batch.Queue(
+			deleteVotesStmtName, resource.Aidx, resource.Addr[:], [][]byte{}, uint64(round))
+		return
+	}
+	if (resource.State.LocalState == nil) || isProposal(resource.State.LocalState) {
+		return
+	}
+
+	kv := resource.State.LocalState.KeyValue
+	proposals := getVoteProposals(kv)
+	for _, proposal := range proposals {
+		key := voteKey{app: resource.Aidx, voter: resource.Addr}
+		copy(key.proposal[:], proposal)
+
+		if option, ok := registerVotes[key]; ok {
+			var weight *string
+			if value := tealUint(kv, Deposit); value != nil {
+				weight = new(string)
+				*weight = strconv.FormatUint(*value, 10)
+			}
+			batch.Queue(
+				upsertVoteStmtName, resource.Aidx, proposal, resource.Addr[:], option,
+				weight, uint64(round))
+		} else {
+			// The vote was cast before this block, keep what we know about it.
+			batch.Queue(
+				insertVoteStmtName, resource.Aidx, proposal, resource.Addr[:], uint64(round))
+		}
+	}
+
+	if proposals == nil {
+		proposals = [][]byte{}
+	}
+	batch.Queue(deleteVotesStmtName, resource.Aidx, resource.Addr[:], proposals, uint64(round))
+}
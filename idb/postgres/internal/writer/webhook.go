@@ -0,0 +1,42 @@
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/algorand/indexer/idb"
+)
+
+// addWebhookDeliveries writes the deliveries of `events` to the matching webhooks
+// into the outbox. It must be called in the transaction of the block, so that a
+// committed block always has its deliveries.
+func addWebhookDeliveries(tx pgx.Tx, round basics.Round, events []idb.DAOEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	apps := make([]uint64, len(events))
+	kinds := make([]string, len(events))
+	payloads := make([]string, len(events))
+	for i := range events {
+		payload, err := json.Marshal(events[i].Payload())
+		if err != nil {
+			return fmt.Errorf("addWebhookDeliveries() err: %w", err)
+		}
+		apps[i] = events[i].AppID
+		kinds[i] = string(events[i].Kind)
+		payloads[i] = string(payload)
+	}
+
+	_, err := tx.Exec(
+		context.Background(), insertWebhookDeliveriesStmtName, uint64(round), apps, kinds,
+		payloads)
+	if err != nil {
+		return fmt.Errorf("addWebhookDeliveries() exec err: %w", err)
+	}
+	return nil
+}
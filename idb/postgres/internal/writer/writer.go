@@ -2,10 +2,7 @@ package writer
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
-	"log"
-	"os"
 	"strconv"
 
 	"github.com/algorand/go-algorand/data/basics"
@@ -34,6 +31,28 @@ const (
 	deleteAppStmtName                  = "delete_app"
 	deleteAccountAppStmtName           = "delete_account_app"
 	updateAccountTotalsStmtName        = "update_account_totals"
+	upsertProposalStmtName             = "upsert_proposal"
+	deleteProposalStmtName             = "delete_proposal"
+	insertProposalTallyStmtName        = "insert_proposal_tally"
+	upsertVoteStmtName                 = "upsert_vote"
+	insertVoteStmtName                 = "insert_vote"
+	deleteVotesStmtName                = "delete_votes"
+	upsertDepositStmtName              = "upsert_deposit"
+	deleteDepositStmtName              = "delete_deposit"
+	upsertDAOStmtName                  = "upsert_dao"
+	deleteDAOStmtName                  = "delete_dao"
+	closeDAOProposalsStmtName          = "close_dao_proposals"
+	closeDAOVotesStmtName              = "close_dao_votes"
+	closeDAODepositsStmtName           = "close_dao_deposits"
+	updateProposalStatusStmtName       = "update_proposal_status"
+	clearHolderSnapshotsStmtName       = "clear_holder_snapshots"
+	snapshotHoldersStmtName            = "snapshot_holders"
+	upsertTreasuryStmtName             = "upsert_treasury"
+	seedTreasuryStmtName               = "seed_treasury"
+	seedTreasuryBalanceStmtName        = "seed_treasury_balance"
+	updateExecutionAuditStmtName       = "update_execution_audit"
+	insertDAOEventStmtName             = "insert_dao_event"
+	insertWebhookDeliveriesStmtName    = "insert_webhook_deliveries"
 )
 
 const (
@@ -52,9 +71,23 @@ const (
 	URLHash       = "url_hash"
 	VotingEnd     = "voting_end"
 	VotingStart   = "voting_start"
+	AsaID         = "asa_id"
+	Msg           = "msg"
+	Yes           = "yes"
+	No            = "no"
+	Abstain       = "abstain"
+	Deposit       = "deposit"
+	DepositLock   = "deposit_lock"
+	MinSupport    = "min_support"
+	MinDuration   = "min_duration"
+	MaxDuration   = "max_duration"
+	VoteKeyPrefix = "p_"
 )
 
-var CurrentSigmaDAOApp = ""
+// SigmaDAO app call methods, passed as the first application argument.
+const (
+	RegisterVote = "register_vote"
+)
 
 var statements = map[string]string{
 	setSpecialAccountsStmtName: `INSERT INTO metastate (k, v) VALUES ('` +
@@ -69,10 +102,10 @@ var statements = map[string]string{
 		VALUES($1, $2, $3, $4, FALSE) ON CONFLICT (addr, assetid) DO UPDATE SET
 		amount = EXCLUDED.amount, frozen = EXCLUDED.frozen, deleted = FALSE`,
 	upsertAppStmtName: `INSERT INTO app
-		(index, creator, params, dao_name, asset_id, deleted)
-		VALUES($1, $2, $3, $4, $5, FALSE) ON CONFLICT (index) DO UPDATE SET
-		creator = EXCLUDED.creator, params = EXCLUDED.params, dao_name = EXCLUDED.dao_name,
-		asset_id = EXCLUDED.asset_id, deleted = FALSE`,
+		(index, creator, params, version, deleted)
+		VALUES($1, $2, $3, $4, FALSE) ON CONFLICT (index) DO UPDATE SET
+		creator = EXCLUDED.creator, params = EXCLUDED.params, version = EXCLUDED.version,
+		deleted = FALSE`,
 	upsertAccountAppStmtName: `INSERT INTO account_app
 		(addr, app, localstate, voting_start, voting_end, deleted)
 		VALUES($1, $2, $3, $4, $5, FALSE) ON CONFLICT (addr, app) DO UPDATE SET
@@ -110,11 +143,8 @@ var statements = map[string]string{
 		(addr, assetid, amount, frozen, deleted)
 		VALUES($1, $2, 0, false, TRUE) ON CONFLICT (addr, assetid) DO UPDATE SET
 		amount = EXCLUDED.amount, frozen = TRUE, deleted = TRUE`,
-	deleteAppStmtName: `INSERT INTO app
-		(index, creator, params, dao_name, asset_id, deleted)
-		VALUES($1, $2, 'null'::jsonb, $3, $4, TRUE) ON CONFLICT (index) DO UPDATE SET
-		creator = EXCLUDED.creator, params = EXCLUDED.params, dao_name = EXCLUDED.dao_name,
-		asset_id = EXCLUDED.asset_id, deleted = TRUE`,
+	deleteAppStmtName: `UPDATE app SET params = 'null'::jsonb, deleted = TRUE
+		WHERE index = $1`,
 	deleteAccountAppStmtName: `INSERT INTO account_app
 		(addr, app, localstate, voting_start, voting_end, deleted)
 		VALUES($1, $2, 'null'::jsonb, $3, $4, TRUE) ON CONFLICT (addr, app) DO UPDATE SET
@@ -122,17 +152,229 @@ var statements = map[string]string{
 		voting_end = EXCLUDED.voting_end, deleted = TRUE`,
 	updateAccountTotalsStmtName: `UPDATE metastate SET v = $1 WHERE k = '` +
 		schema.AccountTotals + `'`,
+	upsertProposalStmtName: `INSERT INTO proposal
+		(app, addr, proposal_id, name, url, url_hash, hash_algo, voting_start, voting_end,
+		execute_before, type, from_addr, recipient, asa_id, amount, msg, executed, yes, no,
+		abstain, deleted)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+		$18, $19, $20, FALSE) ON CONFLICT (app, addr) DO UPDATE SET
+		proposal_id = EXCLUDED.proposal_id, name = EXCLUDED.name, url = EXCLUDED.url,
+		url_hash = EXCLUDED.url_hash, hash_algo = EXCLUDED.hash_algo,
+		voting_start = EXCLUDED.voting_start, voting_end = EXCLUDED.voting_end,
+		execute_before = EXCLUDED.execute_before, type = EXCLUDED.type,
+		from_addr = EXCLUDED.from_addr, recipient = EXCLUDED.recipient,
+		asa_id = EXCLUDED.asa_id, amount = EXCLUDED.amount, msg = EXCLUDED.msg,
+		executed = EXCLUDED.executed, yes = EXCLUDED.yes, no = EXCLUDED.no,
+		abstain = EXCLUDED.abstain, status = NULL,
+		snapshot_round = CASE WHEN proposal.deleted THEN NULL ELSE proposal.snapshot_round END,
+		execution_round =
+		CASE WHEN proposal.deleted THEN NULL ELSE proposal.execution_round END,
+		execution_result =
+		CASE WHEN proposal.deleted THEN NULL ELSE proposal.execution_result END,
+		execution_details =
+		CASE WHEN proposal.deleted THEN NULL ELSE proposal.execution_details END,
+		closed_round = NULL, deleted = FALSE`,
+	deleteProposalStmtName: `UPDATE proposal SET closed_round = $3, deleted = TRUE
+		WHERE app = $1 AND addr = $2 AND NOT deleted`,
+	insertProposalTallyStmtName: `INSERT INTO proposal_tally_history
+		(app, addr, round, yes, no, abstain)
+		SELECT $1::bigint, $2::bytea, $3::bigint, $4::numeric, $5::numeric, $6::numeric
+		WHERE NOT EXISTS (SELECT 1 FROM proposal p
+			WHERE p.app = $1::bigint AND p.addr = $2::bytea AND NOT p.deleted AND
+			p.yes = $4::numeric AND p.no = $5::numeric AND p.abstain = $6::numeric)
+		ON CONFLICT (app, addr, round) DO UPDATE SET
+		yes = EXCLUDED.yes, no = EXCLUDED.no, abstain = EXCLUDED.abstain`,
+	upsertVoteStmtName: `INSERT INTO vote
+		(app, proposal, voter, vote_option, weight, round, deleted)
+		VALUES($1, $2, $3, $4, $5, $6, FALSE) ON CONFLICT (app, proposal, voter) DO UPDATE SET
+		vote_option = EXCLUDED.vote_option, weight = EXCLUDED.weight, round = EXCLUDED.round,
+		closed_round = NULL, deleted = FALSE`,
+	insertVoteStmtName: `INSERT INTO vote
+		(app, proposal, voter, vote_option, weight, round, deleted)
+		VALUES($1, $2, $3, NULL, NULL, $4, FALSE) ON CONFLICT (app, proposal, voter) DO NOTHING`,
+	deleteVotesStmtName: `UPDATE vote SET closed_round = $4, deleted = TRUE
+		WHERE app = $1 AND voter = $2 AND NOT deleted AND NOT (proposal = ANY($3))`,
+	upsertDepositStmtName: `WITH changed AS (
+		INSERT INTO dao_deposit (app, addr, amount, deposit_lock, round, deleted)
+		VALUES($1, $2, $3, $4, $5, FALSE) ON CONFLICT (app, addr) DO UPDATE SET
+		amount = EXCLUDED.amount, deposit_lock = EXCLUDED.deposit_lock, round = EXCLUDED.round,
+		closed_round = NULL, deleted = FALSE
+		WHERE dao_deposit.amount <> EXCLUDED.amount OR dao_deposit.deleted OR
+		dao_deposit.deposit_lock IS DISTINCT FROM EXCLUDED.deposit_lock
+		RETURNING app, addr, amount, deposit_lock, round)
+		INSERT INTO dao_deposit_history (app, addr, round, amount, deposit_lock)
+		SELECT app, addr, round, amount, deposit_lock FROM changed
+		ON CONFLICT (app, addr, round) DO UPDATE SET
+		amount = EXCLUDED.amount, deposit_lock = EXCLUDED.deposit_lock`,
+	deleteDepositStmtName: `WITH changed AS (
+		UPDATE dao_deposit SET amount = 0, deposit_lock = NULL, round = $3, closed_round = $3,
+		deleted = TRUE
+		WHERE app = $1 AND addr = $2 AND NOT deleted
+		RETURNING app, addr, amount, deposit_lock, round)
+		INSERT INTO dao_deposit_history (app, addr, round, amount, deposit_lock)
+		SELECT app, addr, round, amount, deposit_lock FROM changed
+		ON CONFLICT (app, addr, round) DO UPDATE SET
+		amount = EXCLUDED.amount, deposit_lock = EXCLUDED.deposit_lock`,
+	upsertDAOStmtName: `INSERT INTO dao
+		(app, creator, name, url, gov_token_id, deposit, min_support, min_duration,
+		max_duration, deleted)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE) ON CONFLICT (app) DO UPDATE SET
+		creator = EXCLUDED.creator, name = EXCLUDED.name, url = EXCLUDED.url,
+		gov_token_id = EXCLUDED.gov_token_id, deposit = EXCLUDED.deposit,
+		min_support = EXCLUDED.min_support, min_duration = EXCLUDED.min_duration,
+		max_duration = EXCLUDED.max_duration, closed_round = NULL, deleted = FALSE`,
+	deleteDAOStmtName: `UPDATE dao SET closed_round = $2, deleted = TRUE
+		WHERE app = $1 AND NOT deleted`,
+	// The records of a deleted SigmaDAO app keep their values so that their history
+	// can still be read.
+	closeDAOProposalsStmtName: `UPDATE proposal SET closed_round = $2, deleted = TRUE
+		WHERE app = $1 AND NOT deleted`,
+	closeDAOVotesStmtName: `UPDATE vote SET closed_round = $2, deleted = TRUE
+		WHERE app = $1 AND NOT deleted`,
+	closeDAODepositsStmtName: `UPDATE dao_deposit SET closed_round = $2, deleted = TRUE
+		WHERE app = $1 AND NOT deleted`,
+	// Recomputes the status of proposals that can still change, given the block
+	// timestamp $1. A proposal passes with more yes than no votes and at least
+	// min_support yes votes. The status is reset by proposal updates, so only the
+	// statuses that differ from the last one in the history are recorded, at round
+	// $2, and returned.
+	updateProposalStatusStmtName: `WITH computed AS (
+		SELECT p.app, p.addr,
+		CASE
+		WHEN p.executed THEN '` + string(idb.ProposalExecuted) + `'
+		WHEN $1 < p.voting_start THEN '` + string(idb.ProposalPending) + `'
+		WHEN $1 <= p.voting_end THEN '` + string(idb.ProposalVoting) + `'
+		WHEN p.yes > p.no AND p.yes >= coalesce(d.min_support, 0) THEN
+			CASE WHEN $1 < p.execute_before THEN '` + string(idb.ProposalPassed) + `'
+			ELSE '` + string(idb.ProposalExpired) + `' END
+		ELSE '` + string(idb.ProposalRejected) + `'
+		END AS status
+		FROM proposal p LEFT JOIN dao d ON d.app = p.app
+		WHERE NOT p.deleted AND (p.status IS NULL OR p.status IN ('` +
+		string(idb.ProposalPending) + `', '` + string(idb.ProposalVoting) + `', '` +
+		string(idb.ProposalPassed) + `'))),
+		updated AS (
+		UPDATE proposal SET status = computed.status FROM computed
+		WHERE proposal.app = computed.app AND proposal.addr = computed.addr AND
+		proposal.status IS DISTINCT FROM computed.status
+		RETURNING proposal.app, proposal.addr, proposal.status)
+		INSERT INTO proposal_status_history (app, addr, round, status)
+		SELECT u.app, u.addr, $2, u.status FROM updated u
+		WHERE u.status IS DISTINCT FROM (
+			SELECT h.status FROM proposal_status_history h
+			WHERE h.app = u.app AND h.addr = u.addr ORDER BY h.round DESC LIMIT 1)
+		ON CONFLICT (app, addr, round) DO UPDATE SET status = EXCLUDED.status
+		RETURNING app, addr, status`,
+	// Removes the snapshot left by a closed proposal whose account added a new proposal,
+	// before the new one is taken.
+	clearHolderSnapshotsStmtName: `DELETE FROM proposal_holder_snapshot s USING proposal p
+		WHERE p.app = s.app AND p.addr = s.proposal AND p.snapshot_round IS NULL AND
+		p.voting_start <= $1`,
+	// Snapshots the governance token holders of the proposals whose voting starts at
+	// or before the block timestamp $1, at round $2. Holdings must be up to date, with
+	// the DAORelevance policy the holders are the relevant accounts, see
+	// writeRelevanceChanges().
+	snapshotHoldersStmtName: `WITH due AS (
+		UPDATE proposal p SET snapshot_round = $2 FROM dao d
+		WHERE d.app = p.app AND p.snapshot_round IS NULL AND NOT p.deleted AND
+		p.voting_start <= $1 AND d.gov_token_id IS NOT NULL
+		RETURNING p.app, p.addr, d.gov_token_id)
+		INSERT INTO proposal_holder_snapshot (app, proposal, holder, amount, round)
+		SELECT due.app, due.addr, aa.addr, aa.amount, $2 FROM due
+		JOIN account_asset aa ON aa.assetid = due.gov_token_id
+		WHERE NOT aa.deleted AND aa.amount > 0
+		ON CONFLICT (app, proposal, holder) DO UPDATE SET
+		amount = EXCLUDED.amount, round = EXCLUDED.round`,
+	// Updates a treasury balance and adds a history row with the change since the
+	// previous balance.
+	upsertTreasuryStmtName: `WITH prev AS (
+		SELECT amount FROM dao_treasury WHERE app = $1 AND addr = $2 AND asset = $3),
+		changed AS (
+		INSERT INTO dao_treasury (app, addr, asset, amount, round)
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (app, addr, asset) DO UPDATE SET
+		amount = EXCLUDED.amount, round = EXCLUDED.round
+		WHERE dao_treasury.amount <> EXCLUDED.amount
+		RETURNING app, addr, asset, amount, round)
+		INSERT INTO dao_treasury_history (app, addr, asset, round, amount, change)
+		SELECT c.app, c.addr, c.asset, c.round, c.amount,
+		c.amount - COALESCE((SELECT amount FROM prev), 0) FROM changed c
+		ON CONFLICT (app, addr, asset, round) DO UPDATE SET
+		amount = EXCLUDED.amount, change = EXCLUDED.change`,
+	// Starts tracking a treasury from the current balances of its account. There is
+	// always an ALGO row so that the treasury is known after a restart.
+	seedTreasuryStmtName: `WITH balances AS (
+		SELECT 0 AS asset, COALESCE((SELECT microalgos FROM account
+		WHERE addr = $2::bytea AND NOT deleted), 0)::numeric AS amount
+		UNION ALL
+		SELECT assetid, amount FROM account_asset WHERE addr = $2::bytea AND NOT deleted),
+		seeded AS (
+		INSERT INTO dao_treasury (app, addr, asset, amount, round)
+		SELECT $1::bigint, $2::bytea, asset, amount, $3::bigint FROM balances
+		ON CONFLICT (app, addr, asset) DO NOTHING
+		RETURNING app, addr, asset, amount, round)
+		INSERT INTO dao_treasury_history (app, addr, asset, round, amount, change)
+		SELECT app, addr, asset, round, amount, NULL FROM seeded
+		ON CONFLICT (app, addr, asset, round) DO NOTHING`,
+	// Starts tracking a treasury balance from the given amount.
+	seedTreasuryBalanceStmtName: `WITH seeded AS (
+		INSERT INTO dao_treasury (app, addr, asset, amount, round)
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (app, addr, asset) DO NOTHING
+		RETURNING app, addr, asset, amount, round)
+		INSERT INTO dao_treasury_history (app, addr, asset, round, amount, change)
+		SELECT app, addr, asset, round, amount, NULL FROM seeded
+		ON CONFLICT (app, addr, asset, round) DO NOTHING`,
+	updateExecutionAuditStmtName: `UPDATE proposal SET
+		execution_round = $3, execution_result = $4, execution_details = $5
+		WHERE app = $1 AND addr = $2`,
+	insertDAOEventStmtName: `INSERT INTO dao_event
+		(round, intra, app, txid, sender, proposal, method, args)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+	// One delivery per event and matching webhook, in event order.
+	insertWebhookDeliveriesStmtName: `INSERT INTO webhook_delivery
+		(webhook, round, kind, payload, status, next_attempt)
+		SELECT w.name, $1, e.kind, e.payload::jsonb, '` + string(idb.WebhookDeliveryPending) + `', now()
+		FROM unnest($2::bigint[], $3::text[], $4::text[]) WITH ORDINALITY AS e (app, kind, payload, seq)
+		JOIN webhook w ON (w.apps IS NULL OR e.app = ANY(w.apps))
+		AND (w.kinds IS NULL OR e.kind = ANY(w.kinds))
+		ORDER BY e.seq, w.name`,
 }
 
 // Writer is responsible for writing blocks and accounting state deltas to the database.
 type Writer struct {
-	tx pgx.Tx
+	tx              pgx.Tx
+	daoPrograms     DAOProgramRegistry
+	daoApps         *DAOAppCache
+	daoAppChanges   DAOAppChanges
+	relevance       RelevancePolicy
+	relChanges      RelevanceChanges
+	treasuries      *TreasuryCache
+	treasuryChanges TreasuryChanges
+	ledger          idb.LedgerState
+	audits          []ExecutionAudit
+	events          []idb.DAOEvent
 }
 
-// MakeWriter creates a Writer object.
-func MakeWriter(tx pgx.Tx) (Writer, error) {
+// MakeWriter creates a Writer object. `daoPrograms` decides which applications
+// are indexed as SigmaDAO applications. `daoApps` are the SigmaDAO applications
+// already in the database, the local state of other applications is not written.
+// `relevance` decides which accounts and assets are written, nil means all of them.
+// `treasuries` are the SigmaDAO treasuries whose balances are tracked. `ledger` is
+// the state before the written block, it is read for the accounts and assets that
+// become relevant and the treasuries that start being tracked; if nil, only the
+// changes in the block are written. The writer does not modify `daoApps`,
+// `relevance` and `treasuries`, see DAOAppChanges(), RelevanceChanges() and
+// TreasuryChanges().
+func MakeWriter(tx pgx.Tx, daoPrograms DAOProgramRegistry, daoApps *DAOAppCache, relevance RelevancePolicy, treasuries *TreasuryCache, ledger idb.LedgerState) (Writer, error) {
+	if relevance == nil {
+		relevance = FullIndexing{}
+	}
 	w := Writer{
-		tx: tx,
+		tx:          tx,
+		daoPrograms: daoPrograms,
+		daoApps:     daoApps,
+		relevance:   relevance,
+		treasuries:  treasuries,
+		ledger:      ledger,
 	}
 
 	for name, query := range statements {
@@ -250,62 +492,74 @@ func writeAssetResource(round basics.Round, resource *ledgercore.AssetResourceRe
 	}
 }
 
-func readSigmaDAOApp(SimgaDAOApp string) string {
-	// if last fetched SimgaDAOApp hash is equal to new SigmaDAO App hash then do not read from file
-	if SimgaDAOApp == CurrentSigmaDAOApp {
-		return CurrentSigmaDAOApp
-	}
-	// read from file only when last fetched SigmaDAOApp is not matching with current SigmaDAOApp
-	// this is needed to ensure changed app hash in future
-	content, err := os.ReadFile("SigmaDAOApp.txt")
-	if err != nil {
-		log.Fatal(err)
+// writeAppParams updates the `app` and `dao` tables and records the SigmaDAO apps
+// created or deleted in `changes`.
+func writeAppParams(resource *ledgercore.AppResourceRecord, daoPrograms DAOProgramRegistry, daoApps *DAOAppCache, changes DAOAppChanges, batch *pgx.Batch) {
+	if resource.Params.Deleted {
+		// Only SigmaDAO apps are in the tables, so other deletions are no-ops.
+		if daoApps.Contains(resource.Aidx) {
+			batch.Queue(deleteAppStmtName, resource.Aidx)
+			changes[resource.Aidx] = false
+		}
+	} else if resource.Params.Params != nil {
+		// allow only SigmaDAO apps
+		version, ok := daoPrograms.Match(resource.Params.Params.ApprovalProgram)
+		if ok {
+			batch.Queue(
+				upsertAppStmtName, resource.Aidx, resource.Addr[:],
+				encoding.EncodeAppParams(*resource.Params.Params), version)
+			writeDAO(resource.Aidx, resource.Addr, resource.Params.Params, batch)
+			changes[resource.Aidx] = true
+		}
 	}
-	// update current sigmadao app hash with new hash
-	CurrentSigmaDAOApp = string(content)
-	return CurrentSigmaDAOApp
 }
 
-func writeAppResource(round basics.Round, resource *ledgercore.AppResourceRecord, batch *pgx.Batch) {
-	if resource.Params.Params != nil && resource.Params.Params.ApprovalProgram != nil {
-		b64 := base64.StdEncoding
-		var appHash = b64.EncodeToString([]byte(resource.Params.Params.ApprovalProgram))
-		SigmaDAOApp := readSigmaDAOApp(appHash)
-		// allow only SigmaDAO app
-		if SigmaDAOApp == appHash {
-			daoName := resource.Params.Params.GlobalState[DAOName]
-			assetId := resource.Params.Params.GlobalState[GovTokenId]
-			if resource.Params.Deleted {
-				batch.Queue(deleteAppStmtName, resource.Aidx, resource.Addr[:], daoName.Bytes, assetId.Uint)
-			} else {
-				if resource.Params.Params != nil {
-					batch.Queue(
-						upsertAppStmtName, resource.Aidx, resource.Addr[:],
-						encoding.EncodeAppParams(*resource.Params.Params), daoName.Bytes, assetId.Uint)
-				}
-			}
+// writeAppLocalState updates the `account_app` table and the tables decoded from
+// SigmaDAO local state.
+func writeAppLocalState(round basics.Round, resource *ledgercore.AppResourceRecord, registerVotes map[voteKey]string, batch *pgx.Batch) {
+	if resource.State.Deleted {
+		batch.Queue(deleteAccountAppStmtName, resource.Addr[:], resource.Aidx, uint64(0), uint64(0))
+	} else {
+		if resource.State.LocalState != nil {
+			voting_start := resource.State.LocalState.KeyValue[VotingStart]
+			voting_end := resource.State.LocalState.KeyValue[VotingEnd]
+			batch.Queue(
+				upsertAccountAppStmtName, resource.Addr[:], resource.Aidx,
+				encoding.EncodeAppLocalState(*resource.State.LocalState), voting_start.Uint, voting_end.Uint)
 		}
 	}
 
-	if resource.State.LocalState != nil {
-		voting_start := resource.State.LocalState.KeyValue[VotingStart]
-		voting_end := resource.State.LocalState.KeyValue[VotingEnd]
-		if resource.State.Deleted {
-			batch.Queue(deleteAccountAppStmtName, resource.Addr[:], resource.Aidx, voting_start.Uint, voting_end.Uint)
-		} else {
-			if resource.State.LocalState != nil {
-				batch.Queue(
-					upsertAccountAppStmtName, resource.Addr[:], resource.Aidx,
-					encoding.EncodeAppLocalState(*resource.State.LocalState), voting_start.Uint, voting_end.Uint)
-			}
-		}
+	if resource.State.Deleted || (resource.State.LocalState != nil) {
+		writeProposal(round, resource, batch)
+		writeVotes(round, resource, registerVotes, batch)
+		writeDeposit(round, resource, batch)
 	}
 }
 
-func writeAccountDeltas(round basics.Round, accountDeltas *ledgercore.AccountDeltas, sigtypeDeltas map[basics.Address]sigTypeDelta, batch *pgx.Batch) {
+func writeAccountDeltas(round basics.Round, accountDeltas *ledgercore.AccountDeltas, sigtypeDeltas map[basics.Address]sigTypeDelta, daoPrograms DAOProgramRegistry, daoApps *DAOAppCache, changes DAOAppChanges, relevance RelevancePolicy, ledger idb.LedgerState, treasuries *TreasuryCache, treasuryChanges TreasuryChanges, registerVotes map[voteKey]string, batch *pgx.Batch) (RelevanceChanges, error) {
+	// Update `app` table. App params go first so that the state of SigmaDAO apps
+	// created in this round is not skipped.
+	appResources := accountDeltas.GetAllAppResources()
+	for i := range appResources {
+		writeAppParams(&appResources[i], daoPrograms, daoApps, changes, batch)
+	}
+	view := daoAppView{cache: daoApps, changes: changes}
+	relChanges := getRelevanceChanges(accountDeltas, relevance, view)
+	getTreasuryChanges(appResources, view, treasuries, treasuryChanges)
+
+	// Write the state of newly relevant accounts and assets that is not in the block.
+	err := writeRelevanceChanges(round, accountDeltas, relevance, relChanges, ledger, batch)
+	if err != nil {
+		return RelevanceChanges{}, fmt.Errorf("writeAccountDeltas() err: %w", err)
+	}
+
 	// Update `account` table.
 	for i := 0; i < accountDeltas.Len(); i++ {
 		address, accountData := accountDeltas.GetByIdx(i)
+		writeTreasuryAccount(round, address, &accountData, treasuries, batch)
+		if !relevance.AccountRelevant(address, relChanges) {
+			continue
+		}
 
 		var sigtypeDelta optionalSigTypeDelta
 		sigtypeDelta.value, sigtypeDelta.present = sigtypeDeltas[address]
@@ -317,17 +571,63 @@ func writeAccountDeltas(round basics.Round, accountDeltas *ledgercore.AccountDel
 	{
 		assetResources := accountDeltas.GetAllAssetResources()
 		for i := range assetResources {
-			writeAssetResource(round, &assetResources[i], batch)
+			writeTreasuryAsset(round, &assetResources[i], treasuries, batch)
+			if relevance.AssetRelevant(assetResources[i].Aidx, relChanges) {
+				writeAssetResource(round, &assetResources[i], batch)
+			}
 		}
 	}
 
-	// Update `app` and `account_app` tables.
-	{
-		appResources := accountDeltas.GetAllAppResources()
-		for i := range appResources {
-			writeAppResource(round, &appResources[i], batch)
+	// Update `dao_treasury` table with the new treasuries.
+	err = writeTreasuryChanges(round, treasuryChanges, accountDeltas, ledger, batch)
+	if err != nil {
+		return RelevanceChanges{}, fmt.Errorf("writeAccountDeltas() err: %w", err)
+	}
+
+	// Update `account_app` table.
+	for i := range appResources {
+		if view.contains(appResources[i].Aidx) {
+			writeAppLocalState(round, &appResources[i], registerVotes, batch)
 		}
 	}
+
+	// Close the records of deleted SigmaDAO apps. Must come after the local state
+	// updates, which may still touch them in this round.
+	writeDAODeletions(round, changes, batch)
+
+	return relChanges, nil
+}
+
+// DAOAppChanges returns the SigmaDAO apps created or deleted by the last AddBlock()
+// call. They need to be applied to the DAO app cache once the transaction commits.
+func (w *Writer) DAOAppChanges() DAOAppChanges {
+	return w.daoAppChanges
+}
+
+// TreasuryChanges returns the treasuries that started being tracked in the last
+// AddBlock() call. They need to be applied to the treasury cache once the
+// transaction commits.
+func (w *Writer) TreasuryChanges() TreasuryChanges {
+	return w.treasuryChanges
+}
+
+// ExecutionAudits returns the results of checking the `execute` calls of the last
+// AddBlock() call against the proposal terms.
+func (w *Writer) ExecutionAudits() []ExecutionAudit {
+	return w.audits
+}
+
+// DAOEvents returns the streamed SigmaDAO events of the last AddBlock() call. They
+// must only be published once the transaction commits.
+func (w *Writer) DAOEvents() []idb.DAOEvent {
+	return w.events
+}
+
+// RelevanceChanges returns the accounts and assets that became relevant in the last
+// AddBlock() call. They need to be applied to the relevance policy once the
+// transaction commits.
+func (w *Writer) RelevanceChanges() RelevanceChanges {
+	return w.relChanges
 }
 
 // AddBlock0 writes block 0 to the database.
@@ -357,6 +657,45 @@ func (w *Writer) AddBlock0(block *bookkeeping.Block) error {
 	return nil
 }
 
+// AddDAOApps writes the SigmaDAO apps in `accountDeltas` to the `app`, `account_app`
+// and SigmaDAO tables. It backfills apps whose program was registered after they
+// were created, so `accountDeltas` must hold their full params and local states as
+// of the block with header `header`, the last block in the database.
+func (w *Writer) AddDAOApps(header *bookkeeping.BlockHeader, accountDeltas *ledgercore.AccountDeltas) error {
+	var batch pgx.Batch
+
+	w.daoAppChanges = make(DAOAppChanges)
+	appResources := accountDeltas.GetAllAppResources()
+	for i := range appResources {
+		writeAppParams(&appResources[i], w.daoPrograms, w.daoApps, w.daoAppChanges, &batch)
+	}
+	view := daoAppView{cache: w.daoApps, changes: w.daoAppChanges}
+	for i := range appResources {
+		if view.contains(appResources[i].Aidx) {
+			// Vote options are only known for votes seen being cast.
+			writeAppLocalState(header.Round, &appResources[i], nil, &batch)
+		}
+	}
+	// Must come after all proposal updates.
+	batch.Queue(updateProposalStatusStmtName, header.TimeStamp, uint64(header.Round))
+
+	results := w.tx.SendBatch(context.Background(), &batch)
+	// Clean the results off the connection's queue. Without this, weird things happen.
+	for i := 0; i < batch.Len(); i++ {
+		_, err := results.Exec()
+		if err != nil {
+			results.Close()
+			return fmt.Errorf("AddDAOApps() exec err: %w", err)
+		}
+	}
+	err := results.Close()
+	if err != nil {
+		return fmt.Errorf("AddDAOApps() close results err: %w", err)
+	}
+
+	return nil
+}
+
 // AddBlock writes the block and accounting state deltas to the database, except for
 // transactions and transaction participation. Those are imported by free functions in
 // the writer/ directory.
@@ -373,9 +712,33 @@ func (w *Writer) AddBlock(block *bookkeeping.Block, modifiedTxns []transactions.
 		if err != nil {
 			return fmt.Errorf("AddBlock() err: %w", err)
 		}
-		writeAccountDeltas(block.Round(), &delta.Accts, sigTypeDeltas, &batch)
+		registerVotes := getRegisterVotes(block.Payset)
+		w.daoAppChanges = make(DAOAppChanges)
+		w.treasuryChanges = make(TreasuryChanges)
+		w.relChanges, err = writeAccountDeltas(
+			block.Round(), &delta.Accts, sigTypeDeltas, w.daoPrograms, w.daoApps,
+			w.daoAppChanges, w.relevance, w.ledger, w.treasuries, w.treasuryChanges,
+			registerVotes, &batch)
+		if err != nil {
+			return fmt.Errorf("AddBlock() err: %w", err)
+		}
+
+		view := daoAppView{cache: w.daoApps, changes: w.daoAppChanges}
+		w.events, err = writeDAOEvents(block, view, &batch)
+		if err != nil {
+			return fmt.Errorf("AddBlock() err: %w", err)
+		}
+
+		w.audits, err = getExecutionAudits(block, &delta.Accts, view)
+		if err != nil {
+			return fmt.Errorf("AddBlock() err: %w", err)
+		}
+		writeExecutionAudits(block.Round(), w.audits, &batch)
 	}
 	batch.Queue(updateAccountTotalsStmtName, encoding.EncodeAccountTotals(&delta.Totals))
+	// Must come after all holding updates of this block.
+	batch.Queue(clearHolderSnapshotsStmtName, block.TimeStamp)
+	batch.Queue(snapshotHoldersStmtName, block.TimeStamp, uint64(block.Round()))
 
 	results := w.tx.SendBatch(context.Background(), &batch)
 	// Clean the results off the connection's queue. Without this, weird things happen.
@@ -391,5 +754,17 @@ func (w *Writer) AddBlock(block *bookkeeping.Block, modifiedTxns []transactions.
 		return fmt.Errorf("AddBlock() close results err: %w", err)
 	}
 
+	// Must come after all proposal updates of this block.
+	statusChanges, err := updateProposalStatus(w.tx, block.Round(), block.TimeStamp)
+	if err != nil {
+		return fmt.Errorf("AddBlock() err: %w", err)
+	}
+	w.events = append(w.events, statusChanges...)
+
+	err = addWebhookDeliveries(w.tx, block.Round(), w.events)
+	if err != nil {
+		return fmt.Errorf("AddBlock() err: %w", err)
+	}
+
 	return nil
 }
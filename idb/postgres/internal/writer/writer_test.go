@@ -2,7 +2,10 @@ package writer_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math"
+	"strconv"
 	"testing"
 
 	"github.com/algorand/go-algorand/crypto"
@@ -99,7 +102,7 @@ func TestWriterSpecialAccounts(t *testing.T) {
 	block := test.MakeGenesisBlock()
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, ledgercore.StateDelta{})
@@ -160,7 +163,7 @@ func TestWriterAccountTableBasic(t *testing.T) {
 	})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -262,7 +265,7 @@ func TestWriterAccountTableCreateDeleteSameRound(t *testing.T) {
 	delta.Accts.Upsert(test.AccountA, ledgercore.AccountData{})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -345,7 +348,7 @@ func TestWriterDeleteAccountDoesNotDeleteKeytype(t *testing.T) {
 	})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -396,7 +399,7 @@ func TestWriterAccountAssetTableBasic(t *testing.T) {
 		ledgercore.AssetHoldingDelta{Holding: &assetHolding})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -482,7 +485,7 @@ func TestWriterAccountAssetTableCreateDeleteSameRound(t *testing.T) {
 		ledgercore.AssetHoldingDelta{Deleted: true})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -532,7 +535,7 @@ func TestWriterAccountAssetTableLargeAmount(t *testing.T) {
 		ledgercore.AssetHoldingDelta{Holding: &assetHolding})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -570,7 +573,7 @@ func TestWriterAssetTableBasic(t *testing.T) {
 		ledgercore.AssetHoldingDelta{})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -662,7 +665,7 @@ func TestWriterAssetTableCreateDeleteSameRound(t *testing.T) {
 		ledgercore.AssetHoldingDelta{})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -719,8 +722,13 @@ func TestWriterAppTableBasic(t *testing.T) {
 		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &appParams},
 		ledgercore.AppLocalStateDelta{})
 
+	daoPrograms := writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+		{Version: "v1", ApprovalProgram: []byte{1, 2}},
+		{Version: "v2", ApprovalProgram: []byte{3, 4, 5}},
+	})
+
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, daoPrograms, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -735,16 +743,16 @@ func TestWriterAppTableBasic(t *testing.T) {
 	var index uint64
 	var creator []byte
 	var params []byte
+	var version string
 	var deleted bool
-	var createdAt uint64
-	var closedAt *uint64
 
-	rows, err := db.Query(context.Background(), "SELECT * FROM app")
+	rows, err := db.Query(
+		context.Background(), "SELECT index, creator, params, version, deleted FROM app")
 	require.NoError(t, err)
 	defer rows.Close()
 
 	require.True(t, rows.Next())
-	err = rows.Scan(&index, &creator, &params, &deleted, &createdAt, &closedAt)
+	err = rows.Scan(&index, &creator, &params, &version, &deleted)
 	require.NoError(t, err)
 
 	assert.Equal(t, appID, basics.AppIndex(index))
@@ -754,9 +762,8 @@ func TestWriterAppTableBasic(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, appParams, paramsRead)
 	}
+	assert.Equal(t, "v2", version)
 	assert.False(t, deleted)
-	assert.Equal(t, block.Round(), basics.Round(createdAt))
-	assert.Nil(t, closedAt)
 
 	assert.False(t, rows.Next())
 	assert.NoError(t, rows.Err())
@@ -772,12 +779,13 @@ func TestWriterAppTableBasic(t *testing.T) {
 	err = pgutil.TxWithRetry(db, serializable, f, nil)
 	require.NoError(t, err)
 
-	rows, err = db.Query(context.Background(), "SELECT * FROM app")
+	rows, err = db.Query(
+		context.Background(), "SELECT index, creator, params, version, deleted FROM app")
 	require.NoError(t, err)
 	defer rows.Close()
 
 	require.True(t, rows.Next())
-	err = rows.Scan(&index, &creator, &params, &deleted, &createdAt, &closedAt)
+	err = rows.Scan(&index, &creator, &params, &version, &deleted)
 	require.NoError(t, err)
 
 	assert.Equal(t, appID, basics.AppIndex(index))
@@ -788,15 +796,53 @@ func TestWriterAppTableBasic(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, basics.AppParams{}, paramsRead)
 	}
+	assert.Equal(t, "v2", version)
 	assert.True(t, deleted)
-	assert.Equal(t, uint64(block.Round())-1, createdAt)
-	require.NotNil(t, closedAt)
-	assert.Equal(t, uint64(block.Round()), *closedAt)
 
 	assert.False(t, rows.Next())
 	assert.NoError(t, rows.Err())
 }
 
+// Apps whose approval program is not in the registry must not be written.
+func TestWriterAppTableUnknownProgram(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	appParams := basics.AppParams{
+		ApprovalProgram: []byte{3, 4, 5},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, basics.AppIndex(3), ledgercore.AppParamsDelta{Params: &appParams},
+		ledgercore.AppLocalStateDelta{})
+
+	daoPrograms := writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+		{Version: "v1", ApprovalProgram: []byte{3, 4}},
+	})
+
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, daoPrograms, nil, nil, nil, nil)
+		require.NoError(t, err)
+
+		err = w.AddBlock(&block, block.Payset, delta)
+		require.NoError(t, err)
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	var count int
+	row := db.QueryRow(context.Background(), "SELECT count(*) FROM app")
+	err = row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
 // Simulate a scenario where an app is added and deleted in the same round.
 func TestWriterAppTableCreateDeleteSameRound(t *testing.T) {
 	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
@@ -812,7 +858,7 @@ func TestWriterAppTableCreateDeleteSameRound(t *testing.T) {
 		ledgercore.AppLocalStateDelta{})
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -824,29 +870,12 @@ func TestWriterAppTableCreateDeleteSameRound(t *testing.T) {
 	err := pgutil.TxWithRetry(db, serializable, f, nil)
 	require.NoError(t, err)
 
-	var index uint64
-	var creator []byte
-	var params []byte
-	var deleted bool
-	var createdAt uint64
-	var closedAt uint64
-
-	row := db.QueryRow(context.Background(), "SELECT * FROM app")
+	// The app was never seen by the indexer, so nothing is recorded.
+	var count int
+	row := db.QueryRow(context.Background(), "SELECT count(*) FROM app")
+	err = row.Scan(&count)
 	require.NoError(t, err)
-	err = row.Scan(&index, &creator, &params, &deleted, &createdAt, &closedAt)
-	require.NoError(t, err)
-
-	assert.Equal(t, appID, basics.AppIndex(index))
-	assert.Equal(t, test.AccountA[:], creator)
-	assert.Equal(t, []byte("null"), params)
-	{
-		paramsRead, err := encoding.DecodeAppParams(params)
-		require.NoError(t, err)
-		assert.Equal(t, basics.AppParams{}, paramsRead)
-	}
-	assert.True(t, deleted)
-	assert.Equal(t, block.Round(), basics.Round(createdAt))
-	assert.Equal(t, block.Round(), basics.Round(closedAt))
+	assert.Equal(t, 0, count)
 }
 
 func TestWriterAccountAppTableBasic(t *testing.T) {
@@ -869,8 +898,10 @@ func TestWriterAccountAppTableBasic(t *testing.T) {
 		test.AccountA, appID, ledgercore.AppParamsDelta{},
 		ledgercore.AppLocalStateDelta{LocalState: &appLocalState})
 
+	daoApps := writer.MakeDAOAppCache([]basics.AppIndex{appID})
+
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, daoApps, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -961,8 +992,10 @@ func TestWriterAccountAppTableCreateDeleteSameRound(t *testing.T) {
 		test.AccountA, appID, ledgercore.AppParamsDelta{},
 		ledgercore.AppLocalStateDelta{Deleted: true})
 
+	daoApps := writer.MakeDAOAppCache([]basics.AppIndex{appID})
+
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, daoApps, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, delta)
@@ -998,6 +1031,100 @@ func TestWriterAccountAppTableCreateDeleteSameRound(t *testing.T) {
 	assert.Equal(t, block.Round(), basics.Round(closedAt))
 }
 
+// Local state of apps that are not SigmaDAO apps is not written.
+func TestWriterAccountAppTableNonDAOApp(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"deposit": {Type: basics.TealUintType, Uint: 5},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, basics.AppIndex(3), ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	addBlock(t, db, &block, delta)
+
+	var count int
+	row := db.QueryRow(context.Background(), "SELECT count(*) FROM account_app")
+	err := row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+// The local state of a SigmaDAO app created in the same round is written, and the
+// creation is reported so that the cache can be updated.
+func TestWriterAccountAppTableDAOAppCreatedSameRound(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	appID := basics.AppIndex(3)
+	appParams := basics.AppParams{ApprovalProgram: testDAOProgram}
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"deposit": {Type: basics.TealUintType, Uint: 5},
+		},
+	}
+	var delta ledgercore.StateDelta
+	// Local state comes first so that the writer cannot rely on the delta order.
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &appParams},
+		ledgercore.AppLocalStateDelta{})
+
+	daoApps := writer.MakeDAOAppCache(nil)
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, testDAOPrograms, daoApps, nil, nil, nil)
+		require.NoError(t, err)
+
+		err = w.AddBlock(&block, block.Payset, delta)
+		require.NoError(t, err)
+		assert.Equal(t, writer.DAOAppChanges{appID: true}, w.DAOAppChanges())
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	var addr []byte
+	row := db.QueryRow(context.Background(), "SELECT addr FROM account_app WHERE app = $1", appID)
+	err = row.Scan(&addr)
+	require.NoError(t, err)
+	assert.Equal(t, test.AccountB[:], addr)
+
+	// Deleting the app is reported as well.
+	block.BlockHeader.Round++
+	daoApps.Apply(writer.DAOAppChanges{appID: true})
+	delta.Accts = ledgercore.AccountDeltas{}
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Deleted: true},
+		ledgercore.AppLocalStateDelta{})
+	f = func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, testDAOPrograms, daoApps, nil, nil, nil)
+		require.NoError(t, err)
+
+		err = w.AddBlock(&block, block.Payset, delta)
+		require.NoError(t, err)
+		assert.Equal(t, writer.DAOAppChanges{appID: false}, w.DAOAppChanges())
+
+		w.Close()
+		return nil
+	}
+	err = pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+}
+
 func TestWriterAccountTotals(t *testing.T) {
 	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
 	defer shutdownFunc()
@@ -1015,7 +1142,7 @@ func TestWriterAccountTotals(t *testing.T) {
 	}
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&block, block.Payset, ledgercore.StateDelta{Totals: accountTotals})
@@ -1035,3 +1162,1752 @@ func TestWriterAccountTotals(t *testing.T) {
 
 	assert.Equal(t, accountTotals, accountTotalsRead)
 }
+
+// testDAOProgram is the approval program of the SigmaDAO app used in tests.
+var testDAOProgram = []byte{6, 0x81, 0x01, 0x43}
+
+var testDAOPrograms = writer.MakeDAOProgramRegistry([]idb.DAOProgram{
+	{Version: "v1", ApprovalProgram: testDAOProgram},
+})
+
+// addBlock writes `block` with `delta` using the test SigmaDAO program registry.
+// addBlock writes `block` and returns its streamed SigmaDAO events.
+func addBlock(t *testing.T, db *pgxpool.Pool, block *bookkeeping.Block, delta ledgercore.StateDelta) []idb.DAOEvent {
+	return addBlockWithLedger(t, db, block, delta, nil, nil)
+}
+
+// addBlockWithLedger writes a block with the relevance policy `relevance` and the
+// ledger state `ledger` and applies the relevance changes to the policy.
+func addBlockWithLedger(t *testing.T, db *pgxpool.Pool, block *bookkeeping.Block, delta ledgercore.StateDelta, relevance writer.RelevancePolicy, ledger idb.LedgerState) []idb.DAOEvent {
+	var events []idb.DAOEvent
+	var relChanges writer.RelevanceChanges
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(
+			tx, testDAOPrograms, loadDAOApps(t, tx), relevance, loadTreasuries(t, tx),
+			ledger)
+		require.NoError(t, err)
+
+		err = w.AddBlock(block, block.Payset, delta)
+		require.NoError(t, err)
+		events = w.DAOEvents()
+		relChanges = w.RelevanceChanges()
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+	if relevance != nil {
+		relevance.Apply(relChanges)
+	}
+	return events
+}
+
+// testLedgerState is an idb.LedgerState with the given accounts, holdings and assets.
+type testLedgerState struct {
+	accounts map[basics.Address]ledgercore.AccountData
+	holdings map[basics.Address]map[basics.AssetIndex]basics.AssetHolding
+	creators map[basics.AssetIndex]basics.Address
+	params   map[basics.AssetIndex]basics.AssetParams
+}
+
+func makeTestLedgerState() *testLedgerState {
+	return &testLedgerState{
+		accounts: make(map[basics.Address]ledgercore.AccountData),
+		holdings: make(map[basics.Address]map[basics.AssetIndex]basics.AssetHolding),
+		creators: make(map[basics.AssetIndex]basics.Address),
+		params:   make(map[basics.AssetIndex]basics.AssetParams),
+	}
+}
+
+func (l *testLedgerState) setHolding(addr basics.Address, assetID basics.AssetIndex, amount uint64) {
+	if l.holdings[addr] == nil {
+		l.holdings[addr] = make(map[basics.AssetIndex]basics.AssetHolding)
+	}
+	l.holdings[addr][assetID] = basics.AssetHolding{Amount: amount}
+}
+
+func (l *testLedgerState) Account(address basics.Address) (ledgercore.AccountData, error) {
+	return l.accounts[address], nil
+}
+
+func (l *testLedgerState) AssetHoldings(address basics.Address) (map[basics.AssetIndex]basics.AssetHolding, error) {
+	return l.holdings[address], nil
+}
+
+func (l *testLedgerState) AssetHolding(address basics.Address, assetID basics.AssetIndex) (*basics.AssetHolding, error) {
+	holding, ok := l.holdings[address][assetID]
+	if !ok {
+		return nil, nil
+	}
+	return &holding, nil
+}
+
+func (l *testLedgerState) Asset(assetID basics.AssetIndex) (basics.Address, *basics.AssetParams, error) {
+	params, ok := l.params[assetID]
+	if !ok {
+		return basics.Address{}, nil, nil
+	}
+	return l.creators[assetID], &params, nil
+}
+
+// loadDAOApps returns a cache with the SigmaDAO apps in the `app` table.
+func loadDAOApps(t *testing.T, tx pgx.Tx) *writer.DAOAppCache {
+	rows, err := tx.Query(context.Background(), "SELECT index FROM app WHERE NOT deleted")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var apps []basics.AppIndex
+	for rows.Next() {
+		var app uint64
+		require.NoError(t, rows.Scan(&app))
+		apps = append(apps, basics.AppIndex(app))
+	}
+	require.NoError(t, rows.Err())
+
+	return writer.MakeDAOAppCache(apps)
+}
+
+// loadTreasuries returns a cache with the treasuries in the `dao_treasury` table.
+func loadTreasuries(t *testing.T, tx pgx.Tx) *writer.TreasuryCache {
+	rows, err := tx.Query(context.Background(), "SELECT DISTINCT app, addr FROM dao_treasury")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var treasuries []writer.Treasury
+	for rows.Next() {
+		var app uint64
+		var addr []byte
+		require.NoError(t, rows.Scan(&app, &addr))
+		treasury := writer.Treasury{App: basics.AppIndex(app)}
+		copy(treasury.Address[:], addr)
+		treasuries = append(treasuries, treasury)
+	}
+	require.NoError(t, rows.Err())
+
+	return writer.MakeTreasuryCache(treasuries)
+}
+
+// addDAOApp creates the SigmaDAO app `appID` at round `round`.
+func addDAOApp(t *testing.T, db *pgxpool.Pool, round basics.Round, appID basics.AppIndex, globalState basics.TealKeyValue) {
+	var block bookkeeping.Block
+	block.BlockHeader.Round = round
+
+	appParams := basics.AppParams{
+		ApprovalProgram: testDAOProgram,
+		GlobalState:     globalState,
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &appParams},
+		ledgercore.AppLocalStateDelta{})
+
+	addBlock(t, db, &block, delta)
+}
+
+func TestWriterProposalTable(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(2)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"id":             {Type: basics.TealUintType, Uint: 7},
+			"name":           {Type: basics.TealBytesType, Bytes: "my proposal"},
+			"url":            {Type: basics.TealBytesType, Bytes: "www.example.com"},
+			"url_hash":       {Type: basics.TealBytesType, Bytes: "\x01\x02"},
+			"hash_algo":      {Type: basics.TealBytesType, Bytes: "sha256"},
+			"voting_start":   {Type: basics.TealUintType, Uint: 100},
+			"voting_end":     {Type: basics.TealUintType, Uint: 200},
+			"execute_before": {Type: basics.TealUintType, Uint: 300},
+			"type":           {Type: basics.TealUintType, Uint: 1},
+			"from":           {Type: basics.TealBytesType, Bytes: string(test.AccountC[:])},
+			"recipient":      {Type: basics.TealBytesType, Bytes: string(test.AccountD[:])},
+			"amount":         {Type: basics.TealUintType, Uint: math.MaxUint64},
+			"yes":            {Type: basics.TealUintType, Uint: 10},
+			"abstain":        {Type: basics.TealUintType, Uint: 2},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	addBlock(t, db, &block, delta)
+
+	var proposalID uint64
+	var name, url, hashAlgo string
+	var urlHash, from, recipient, msg []byte
+	var votingStart, votingEnd, executeBefore, proposalType uint64
+	var asaID *uint64
+	var amount, yes, no, abstain string
+	var executed, deleted bool
+
+	query := `SELECT proposal_id, name, url, url_hash, hash_algo, voting_start, voting_end,
+		execute_before, type, from_addr, recipient, asa_id, amount::text, msg, executed,
+		yes::text, no::text, abstain::text, deleted FROM proposal WHERE app = $1 AND addr = $2`
+	row := db.QueryRow(context.Background(), query, uint64(appID), test.AccountB[:])
+	err := row.Scan(
+		&proposalID, &name, &url, &urlHash, &hashAlgo, &votingStart, &votingEnd,
+		&executeBefore, &proposalType, &from, &recipient, &asaID, &amount, &msg, &executed,
+		&yes, &no, &abstain, &deleted)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(7), proposalID)
+	assert.Equal(t, "my proposal", name)
+	assert.Equal(t, "www.example.com", url)
+	assert.Equal(t, []byte{1, 2}, urlHash)
+	assert.Equal(t, "sha256", hashAlgo)
+	assert.Equal(t, uint64(100), votingStart)
+	assert.Equal(t, uint64(200), votingEnd)
+	assert.Equal(t, uint64(300), executeBefore)
+	assert.Equal(t, uint64(1), proposalType)
+	assert.Equal(t, test.AccountC[:], from)
+	assert.Equal(t, test.AccountD[:], recipient)
+	assert.Nil(t, asaID)
+	assert.Equal(t, "18446744073709551615", amount)
+	assert.Nil(t, msg)
+	assert.False(t, executed)
+	assert.Equal(t, "10", yes)
+	assert.Equal(t, "0", no)
+	assert.Equal(t, "2", abstain)
+	assert.False(t, deleted)
+
+	// close_proposal clears the proposal keys.
+	block.BlockHeader.Round++
+	delta = ledgercore.StateDelta{}
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{}})
+	addBlock(t, db, &block, delta)
+
+	row = db.QueryRow(
+		context.Background(), "SELECT deleted FROM proposal WHERE app = $1 AND addr = $2",
+		uint64(appID), test.AccountB[:])
+	err = row.Scan(&deleted)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestWriterProposalTallyHistory(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"type": {Type: basics.TealUintType, Uint: 3},
+			"msg":  {Type: basics.TealBytesType, Bytes: "hello"},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(2)
+	addBlock(t, db, &block, delta)
+
+	// A vote.
+	block.BlockHeader.Round++
+	localState.KeyValue["yes"] = basics.TealValue{Type: basics.TealUintType, Uint: 5}
+	addBlock(t, db, &block, delta)
+
+	// A change that is not a vote.
+	block.BlockHeader.Round++
+	localState.KeyValue["executed"] = basics.TealValue{Type: basics.TealUintType, Uint: 0}
+	addBlock(t, db, &block, delta)
+
+	// More votes.
+	block.BlockHeader.Round++
+	localState.KeyValue["no"] = basics.TealValue{Type: basics.TealUintType, Uint: 2}
+	localState.KeyValue["abstain"] = basics.TealValue{Type: basics.TealUintType, Uint: 1}
+	addBlock(t, db, &block, delta)
+
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT round, yes::text, no::text, abstain::text FROM proposal_tally_history
+		WHERE app = $1 AND addr = $2 ORDER BY round`,
+		uint64(appID), test.AccountB[:])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var history [][4]string
+	for rows.Next() {
+		var round uint64
+		var yes, no, abstain string
+		require.NoError(t, rows.Scan(&round, &yes, &no, &abstain))
+		history = append(history, [4]string{strconv.FormatUint(round, 10), yes, no, abstain})
+	}
+	require.NoError(t, rows.Err())
+
+	expected := [][4]string{
+		{"2", "0", "0", "0"},
+		{"3", "5", "0", "0"},
+		{"5", "5", "2", "1"},
+	}
+	assert.Equal(t, expected, history)
+}
+
+// Local state of apps that are not SigmaDAO apps must not create proposals.
+func TestWriterProposalTableUnknownApp(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"type": {Type: basics.TealUintType, Uint: 1},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountB, basics.AppIndex(3), ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	addBlock(t, db, &block, delta)
+
+	var count int
+	row := db.QueryRow(context.Background(), "SELECT count(*) FROM proposal")
+	err := row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestWriterVoteTable(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	proposal := test.AccountB
+	voter := test.AccountC
+
+	registerVote := test.MakeAppCallTxn(uint64(appID), voter)
+	registerVote.Txn.ApplicationArgs = [][]byte{[]byte("register_vote"), []byte("yes")}
+	registerVote.Txn.Accounts = []basics.Address{proposal}
+	block, err := test.MakeBlockForTxns(
+		bookkeeping.BlockHeader{Round: basics.Round(1)}, &registerVote)
+	require.NoError(t, err)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"deposit":                  {Type: basics.TealUintType, Uint: 15},
+			"p_" + string(proposal[:]): {Type: basics.TealUintType, Uint: 7},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		voter, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	addBlock(t, db, &block, delta)
+
+	var option string
+	var weight string
+	var round uint64
+	var deleted bool
+
+	query := `SELECT vote_option, weight::text, round, deleted FROM vote
+		WHERE app = $1 AND proposal = $2 AND voter = $3`
+	row := db.QueryRow(context.Background(), query, uint64(appID), proposal[:], voter[:])
+	err = row.Scan(&option, &weight, &round, &deleted)
+	require.NoError(t, err)
+
+	assert.Equal(t, "yes", option)
+	assert.Equal(t, "15", weight)
+	assert.Equal(t, uint64(block.Round()), round)
+	assert.False(t, deleted)
+
+	// An unrelated local state change keeps the vote as it is.
+	block.BlockHeader.Round++
+	block.Payset = nil
+	localState.KeyValue["deposit_lock"] = basics.TealValue{Type: basics.TealUintType, Uint: 1}
+	addBlock(t, db, &block, delta)
+
+	row = db.QueryRow(context.Background(), query, uint64(appID), proposal[:], voter[:])
+	err = row.Scan(&option, &weight, &round, &deleted)
+	require.NoError(t, err)
+
+	assert.Equal(t, "yes", option)
+	assert.Equal(t, "15", weight)
+	assert.Equal(t, uint64(block.Round())-1, round)
+	assert.False(t, deleted)
+
+	// clear_vote_record removes the key.
+	block.BlockHeader.Round++
+	delete(localState.KeyValue, "p_"+string(proposal[:]))
+	addBlock(t, db, &block, delta)
+
+	row = db.QueryRow(context.Background(), query, uint64(appID), proposal[:], voter[:])
+	err = row.Scan(&option, &weight, &round, &deleted)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+// Clearing out of the app deletes all the votes of an account.
+func TestWriterVoteTableClearState(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	voter := test.AccountC
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(2)
+
+	localState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"p_" + string(test.AccountA[:]): {Type: basics.TealUintType, Uint: 1},
+			"p_" + string(test.AccountB[:]): {Type: basics.TealUintType, Uint: 2},
+		},
+	}
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		voter, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &localState})
+	addBlock(t, db, &block, delta)
+
+	countQuery := "SELECT count(*) FROM vote WHERE voter = $1 AND NOT deleted"
+	var count int
+	row := db.QueryRow(context.Background(), countQuery, voter[:])
+	err := row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Votes cast before indexing have an unknown option.
+	var option *string
+	row = db.QueryRow(
+		context.Background(), "SELECT vote_option FROM vote WHERE voter = $1 LIMIT 1", voter[:])
+	err = row.Scan(&option)
+	require.NoError(t, err)
+	assert.Nil(t, option)
+
+	block.BlockHeader.Round++
+	delta = ledgercore.StateDelta{}
+	delta.Accts.UpsertAppResource(
+		voter, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{Deleted: true})
+	addBlock(t, db, &block, delta)
+
+	row = db.QueryRow(context.Background(), countQuery, voter[:])
+	err = row.Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+type depositHistoryRow struct {
+	round       uint64
+	amount      string
+	depositLock *uint64
+}
+
+func depositHistoryQuery(t *testing.T, db *pgxpool.Pool, appID basics.AppIndex, addr basics.Address) []depositHistoryRow {
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT round, amount::text, deposit_lock FROM dao_deposit_history
+		WHERE app = $1 AND addr = $2 ORDER BY round`,
+		uint64(appID), addr[:])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var res []depositHistoryRow
+	for rows.Next() {
+		var row depositHistoryRow
+		err = rows.Scan(&row.round, &row.amount, &row.depositLock)
+		require.NoError(t, err)
+		res = append(res, row)
+	}
+	require.NoError(t, rows.Err())
+	return res
+}
+
+func TestWriterDepositTable(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	member := test.AccountC
+	var block bookkeeping.Block
+
+	writeLocalState := func(round basics.Round, localState ledgercore.AppLocalStateDelta) {
+		block.BlockHeader.Round = round
+		var delta ledgercore.StateDelta
+		delta.Accts.UpsertAppResource(member, appID, ledgercore.AppParamsDelta{}, localState)
+		addBlock(t, db, &block, delta)
+	}
+
+	// deposit_vote_token
+	writeLocalState(basics.Round(2), ledgercore.AppLocalStateDelta{
+		LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"deposit": {Type: basics.TealUintType, Uint: 15},
+			},
+		},
+	})
+	// register_vote locks the deposit.
+	writeLocalState(basics.Round(3), ledgercore.AppLocalStateDelta{
+		LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"deposit":      {Type: basics.TealUintType, Uint: 15},
+				"deposit_lock": {Type: basics.TealUintType, Uint: 1000},
+			},
+		},
+	})
+	// A change to other keys doesn't add history.
+	writeLocalState(basics.Round(4), ledgercore.AppLocalStateDelta{
+		LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"deposit":      {Type: basics.TealUintType, Uint: 15},
+				"deposit_lock": {Type: basics.TealUintType, Uint: 1000},
+				"other":        {Type: basics.TealUintType, Uint: 1},
+			},
+		},
+	})
+
+	var amount string
+	var depositLock uint64
+	var round uint64
+	var deleted bool
+	query := `SELECT amount::text, deposit_lock, round, deleted FROM dao_deposit
+		WHERE app = $1 AND addr = $2`
+	row := db.QueryRow(context.Background(), query, uint64(appID), member[:])
+	err := row.Scan(&amount, &depositLock, &round, &deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "15", amount)
+	assert.Equal(t, uint64(1000), depositLock)
+	assert.Equal(t, uint64(3), round)
+	assert.False(t, deleted)
+
+	// Clear state forfeits the deposit.
+	writeLocalState(basics.Round(5), ledgercore.AppLocalStateDelta{Deleted: true})
+
+	var depositLockPtr *uint64
+	row = db.QueryRow(context.Background(), query, uint64(appID), member[:])
+	err = row.Scan(&amount, &depositLockPtr, &round, &deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "0", amount)
+	assert.Nil(t, depositLockPtr)
+	assert.Equal(t, uint64(5), round)
+	assert.True(t, deleted)
+
+	lock := uint64(1000)
+	expected := []depositHistoryRow{
+		{round: 2, amount: "15"},
+		{round: 3, amount: "15", depositLock: &lock},
+		{round: 5, amount: "0"},
+	}
+	assert.Equal(t, expected, depositHistoryQuery(t, db, appID, member))
+}
+
+func TestWriterDAOTable(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, basics.TealKeyValue{
+		"dao_name":     {Type: basics.TealBytesType, Bytes: "my dao"},
+		"url":          {Type: basics.TealBytesType, Bytes: "\xff\xfe"}, // not utf8
+		"gov_token_id": {Type: basics.TealUintType, Uint: 12},
+		"deposit":      {Type: basics.TealUintType, Uint: 15},
+		"min_support":  {Type: basics.TealUintType, Uint: 5},
+		"min_duration": {Type: basics.TealUintType, Uint: 60},
+		"max_duration": {Type: basics.TealUintType, Uint: 600},
+	})
+
+	var creator []byte
+	var name, url string
+	var govTokenID uint64
+	var deposit, minSupport string
+	var minDuration, maxDuration uint64
+	var deleted bool
+
+	query := `SELECT creator, name, url, gov_token_id, deposit::text, min_support::text,
+		min_duration, max_duration, deleted FROM dao WHERE app = $1`
+	row := db.QueryRow(context.Background(), query, uint64(appID))
+	err := row.Scan(
+		&creator, &name, &url, &govTokenID, &deposit, &minSupport, &minDuration, &maxDuration,
+		&deleted)
+	require.NoError(t, err)
+
+	assert.Equal(t, test.AccountA[:], creator)
+	assert.Equal(t, "my dao", name)
+	assert.Equal(t, "", url)
+	assert.Equal(t, uint64(12), govTokenID)
+	assert.Equal(t, "15", deposit)
+	assert.Equal(t, "5", minSupport)
+	assert.Equal(t, uint64(60), minDuration)
+	assert.Equal(t, uint64(600), maxDuration)
+	assert.False(t, deleted)
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(2)
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Deleted: true},
+		ledgercore.AppLocalStateDelta{})
+	addBlock(t, db, &block, delta)
+
+	row = db.QueryRow(context.Background(), "SELECT deleted FROM dao WHERE app = $1", uint64(appID))
+	err = row.Scan(&deleted)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestWriterAddDAOApps(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	otherAppID := basics.AppIndex(4)
+	member := test.AccountC
+
+	var accountDeltas ledgercore.AccountDeltas
+	accountDeltas.UpsertAppResource(
+		test.AccountA, appID,
+		ledgercore.AppParamsDelta{Params: &basics.AppParams{ApprovalProgram: testDAOProgram}},
+		ledgercore.AppLocalStateDelta{})
+	accountDeltas.UpsertAppResource(
+		member, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"deposit": {Type: basics.TealUintType, Uint: 15},
+			},
+		}})
+	// Not a SigmaDAO app.
+	accountDeltas.UpsertAppResource(
+		test.AccountA, otherAppID,
+		ledgercore.AppParamsDelta{Params: &basics.AppParams{ApprovalProgram: []byte{6}}},
+		ledgercore.AppLocalStateDelta{})
+	accountDeltas.UpsertAppResource(
+		member, otherAppID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{}})
+
+	header := bookkeeping.BlockHeader{Round: basics.Round(7)}
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, testDAOPrograms, writer.MakeDAOAppCache(nil), nil, nil, nil)
+		require.NoError(t, err)
+
+		err = w.AddDAOApps(&header, &accountDeltas)
+		require.NoError(t, err)
+		assert.Equal(t, writer.DAOAppChanges{appID: true}, w.DAOAppChanges())
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	var version string
+	row := db.QueryRow(context.Background(), "SELECT version FROM app WHERE index = $1", uint64(appID))
+	err = row.Scan(&version)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", version)
+
+	// Only the SigmaDAO app and its local state are written.
+	for _, query := range []string{"SELECT COUNT(*) FROM dao", "SELECT COUNT(*) FROM account_app"} {
+		var count int
+		row = db.QueryRow(context.Background(), query)
+		err = row.Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, query)
+	}
+
+	var amount string
+	var round uint64
+	row = db.QueryRow(
+		context.Background(),
+		"SELECT amount::text, round FROM dao_deposit WHERE app = $1 AND addr = $2",
+		uint64(appID), member[:])
+	err = row.Scan(&amount, &round)
+	require.NoError(t, err)
+	assert.Equal(t, "15", amount)
+	assert.Equal(t, uint64(7), round)
+}
+
+// Deleting a SigmaDAO app closes its proposals, votes and deposits.
+func TestWriterDAODeletionCascade(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+	otherAppID := basics.AppIndex(4)
+	addDAOApp(t, db, basics.Round(1), otherAppID, nil)
+
+	proposal := test.AccountB
+	member := test.AccountC
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(2)
+	var delta ledgercore.StateDelta
+	proposalState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"id":   {Type: basics.TealUintType, Uint: 7},
+			"type": {Type: basics.TealUintType, Uint: 3},
+		},
+	}
+	memberState := basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"deposit":                  {Type: basics.TealUintType, Uint: 15},
+			"p_" + string(proposal[:]): {Type: basics.TealUintType, Uint: 7},
+		},
+	}
+	for _, app := range []basics.AppIndex{appID, otherAppID} {
+		delta.Accts.UpsertAppResource(
+			proposal, app, ledgercore.AppParamsDelta{},
+			ledgercore.AppLocalStateDelta{LocalState: &proposalState})
+		delta.Accts.UpsertAppResource(
+			member, app, ledgercore.AppParamsDelta{},
+			ledgercore.AppLocalStateDelta{LocalState: &memberState})
+	}
+	addBlock(t, db, &block, delta)
+
+	block.BlockHeader.Round++
+	delta = ledgercore.StateDelta{}
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Deleted: true},
+		ledgercore.AppLocalStateDelta{})
+	addBlock(t, db, &block, delta)
+
+	queries := []string{
+		"SELECT closed_round, deleted FROM dao WHERE app = $1",
+		"SELECT closed_round, deleted FROM proposal WHERE app = $1",
+		"SELECT closed_round, deleted FROM vote WHERE app = $1",
+		"SELECT closed_round, deleted FROM dao_deposit WHERE app = $1",
+	}
+	for _, query := range queries {
+		var closedRound *uint64
+		var deleted bool
+
+		row := db.QueryRow(context.Background(), query, uint64(appID))
+		err := row.Scan(&closedRound, &deleted)
+		require.NoError(t, err, query)
+		require.NotNil(t, closedRound, query)
+		assert.Equal(t, uint64(3), *closedRound, query)
+		assert.True(t, deleted, query)
+
+		// The other dao is unaffected.
+		row = db.QueryRow(context.Background(), query, uint64(otherAppID))
+		err = row.Scan(&closedRound, &deleted)
+		require.NoError(t, err, query)
+		assert.Nil(t, closedRound, query)
+		assert.False(t, deleted, query)
+	}
+
+	// The deposit amount is kept, without a history row for the deletion.
+	var amount string
+	row := db.QueryRow(
+		context.Background(), "SELECT amount::text FROM dao_deposit WHERE app = $1",
+		uint64(appID))
+	err := row.Scan(&amount)
+	require.NoError(t, err)
+	assert.Equal(t, "15", amount)
+	assert.Equal(
+		t, []depositHistoryRow{{round: 2, amount: "15"}},
+		depositHistoryQuery(t, db, appID, member))
+}
+
+func TestWriterProposalStatus(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, basics.TealKeyValue{
+		"min_support": {Type: basics.TealUintType, Uint: 5},
+	})
+
+	proposalState := func(yes, no, executed uint64) *basics.AppLocalState {
+		return &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"type":           {Type: basics.TealUintType, Uint: 3},
+				"voting_start":   {Type: basics.TealUintType, Uint: 100},
+				"voting_end":     {Type: basics.TealUintType, Uint: 200},
+				"execute_before": {Type: basics.TealUintType, Uint: 300},
+				"yes":            {Type: basics.TealUintType, Uint: yes},
+				"no":             {Type: basics.TealUintType, Uint: no},
+				"executed":       {Type: basics.TealUintType, Uint: executed},
+			},
+		}
+	}
+
+	round := basics.Round(2)
+	// Writes a block with timestamp `timestamp` and optionally a new proposal state.
+	var events []idb.DAOEvent
+	addBlockAt := func(timestamp int64, localState *basics.AppLocalState) {
+		var block bookkeeping.Block
+		block.BlockHeader.Round = round
+		block.BlockHeader.TimeStamp = timestamp
+		round++
+
+		var delta ledgercore.StateDelta
+		if localState != nil {
+			delta.Accts.UpsertAppResource(
+				test.AccountB, appID, ledgercore.AppParamsDelta{},
+				ledgercore.AppLocalStateDelta{LocalState: localState})
+		}
+		events = addBlock(t, db, &block, delta)
+	}
+	status := func() idb.ProposalStatus {
+		var res string
+		row := db.QueryRow(
+			context.Background(), "SELECT status FROM proposal WHERE app = $1 AND addr = $2",
+			uint64(appID), test.AccountB[:])
+		err := row.Scan(&res)
+		require.NoError(t, err)
+		return idb.ProposalStatus(res)
+	}
+
+	addBlockAt(50, proposalState(0, 0, 0))
+	assert.Equal(t, idb.ProposalPending, status())
+
+	// Voting starts without any transaction.
+	addBlockAt(150, nil)
+	assert.Equal(t, idb.ProposalVoting, status())
+	proposal := test.AccountB
+	expected := idb.DAOEvent{
+		Kind:     idb.DAOEventStatusChanged,
+		Round:    3,
+		AppID:    uint64(appID),
+		Proposal: &proposal,
+		Status:   idb.ProposalVoting,
+	}
+	assert.Equal(t, []idb.DAOEvent{expected}, events)
+
+	// No status change, no event.
+	addBlockAt(160, proposalState(10, 2, 0))
+	assert.Equal(t, idb.ProposalVoting, status())
+	assert.Empty(t, events)
+
+	addBlockAt(250, nil)
+	assert.Equal(t, idb.ProposalPassed, status())
+
+	addBlockAt(350, nil)
+	assert.Equal(t, idb.ProposalExpired, status())
+
+	// Expired is final.
+	addBlockAt(360, nil)
+	assert.Equal(t, idb.ProposalExpired, status())
+
+	// The proposal account is reused for a new proposal that gets executed.
+	addBlockAt(50, proposalState(10, 2, 0))
+	assert.Equal(t, idb.ProposalPending, status())
+	addBlockAt(260, proposalState(10, 2, 1))
+	assert.Equal(t, idb.ProposalExecuted, status())
+
+	// Not enough yes votes.
+	addBlockAt(250, proposalState(4, 0, 0))
+	assert.Equal(t, idb.ProposalRejected, status())
+
+	// More no than yes votes.
+	addBlockAt(250, proposalState(6, 6, 0))
+	assert.Equal(t, idb.ProposalRejected, status())
+
+	// Every change is in the history.
+	rows, err := db.Query(
+		context.Background(),
+		"SELECT round, status FROM proposal_status_history WHERE app = $1 AND addr = $2 "+
+			"ORDER BY round", uint64(appID), test.AccountB[:])
+	require.NoError(t, err)
+	defer rows.Close()
+	var history []string
+	for rows.Next() {
+		var round uint64
+		var status string
+		require.NoError(t, rows.Scan(&round, &status))
+		history = append(history, fmt.Sprintf("%d:%s", round, status))
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{
+		"2:pending", "3:voting", "5:passed", "6:expired", "8:pending", "9:executed",
+		"10:rejected"}, history)
+}
+
+func TestWriterProposalHolderSnapshot(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	govTokenID := basics.AssetIndex(9)
+	addDAOApp(t, db, basics.Round(1), appID, basics.TealKeyValue{
+		"gov_token_id": {Type: basics.TealUintType, Uint: uint64(govTokenID)},
+	})
+
+	round := basics.Round(2)
+	// Writes a block with timestamp `timestamp` and the given governance token balances.
+	addBlockAt := func(timestamp int64, localState *basics.AppLocalState, balances map[basics.Address]uint64) {
+		var block bookkeeping.Block
+		block.BlockHeader.Round = round
+		block.BlockHeader.TimeStamp = timestamp
+		round++
+
+		var delta ledgercore.StateDelta
+		if localState != nil {
+			delta.Accts.UpsertAppResource(
+				test.AccountB, appID, ledgercore.AppParamsDelta{},
+				ledgercore.AppLocalStateDelta{LocalState: localState})
+		}
+		for addr, amount := range balances {
+			delta.Accts.UpsertAssetResource(
+				addr, govTokenID, ledgercore.AssetParamsDelta{},
+				ledgercore.AssetHoldingDelta{Holding: &basics.AssetHolding{Amount: amount}})
+		}
+		addBlock(t, db, &block, delta)
+	}
+	snapshot := func() map[basics.Address]uint64 {
+		rows, err := db.Query(
+			context.Background(),
+			`SELECT holder, amount FROM proposal_holder_snapshot
+			WHERE app = $1 AND proposal = $2 AND round = 3`,
+			uint64(appID), test.AccountB[:])
+		require.NoError(t, err)
+		defer rows.Close()
+
+		res := make(map[basics.Address]uint64)
+		for rows.Next() {
+			var holder []byte
+			var amount uint64
+			require.NoError(t, rows.Scan(&holder, &amount))
+			var addr basics.Address
+			copy(addr[:], holder)
+			res[addr] = amount
+		}
+		require.NoError(t, rows.Err())
+		return res
+	}
+
+	proposalState := &basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"type":         {Type: basics.TealUintType, Uint: 3},
+			"voting_start": {Type: basics.TealUintType, Uint: 100},
+			"voting_end":   {Type: basics.TealUintType, Uint: 200},
+		},
+	}
+	addBlockAt(50, proposalState, map[basics.Address]uint64{test.AccountC: 10})
+	assert.Empty(t, snapshot())
+
+	// Voting starts, balances of this block are included. Empty holdings are not.
+	addBlockAt(150, nil, map[basics.Address]uint64{test.AccountD: 20, test.AccountE: 0})
+	expected := map[basics.Address]uint64{test.AccountC: 10, test.AccountD: 20}
+	assert.Equal(t, expected, snapshot())
+
+	// Later transfers do not change the snapshot.
+	addBlockAt(160, nil, map[basics.Address]uint64{test.AccountC: 0, test.AccountE: 5})
+	assert.Equal(t, expected, snapshot())
+}
+
+// With the DAORelevance policy, the snapshot includes the holdings of relevant
+// accounts that did not change since they became relevant.
+func TestWriterProposalHolderSnapshotDAORelevance(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	govTokenID := basics.AssetIndex(9)
+	relevance := writer.MakeDAORelevance(nil, nil)
+	ledger := makeTestLedgerState()
+	// A creates the DAO app and holds most of the token, C votes with a holding that
+	// never moves and D holds the token without using the DAO.
+	ledger.setHolding(test.AccountA, govTokenID, 40)
+	ledger.setHolding(test.AccountC, govTokenID, 10)
+	ledger.setHolding(test.AccountD, govTokenID, 20)
+
+	round := basics.Round(1)
+	addBlockAt := func(timestamp int64, delta ledgercore.StateDelta) {
+		var block bookkeeping.Block
+		block.BlockHeader.Round = round
+		block.BlockHeader.TimeStamp = timestamp
+		round++
+		addBlockWithLedger(t, db, &block, delta, relevance, ledger)
+	}
+
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &basics.AppParams{
+			ApprovalProgram: testDAOProgram,
+			GlobalState: basics.TealKeyValue{
+				"gov_token_id": {Type: basics.TealUintType, Uint: uint64(govTokenID)},
+			},
+		}},
+		ledgercore.AppLocalStateDelta{})
+	addBlockAt(10, delta)
+
+	delta = ledgercore.StateDelta{}
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"type":         {Type: basics.TealUintType, Uint: 3},
+				"voting_start": {Type: basics.TealUintType, Uint: 100},
+				"voting_end":   {Type: basics.TealUintType, Uint: 200},
+			},
+		}})
+	delta.Accts.UpsertAppResource(
+		test.AccountC, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{}})
+	addBlockAt(50, delta)
+
+	// Voting starts.
+	addBlockAt(150, ledgercore.StateDelta{})
+
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT holder, amount FROM proposal_holder_snapshot WHERE app = $1 AND proposal = $2`,
+		uint64(appID), test.AccountB[:])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	snapshot := make(map[basics.Address]uint64)
+	for rows.Next() {
+		var holder []byte
+		var amount uint64
+		require.NoError(t, rows.Scan(&holder, &amount))
+		var addr basics.Address
+		copy(addr[:], holder)
+		snapshot[addr] = amount
+	}
+	require.NoError(t, rows.Err())
+	// D is not found, the ledger cannot list the holders of an asset.
+	expected := map[basics.Address]uint64{test.AccountA: 40, test.AccountC: 10}
+	assert.Equal(t, expected, snapshot)
+}
+
+func TestWriterTreasury(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	treasury := test.AccountC
+	assetID := basics.AssetIndex(9)
+	round := basics.Round(2)
+	// Writes a block with the given treasury balances.
+	addBlockWith := func(localState *basics.AppLocalState, microalgos uint64, assetAmount *uint64) {
+		var block bookkeeping.Block
+		block.BlockHeader.Round = round
+		round++
+
+		var delta ledgercore.StateDelta
+		if localState != nil {
+			delta.Accts.UpsertAppResource(
+				test.AccountB, appID, ledgercore.AppParamsDelta{},
+				ledgercore.AppLocalStateDelta{LocalState: localState})
+		}
+		delta.Accts.Upsert(treasury, ledgercore.AccountData{
+			AccountBaseData: ledgercore.AccountBaseData{
+				MicroAlgos: basics.MicroAlgos{Raw: microalgos},
+			},
+		})
+		if assetAmount != nil {
+			delta.Accts.UpsertAssetResource(
+				treasury, assetID, ledgercore.AssetParamsDelta{},
+				ledgercore.AssetHoldingDelta{Holding: &basics.AssetHolding{Amount: *assetAmount}})
+		}
+		addBlock(t, db, &block, delta)
+	}
+	amount := func(v uint64) *uint64 {
+		return &v
+	}
+
+	// Balances before the treasury is known are not tracked.
+	addBlockWith(nil, 1000, amount(50))
+
+	// The first proposal with `from` starts tracking the treasury.
+	proposalState := &basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"type": {Type: basics.TealUintType, Uint: 1},
+			"from": {Type: basics.TealBytesType, Bytes: string(treasury[:])},
+		},
+	}
+	addBlockWith(proposalState, 900, nil)
+
+	// Outflow of ALGO, inflow of the asset.
+	addBlockWith(nil, 600, amount(80))
+
+	// No change.
+	addBlockWith(nil, 600, nil)
+
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT asset, round, amount::text, change::text FROM dao_treasury_history
+		WHERE app = $1 AND addr = $2 ORDER BY round, asset`,
+		uint64(appID), treasury[:])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type historyRow struct {
+		asset  uint64
+		round  uint64
+		amount string
+		change *string
+	}
+	var history []historyRow
+	for rows.Next() {
+		var row historyRow
+		require.NoError(t, rows.Scan(&row.asset, &row.round, &row.amount, &row.change))
+		history = append(history, row)
+	}
+	require.NoError(t, rows.Err())
+
+	change := func(v string) *string {
+		return &v
+	}
+	expected := []historyRow{
+		{asset: 0, round: 3, amount: "900"},
+		{asset: 9, round: 3, amount: "50"},
+		{asset: 0, round: 4, amount: "600", change: change("-300")},
+		{asset: 9, round: 4, amount: "80", change: change("30")},
+	}
+	assert.Equal(t, expected, history)
+
+	var balance string
+	row := db.QueryRow(
+		context.Background(),
+		"SELECT amount::text FROM dao_treasury WHERE app = $1 AND addr = $2 AND asset = 0",
+		uint64(appID), treasury[:])
+	require.NoError(t, row.Scan(&balance))
+	assert.Equal(t, "600", balance)
+}
+
+// With the DAORelevance policy, the opening balances of a treasury are read from the
+// ledger, its account is not in the database yet.
+func TestWriterTreasuryDAORelevance(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	relevance := writer.MakeDAORelevance(nil, nil)
+	ledger := makeTestLedgerState()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+	var delta ledgercore.StateDelta
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID,
+		ledgercore.AppParamsDelta{Params: &basics.AppParams{ApprovalProgram: testDAOProgram}},
+		ledgercore.AppLocalStateDelta{})
+	addBlockWithLedger(t, db, &block, delta, relevance, ledger)
+
+	// The treasury got its balances before the DAO existed. The block changes one
+	// asset and closes another.
+	treasury := test.AccountC
+	ledger.accounts[treasury] = ledgercore.AccountData{
+		AccountBaseData: ledgercore.AccountBaseData{MicroAlgos: basics.MicroAlgos{Raw: 1000}},
+	}
+	ledger.setHolding(treasury, 9, 50)
+	ledger.setHolding(treasury, 10, 70)
+	ledger.setHolding(treasury, 11, 5)
+
+	block.BlockHeader.Round = basics.Round(2)
+	delta = ledgercore.StateDelta{}
+	delta.Accts.UpsertAppResource(
+		test.AccountB, appID, ledgercore.AppParamsDelta{},
+		ledgercore.AppLocalStateDelta{LocalState: &basics.AppLocalState{
+			KeyValue: basics.TealKeyValue{
+				"type": {Type: basics.TealUintType, Uint: 1},
+				"from": {Type: basics.TealBytesType, Bytes: string(treasury[:])},
+			},
+		}})
+	delta.Accts.UpsertAssetResource(
+		treasury, 10, ledgercore.AssetParamsDelta{},
+		ledgercore.AssetHoldingDelta{Holding: &basics.AssetHolding{Amount: 60}})
+	delta.Accts.UpsertAssetResource(
+		treasury, 11, ledgercore.AssetParamsDelta{},
+		ledgercore.AssetHoldingDelta{Deleted: true})
+	addBlockWithLedger(t, db, &block, delta, relevance, ledger)
+
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT asset, amount::text FROM dao_treasury_history
+		WHERE app = $1 AND addr = $2 AND round = 2 AND change IS NULL`,
+		uint64(appID), treasury[:])
+	require.NoError(t, err)
+	defer rows.Close()
+
+	balances := make(map[uint64]string)
+	for rows.Next() {
+		var asset uint64
+		var amount string
+		require.NoError(t, rows.Scan(&asset, &amount))
+		balances[asset] = amount
+	}
+	require.NoError(t, rows.Err())
+	expected := map[uint64]string{0: "1000", 9: "50", 10: "60"}
+	assert.Equal(t, expected, balances)
+}
+
+func TestWriterExecutionAudit(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	treasury := test.AccountC
+	recipient := test.AccountD
+	proposalState := &basics.AppLocalState{
+		KeyValue: basics.TealKeyValue{
+			"type":      {Type: basics.TealUintType, Uint: 1},
+			"from":      {Type: basics.TealBytesType, Bytes: string(treasury[:])},
+			"recipient": {Type: basics.TealBytesType, Bytes: string(recipient[:])},
+			"amount":    {Type: basics.TealUintType, Uint: 100},
+			"executed":  {Type: basics.TealUintType, Uint: 1},
+		},
+	}
+
+	// Makes an execute group for `proposal` paying `amount` to the recipient.
+	makeGroup := func(proposal basics.Address, amount uint64, group byte) []*transactions.SignedTxnWithAD {
+		execute := test.MakeAppCallTxn(uint64(appID), test.AccountA)
+		execute.Txn.ApplicationArgs = [][]byte{[]byte("execute")}
+		execute.Txn.Accounts = []basics.Address{proposal}
+		payment := test.MakePaymentTxn(
+			0, amount, 0, 0, 0, 0, treasury, recipient, basics.Address{}, basics.Address{})
+		execute.Txn.Group = crypto.Digest{group}
+		payment.Txn.Group = crypto.Digest{group}
+		return []*transactions.SignedTxnWithAD{&execute, &payment}
+	}
+	matching := test.AccountB
+	mismatching := test.AccountE
+	txns := append(makeGroup(matching, 100, 1), makeGroup(mismatching, 90, 2)...)
+	header := test.MakeGenesisBlock().BlockHeader
+	header.Round = basics.Round(1)
+	block, err := test.MakeBlockForTxns(header, txns...)
+	require.NoError(t, err)
+
+	var delta ledgercore.StateDelta
+	for _, proposal := range []basics.Address{matching, mismatching} {
+		delta.Accts.UpsertAppResource(
+			proposal, appID, ledgercore.AppParamsDelta{},
+			ledgercore.AppLocalStateDelta{LocalState: proposalState})
+	}
+
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, testDAOPrograms, loadDAOApps(t, tx), nil, nil, nil)
+		require.NoError(t, err)
+		defer w.Close()
+
+		err = w.AddBlock(&block, block.Payset, delta)
+		require.NoError(t, err)
+
+		audits := w.ExecutionAudits()
+		require.Len(t, audits, 2)
+		assert.Equal(t, matching, audits[0].Proposal)
+		assert.Empty(t, audits[0].Mismatch)
+		assert.Equal(t, mismatching, audits[1].Proposal)
+		assert.NotEmpty(t, audits[1].Mismatch)
+		return nil
+	}
+	err = pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	query := `SELECT execution_round, execution_result, execution_details FROM proposal
+		WHERE app = $1 AND addr = $2`
+	var round uint64
+	var result string
+	var details *string
+
+	row := db.QueryRow(context.Background(), query, uint64(appID), matching[:])
+	require.NoError(t, row.Scan(&round, &result, &details))
+	assert.Equal(t, uint64(block.Round()), round)
+	assert.Equal(t, string(idb.ExecutionMatch), result)
+	assert.Nil(t, details)
+
+	row = db.QueryRow(context.Background(), query, uint64(appID), mismatching[:])
+	require.NoError(t, row.Scan(&round, &result, &details))
+	assert.Equal(t, string(idb.ExecutionMismatch), result)
+	require.NotNil(t, details)
+	assert.Contains(t, *details, "expected 100 microAlgos")
+	assert.Contains(t, *details, "found 90 microAlgos")
+}
+
+// Only SigmaDAO related accounts, assets and asset holdings are written with the
+// DAORelevance policy.
+func TestWriterDAORelevance(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	appID := basics.AppIndex(3)
+	govTokenID := basics.AssetIndex(4)
+	otherAssetID := basics.AssetIndex(5)
+	appParams := basics.AppParams{
+		ApprovalProgram: testDAOProgram,
+		GlobalState: basics.TealKeyValue{
+			"gov_token_id": {Type: basics.TealUintType, Uint: uint64(govTokenID)},
+		},
+	}
+	assetParams := basics.AssetParams{UnitName: "gov"}
+	holding := basics.AssetHolding{Amount: 1}
+	accountData := ledgercore.AccountData{
+		AccountBaseData: ledgercore.AccountBaseData{MicroAlgos: basics.MicroAlgos{Raw: 1}},
+	}
+
+	var delta ledgercore.StateDelta
+	// A creates the DAO app, B creates the governance token, C holds it and D holds an
+	// unrelated asset.
+	delta.Accts.Upsert(test.AccountA, accountData)
+	delta.Accts.Upsert(test.AccountB, accountData)
+	delta.Accts.Upsert(test.AccountC, accountData)
+	delta.Accts.Upsert(test.AccountD, accountData)
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &appParams},
+		ledgercore.AppLocalStateDelta{})
+	delta.Accts.UpsertAssetResource(
+		test.AccountB, govTokenID, ledgercore.AssetParamsDelta{Params: &assetParams},
+		ledgercore.AssetHoldingDelta{})
+	delta.Accts.UpsertAssetResource(
+		test.AccountC, govTokenID, ledgercore.AssetParamsDelta{},
+		ledgercore.AssetHoldingDelta{Holding: &holding})
+	delta.Accts.UpsertAssetResource(
+		test.AccountD, otherAssetID, ledgercore.AssetParamsDelta{Params: &assetParams},
+		ledgercore.AssetHoldingDelta{Holding: &holding})
+
+	relevance := writer.MakeDAORelevance(nil, nil)
+	f := func(tx pgx.Tx) error {
+		w, err := writer.MakeWriter(tx, testDAOPrograms, nil, relevance, nil, nil)
+		require.NoError(t, err)
+
+		err = w.AddBlock(&block, block.Payset, delta)
+		require.NoError(t, err)
+
+		changes := w.RelevanceChanges()
+		assert.Contains(t, changes.Accounts, test.AccountA)
+		assert.Contains(t, changes.Accounts, appID.Address())
+		assert.Contains(t, changes.Accounts, test.AccountC)
+		assert.Equal(
+			t, map[basics.AssetIndex]struct{}{govTokenID: {}}, changes.Assets)
+
+		w.Close()
+		return nil
+	}
+	err := pgutil.TxWithRetry(db, serializable, f, nil)
+	require.NoError(t, err)
+
+	queryAddrs := func(query string) [][]byte {
+		rows, err := db.Query(context.Background(), query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var res [][]byte
+		for rows.Next() {
+			var addr []byte
+			require.NoError(t, rows.Scan(&addr))
+			res = append(res, addr)
+		}
+		require.NoError(t, rows.Err())
+		return res
+	}
+	assert.ElementsMatch(
+		t, [][]byte{test.AccountA[:], test.AccountC[:]},
+		queryAddrs("SELECT addr FROM account"))
+	assert.ElementsMatch(
+		t, [][]byte{test.AccountC[:]}, queryAddrs("SELECT addr FROM account_asset"))
+	assert.ElementsMatch(
+		t, [][]byte{test.AccountB[:]}, queryAddrs("SELECT creator_addr FROM asset"))
+}
+
+// The state of the accounts and assets that become relevant is read from the ledger
+// when the block does not change it.
+func TestWriterDAORelevanceLedgerState(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	var block bookkeeping.Block
+	block.BlockHeader.Round = basics.Round(1)
+
+	appID := basics.AppIndex(3)
+	govTokenID := basics.AssetIndex(4)
+	otherAssetID := basics.AssetIndex(5)
+	appParams := basics.AppParams{
+		ApprovalProgram: testDAOProgram,
+		GlobalState: basics.TealKeyValue{
+			"gov_token_id": {Type: basics.TealUintType, Uint: uint64(govTokenID)},
+		},
+	}
+
+	// B created the governance token before the block. A, D and E hold it, D and E
+	// are already relevant and A creates the DAO app in the block. C holds it too but
+	// is not relevant. The block changes E but not D.
+	ledger := makeTestLedgerState()
+	ledger.creators[govTokenID] = test.AccountB
+	ledger.params[govTokenID] = basics.AssetParams{UnitName: "gov"}
+	ledger.accounts[appID.Address()] = ledgercore.AccountData{
+		AccountBaseData: ledgercore.AccountBaseData{MicroAlgos: basics.MicroAlgos{Raw: 7}},
+	}
+	ledger.setHolding(test.AccountA, govTokenID, 2)
+	ledger.setHolding(test.AccountA, otherAssetID, 3)
+	ledger.setHolding(test.AccountC, govTokenID, 4)
+	ledger.setHolding(test.AccountD, govTokenID, 5)
+	ledger.setHolding(test.AccountE, govTokenID, 6)
+
+	var delta ledgercore.StateDelta
+	delta.Accts.Upsert(test.AccountA, ledgercore.AccountData{
+		AccountBaseData: ledgercore.AccountBaseData{MicroAlgos: basics.MicroAlgos{Raw: 1}},
+	})
+	delta.Accts.Upsert(test.AccountE, ledgercore.AccountData{
+		AccountBaseData: ledgercore.AccountBaseData{MicroAlgos: basics.MicroAlgos{Raw: 8}},
+	})
+	delta.Accts.UpsertAppResource(
+		test.AccountA, appID, ledgercore.AppParamsDelta{Params: &appParams},
+		ledgercore.AppLocalStateDelta{})
+
+	relevance := writer.MakeDAORelevance(
+		[]basics.Address{test.AccountD, test.AccountE}, nil)
+	addBlockWithLedger(t, db, &block, delta, relevance, ledger)
+
+	rows, err := db.Query(context.Background(), "SELECT addr, microalgos FROM account")
+	require.NoError(t, err)
+	accounts := make(map[basics.Address]uint64)
+	for rows.Next() {
+		var addr []byte
+		var microalgos uint64
+		require.NoError(t, rows.Scan(&addr, &microalgos))
+		var address basics.Address
+		copy(address[:], addr)
+		accounts[address] = microalgos
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	expectedAccounts := map[basics.Address]uint64{
+		test.AccountA: 1, test.AccountE: 8, appID.Address(): 7,
+	}
+	assert.Equal(t, expectedAccounts, accounts)
+
+	rows, err = db.Query(
+		context.Background(), "SELECT addr, assetid, amount FROM account_asset")
+	require.NoError(t, err)
+	type holding struct {
+		addr    basics.Address
+		assetID uint64
+		amount  uint64
+	}
+	var holdings []holding
+	for rows.Next() {
+		var h holding
+		var addr []byte
+		require.NoError(t, rows.Scan(&addr, &h.assetID, &h.amount))
+		copy(h.addr[:], addr)
+		holdings = append(holdings, h)
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	// D is written once its holding changes.
+	assert.ElementsMatch(t, []holding{
+		{addr: test.AccountA, assetID: uint64(govTokenID), amount: 2},
+		{addr: test.AccountE, assetID: uint64(govTokenID), amount: 6},
+	}, holdings)
+
+	var creator []byte
+	var params []byte
+	row := db.QueryRow(
+		context.Background(), "SELECT creator_addr, params FROM asset WHERE index = $1",
+		uint64(govTokenID))
+	require.NoError(t, row.Scan(&creator, &params))
+	assert.Equal(t, test.AccountB[:], creator)
+	paramsRead, err := encoding.DecodeAssetParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, ledger.params[govTokenID], paramsRead)
+}
+
+// Only transaction groups calling a SigmaDAO app are written to `dao_txn`, with the
+// same intra round offsets as if every transaction was written.
+func TestAddDAOTransactions(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	daoAppID := uint64(3)
+	otherPay := test.MakePaymentTxn(
+		0, 1, 0, 0, 0, 0, test.AccountA, test.AccountB, basics.Address{}, basics.Address{})
+	groupPay := test.MakePaymentTxn(
+		0, 2, 0, 0, 0, 0, test.AccountC, test.AccountD, basics.Address{}, basics.Address{})
+	groupCall := test.MakeAppCallTxn(daoAppID, test.AccountC)
+	groupCall.ApplyData.EvalDelta.InnerTxns = []transactions.SignedTxnWithAD{
+		test.MakePaymentTxn(
+			0, 3, 0, 0, 0, 0, basics.AppIndex(daoAppID).Address(), test.AccountE, basics.Address{},
+			basics.Address{}),
+	}
+	groupPay.Txn.Group = crypto.Digest{1}
+	groupCall.Txn.Group = crypto.Digest{1}
+	otherCall := test.MakeAppCallTxn(7, test.AccountA)
+
+	block, err := test.MakeBlockForTxns(
+		test.MakeGenesisBlock().BlockHeader, &otherPay, &groupPay, &groupCall, &otherCall)
+	require.NoError(t, err)
+
+	daoApps := writer.MakeDAOAppCache([]basics.AppIndex{basics.AppIndex(daoAppID)})
+	f := func(tx pgx.Tx) error {
+		return writer.AddDAOTransactions(&block, block.Payset, testDAOPrograms, daoApps, tx)
+	}
+	err = makeTx(db, f)
+	require.NoError(t, err)
+
+	txns, err := txnQuery(
+		db, "SELECT round, intra, typeenum, asset, txid, txn, extra FROM dao_txn ORDER BY intra")
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+
+	assert.Equal(t, 1, txns[0].intra)
+	assert.Equal(t, idb.TypeEnumPay, txns[0].typeenum)
+	assert.Equal(t, groupPay.Txn.ID().String(), txns[0].txid)
+
+	assert.Equal(t, 2, txns[1].intra)
+	assert.Equal(t, idb.TypeEnumApplication, txns[1].typeenum)
+	assert.Equal(t, int(daoAppID), txns[1].asset)
+
+	// The inner payment points to its root transaction.
+	assert.Equal(t, 3, txns[2].intra)
+	assert.Equal(t, "", txns[2].txid)
+	extra, err := encoding.DecodeTxnExtra([]byte(txns[2].extra))
+	require.NoError(t, err)
+	assert.Equal(t, idb.OptionalUint{Present: true, Value: 2}, extra.RootIntra)
+	assert.Equal(t, groupCall.Txn.ID().String(), extra.RootTxid)
+
+	participation, err := txnParticipationQuery(
+		db, "SELECT addr, round, intra FROM dao_txn_participation ORDER BY intra, addr")
+	require.NoError(t, err)
+	var addrs []basics.Address
+	for _, row := range participation {
+		addrs = append(addrs, row.addr)
+	}
+	assert.NotContains(t, addrs, test.AccountA)
+	assert.NotContains(t, addrs, test.AccountB)
+	assert.Contains(t, addrs, test.AccountC)
+	assert.Contains(t, addrs, test.AccountD)
+	assert.Contains(t, addrs, test.AccountE)
+}
+
+// A SigmaDAO app created in a block is recognised by its creation transaction.
+func TestAddDAOTransactionsAppCreatedSameBlock(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	createApp := test.MakeCreateAppTxn(test.AccountA)
+	createApp.Txn.ApprovalProgram = testDAOProgram
+	createApp.ApplyData.ApplicationID = 5
+	callApp := test.MakeAppCallTxn(5, test.AccountB)
+
+	block, err := test.MakeBlockForTxns(
+		test.MakeGenesisBlock().BlockHeader, &createApp, &callApp)
+	require.NoError(t, err)
+
+	f := func(tx pgx.Tx) error {
+		return writer.AddDAOTransactions(&block, block.Payset, testDAOPrograms, nil, tx)
+	}
+	err = makeTx(db, f)
+	require.NoError(t, err)
+
+	txns, err := txnQuery(
+		db, "SELECT round, intra, typeenum, asset, txid, txn, extra FROM dao_txn ORDER BY intra")
+	require.NoError(t, err)
+	require.Len(t, txns, 2)
+	assert.Equal(t, 5, txns[0].asset)
+	assert.Equal(t, 5, txns[1].asset)
+}
+
+type daoEventRow struct {
+	intra    int
+	app      uint64
+	txid     string
+	sender   []byte
+	proposal []byte
+	method   string
+	args     idb.DAOEventArgs
+}
+
+func daoEventQuery(t *testing.T, db *pgxpool.Pool) []daoEventRow {
+	rows, err := db.Query(
+		context.Background(),
+		"SELECT intra, app, txid, sender, proposal, method, args FROM dao_event ORDER BY intra")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var res []daoEventRow
+	for rows.Next() {
+		var row daoEventRow
+		var txid []byte
+		var args []byte
+		err = rows.Scan(
+			&row.intra, &row.app, &txid, &row.sender, &row.proposal, &row.method, &args)
+		require.NoError(t, err)
+		row.txid = string(txid)
+		row.args, err = encoding.DecodeDAOEventArgs(args)
+		require.NoError(t, err)
+		res = append(res, row)
+	}
+	require.NoError(t, rows.Err())
+
+	return res
+}
+
+func TestWriterDAOEvents(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	proposal := test.AccountB
+	voter := test.AccountC
+
+	depositXfer := test.MakeAssetTransferTxn(9, 10, voter, appID.Address(), basics.Address{})
+	deposit := test.MakeAppCallTxn(uint64(appID), voter)
+	deposit.Txn.ApplicationArgs = [][]byte{[]byte("deposit_vote_token")}
+	depositXfer.Txn.Group = crypto.Digest{1}
+	deposit.Txn.Group = crypto.Digest{1}
+
+	registerVote := test.MakeAppCallTxn(uint64(appID), voter)
+	registerVote.Txn.ApplicationArgs = [][]byte{[]byte("register_vote"), []byte("yes")}
+	registerVote.Txn.Accounts = []basics.Address{proposal}
+
+	// Another app withdrawing its deposit through an inner transaction.
+	otherApp := basics.AppIndex(7)
+	withdraw := test.MakeAppCallTxn(uint64(appID), otherApp.Address())
+	withdraw.Txn.ApplicationArgs = [][]byte{[]byte("withdraw_vote_deposit"), {0, 5}}
+	otherCall := test.MakeAppCallTxn(uint64(otherApp), voter)
+	otherCall.ApplyData.EvalDelta.InnerTxns = []transactions.SignedTxnWithAD{withdraw}
+
+	// Same method name, but not a SigmaDAO app.
+	nonDAOCall := test.MakeAppCallTxn(uint64(otherApp), voter)
+	nonDAOCall.Txn.ApplicationArgs = [][]byte{[]byte("register_vote"), []byte("no")}
+
+	header := test.MakeGenesisBlock().BlockHeader
+	header.Round = basics.Round(1)
+	block, err := test.MakeBlockForTxns(
+		header, &depositXfer, &deposit, &registerVote, &otherCall, &nonDAOCall)
+	require.NoError(t, err)
+
+	streamed := addBlock(t, db, &block, ledgercore.StateDelta{})
+
+	events := daoEventQuery(t, db)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, 1, events[0].intra)
+	assert.Equal(t, uint64(appID), events[0].app)
+	assert.Equal(t, deposit.Txn.ID().String(), events[0].txid)
+	assert.Equal(t, voter[:], events[0].sender)
+	assert.Nil(t, events[0].proposal)
+	assert.Equal(t, "deposit_vote_token", events[0].method)
+	require.NotNil(t, events[0].args.Amount)
+	assert.Equal(t, uint64(10), *events[0].args.Amount)
+
+	assert.Equal(t, 2, events[1].intra)
+	assert.Equal(t, registerVote.Txn.ID().String(), events[1].txid)
+	assert.Equal(t, proposal[:], events[1].proposal)
+	assert.Equal(t, "register_vote", events[1].method)
+	require.NotNil(t, events[1].args.VoteOption)
+	assert.Equal(t, "yes", *events[1].args.VoteOption)
+
+	// The inner call is recorded with the id of its root transaction.
+	assert.Equal(t, 4, events[2].intra)
+	assert.Equal(t, otherCall.Txn.ID().String(), events[2].txid)
+	otherAppAddr := otherApp.Address()
+	assert.Equal(t, otherAppAddr[:], events[2].sender)
+	assert.Equal(t, "withdraw_vote_deposit", events[2].method)
+	require.NotNil(t, events[2].args.Amount)
+	assert.Equal(t, uint64(5), *events[2].args.Amount)
+
+	// The same events are streamed.
+	require.Len(t, streamed, 3)
+	kinds := []idb.DAOEventKind{
+		idb.DAOEventDepositChanged, idb.DAOEventVoteRegistered, idb.DAOEventDepositChanged}
+	for i, event := range streamed {
+		assert.Equal(t, kinds[i], event.Kind)
+		assert.Equal(t, uint64(1), event.Round)
+		assert.Equal(t, uint64(appID), event.AppID)
+		assert.Equal(t, uint(events[i].intra), event.Intra)
+		assert.Equal(t, events[i].txid, event.Txid)
+		assert.Equal(t, events[i].sender, event.Sender[:])
+		assert.Equal(t, idb.DAOMethod(events[i].method), event.Method)
+		assert.Equal(t, events[i].args, event.Args)
+	}
+	require.NotNil(t, streamed[1].Proposal)
+	assert.Equal(t, proposal, *streamed[1].Proposal)
+}
+
+func TestWriterWebhookDeliveries(t *testing.T) {
+	db, _, shutdownFunc := pgtest.SetupPostgresWithSchema(t)
+	defer shutdownFunc()
+
+	appID := basics.AppIndex(3)
+	addDAOApp(t, db, basics.Round(1), appID, nil)
+
+	_, err := db.Exec(
+		context.Background(),
+		`INSERT INTO webhook (name, url, secret, apps, kinds) VALUES
+		('all', 'http://all', 's', NULL, NULL),
+		('votes', 'http://votes', 's', '{3}', '{vote-registered}'),
+		('other', 'http://other', 's', '{99}', NULL)`)
+	require.NoError(t, err)
+
+	voter := test.AccountC
+	depositXfer := test.MakeAssetTransferTxn(9, 10, voter, appID.Address(), basics.Address{})
+	deposit := test.MakeAppCallTxn(uint64(appID), voter)
+	deposit.Txn.ApplicationArgs = [][]byte{[]byte("deposit_vote_token")}
+	depositXfer.Txn.Group = crypto.Digest{1}
+	deposit.Txn.Group = crypto.Digest{1}
+
+	registerVote := test.MakeAppCallTxn(uint64(appID), voter)
+	registerVote.Txn.ApplicationArgs = [][]byte{[]byte("register_vote"), []byte("yes")}
+	registerVote.Txn.Accounts = []basics.Address{test.AccountB}
+
+	header := test.MakeGenesisBlock().BlockHeader
+	header.Round = basics.Round(1)
+	block, err := test.MakeBlockForTxns(header, &depositXfer, &deposit, &registerVote)
+	require.NoError(t, err)
+	addBlock(t, db, &block, ledgercore.StateDelta{})
+
+	rows, err := db.Query(
+		context.Background(),
+		`SELECT webhook, round, kind, payload, status, attempts FROM webhook_delivery
+		ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type delivery struct {
+		webhook string
+		kind    idb.DAOEventKind
+	}
+	var deliveries []delivery
+	for rows.Next() {
+		var d delivery
+		var round uint64
+		var kind, status string
+		var payload []byte
+		var attempts int
+		err = rows.Scan(&d.webhook, &round, &kind, &payload, &status, &attempts)
+		require.NoError(t, err)
+		d.kind = idb.DAOEventKind(kind)
+		deliveries = append(deliveries, d)
+
+		assert.Equal(t, uint64(1), round)
+		assert.Equal(t, string(idb.WebhookDeliveryPending), status)
+		assert.Equal(t, 0, attempts)
+		var event idb.DAOEventPayload
+		require.NoError(t, json.Unmarshal(payload, &event))
+		assert.Equal(t, d.kind, event.Kind)
+		assert.Equal(t, uint64(appID), event.AppID)
+	}
+	require.NoError(t, rows.Err())
+
+	expected := []delivery{
+		{"all", idb.DAOEventDepositChanged},
+		{"all", idb.DAOEventVoteRegistered},
+		{"votes", idb.DAOEventVoteRegistered},
+	}
+	assert.Equal(t, expected, deliveries)
+}
@@ -36,6 +36,7 @@ import (
 	pgutil "github.com/algorand/indexer/idb/postgres/internal/util"
 	"github.com/algorand/indexer/idb/postgres/internal/writer"
 	"github.com/algorand/indexer/util"
+	"github.com/algorand/indexer/util/metrics"
 )
 
 var serializable = pgx.TxOptions{IsoLevel: pgx.Serializable} // be a real ACID database
@@ -71,9 +72,11 @@ func OpenPostgres(connection string, opts idb.IndexerDbOptions, log *log.Logger)
 // Allow tests to inject a DB
 func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logger) (*IndexerDb, chan struct{}, error) {
 	idb := &IndexerDb{
-		readonly: opts.ReadOnly,
-		log:      logger,
-		db:       db,
+		readonly:     opts.ReadOnly,
+		log:          logger,
+		db:           db,
+		fullIndexing: opts.FullIndexing,
+		daoEventHub:  opts.DAOEventHub,
 	}
 
 	if idb.log == nil {
@@ -103,6 +106,13 @@ func openPostgres(db *pgxpool.Pool, opts idb.IndexerDbOptions, logger *log.Logge
 		}
 	}
 
+	// The other caches read tables created by the migrations, they are loaded by
+	// loadCaches() once the blocking migrations finished.
+	err := idb.loadDAOPrograms(opts.DAOPrograms)
+	if err != nil {
+		return nil, nil, fmt.Errorf("openPostgres() err: %w", err)
+	}
+
 	return idb, ch, nil
 }
 
@@ -114,6 +124,23 @@ type IndexerDb struct {
 	db             *pgxpool.Pool
 	migration      *migration.Migration
 	accountingLock sync.Mutex
+
+	// Known SigmaDAO program versions. Protected by `accountingLock`.
+	daoPrograms writer.DAOProgramRegistry
+	// Whether every account and asset is written.
+	fullIndexing bool
+	// Whether the caches below were loaded. Protected by `accountingLock`.
+	cachesLoaded bool
+	// Ids of the SigmaDAO apps in the `app` table. Protected by `accountingLock`.
+	daoApps *writer.DAOAppCache
+	// Decides which accounts and assets are written. Protected by `accountingLock`.
+	relevance writer.RelevancePolicy
+	// SigmaDAO treasuries whose balances are tracked. Protected by `accountingLock`.
+	treasuries *writer.TreasuryCache
+	// State of the local ledger, may be nil. Protected by `accountingLock`.
+	ledger idb.LedgerState
+	// Receives the SigmaDAO events of the committed rounds, may be nil.
+	daoEventHub *idb.DAOEventHub
 }
 
 // Close is part of idb.IndexerDb.
@@ -182,6 +209,16 @@ func (db *IndexerDb) AddBlock(vb *ledgercore.ValidatedBlock) error {
 	db.accountingLock.Lock()
 	defer db.accountingLock.Unlock()
 
+	err := db.loadCaches()
+	if err != nil {
+		return fmt.Errorf("AddBlock() err: %w", err)
+	}
+
+	var daoAppChanges writer.DAOAppChanges
+	var relChanges writer.RelevanceChanges
+	var treasuryChanges writer.TreasuryChanges
+	var audits []writer.ExecutionAudit
+	var events []idb.DAOEvent
 	f := func(tx pgx.Tx) error {
 		// Check and increment next round counter.
 		importstate, err := db.getImportState(context.Background(), tx)
@@ -199,11 +236,18 @@ func (db *IndexerDb) AddBlock(vb *ledgercore.ValidatedBlock) error {
 			return fmt.Errorf("AddBlock() err: %w", err)
 		}
 
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(
+			tx, db.daoPrograms, db.daoApps, db.relevance, db.treasuries, db.ledger)
 		if err != nil {
 			return fmt.Errorf("AddBlock() err: %w", err)
 		}
 		defer w.Close()
+		// Reset in case of a retry.
+		daoAppChanges = nil
+		relChanges = writer.RelevanceChanges{}
+		treasuryChanges = nil
+		audits = nil
+		events = nil
 
 		if block.Round() == basics.Round(0) {
 			err = w.AddBlock0(&block)
@@ -216,16 +260,30 @@ func (db *IndexerDb) AddBlock(vb *ledgercore.ValidatedBlock) error {
 		var wg sync.WaitGroup
 		defer wg.Wait()
 
-		var err0 error = nil
+		var err0 error
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := func(tx pgx.Tx) error {
+				return writer.AddDAOTransactions(
+					&block, block.Payset, db.daoPrograms, db.daoApps, tx)
+			}
+			err0 = db.txWithRetry(serializable, f)
+		}()
 
 		err = w.AddBlock(&block, block.Payset, vb.Delta())
 		if err != nil {
 			return fmt.Errorf("AddBlock() err: %w", err)
 		}
+		daoAppChanges = w.DAOAppChanges()
+		relChanges = w.RelevanceChanges()
+		treasuryChanges = w.TreasuryChanges()
+		audits = w.ExecutionAudits()
+		events = w.DAOEvents()
 
 		// Wait for goroutines to finish and check for errors. If there is an error, we
 		// return our own error so that the main transaction does not commit. Hence,
-		// `txn` and `txn_participation` tables can only be ahead but not behind
+		// `dao_txn` and `dao_txn_participation` tables can only be ahead but not behind
 		// the other state.
 		wg.Wait()
 		isUniqueViolationFunc := func(err error) bool {
@@ -238,10 +296,26 @@ func (db *IndexerDb) AddBlock(vb *ledgercore.ValidatedBlock) error {
 
 		return nil
 	}
-	err := db.txWithRetry(serializable, f)
+	err = db.txWithRetry(serializable, f)
 	if err != nil {
 		return fmt.Errorf("AddBlock() err: %w", err)
 	}
+	db.daoApps.Apply(daoAppChanges)
+	db.relevance.Apply(relChanges)
+	db.treasuries.Apply(treasuryChanges)
+	if db.daoEventHub != nil {
+		db.daoEventHub.Publish(idb.DAOEventBatch{Round: uint64(block.Round()), Events: events})
+	}
+
+	for _, audit := range audits {
+		if audit.Mismatch != "" {
+			metrics.ExecutionMismatches.Inc()
+			db.log.Warnf(
+				"AddBlock() round %d: execution of proposal %s of dao app %d in transaction %s "+
+					"does not match the proposal: %s",
+				block.Round(), audit.Proposal, audit.App, audit.Txid, audit.Mismatch)
+		}
+	}
 
 	return nil
 }
@@ -387,6 +461,360 @@ func (db *IndexerDb) setNetworkState(tx pgx.Tx, state *types.NetworkState) error
 		tx, schema.NetworkMetaStateKey, string(encoding.EncodeNetworkState(state)))
 }
 
+// Returns idb.ErrorNotInitialized if uninitialized.
+// If `tx` is nil, use a normal query.
+func (db *IndexerDb) getDAOPrograms(ctx context.Context, tx pgx.Tx) ([]idb.DAOProgram, error) {
+	programsJSON, err := db.getMetastate(ctx, tx, schema.DAOProgramsMetastateKey)
+	if err == idb.ErrorNotInitialized {
+		return nil, idb.ErrorNotInitialized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get dao programs err: %w", err)
+	}
+
+	programs, err := encoding.DecodeDAOPrograms([]byte(programsJSON))
+	if err != nil {
+		return nil,
+			fmt.Errorf("unable to parse dao programs v: \"%s\" err: %w", programsJSON, err)
+	}
+
+	return programs, nil
+}
+
+// If `tx` is nil, use a normal query.
+func (db *IndexerDb) setDAOPrograms(tx pgx.Tx, programs []idb.DAOProgram) error {
+	return db.setMetastate(
+		tx, schema.DAOProgramsMetastateKey, string(encoding.EncodeDAOPrograms(programs)))
+}
+
+// loadDAOPrograms sets up the SigmaDAO program registry. A non-empty `programs`
+// replaces the registry persisted in metastate; otherwise the persisted one is used.
+func (db *IndexerDb) loadDAOPrograms(programs []idb.DAOProgram) error {
+	if len(programs) > 0 {
+		if db.readonly {
+			db.log.Warn("loadDAOPrograms() read only mode, not persisting dao programs")
+		} else {
+			err := db.setDAOPrograms(nil, programs)
+			if err != nil {
+				return fmt.Errorf("loadDAOPrograms() err: %w", err)
+			}
+		}
+	} else {
+		var err error
+		programs, err = db.getDAOPrograms(context.Background(), nil)
+		if err == idb.ErrorNotInitialized {
+			db.log.Warn("loadDAOPrograms() no dao programs configured, no applications will be indexed")
+		} else if err != nil {
+			return fmt.Errorf("loadDAOPrograms() err: %w", err)
+		}
+	}
+
+	for _, program := range programs {
+		db.log.Infof("loadDAOPrograms() registered dao program version %s", program.Version)
+	}
+
+	db.accountingLock.Lock()
+	defer db.accountingLock.Unlock()
+	db.daoPrograms = writer.MakeDAOProgramRegistry(programs)
+
+	return nil
+}
+
+// SetDAOPrograms is part of idb.IndexerDB
+func (db *IndexerDb) SetDAOPrograms(programs []idb.DAOProgram) error {
+	err := db.loadDAOPrograms(programs)
+	if err != nil {
+		return fmt.Errorf("SetDAOPrograms() err: %w", err)
+	}
+	return nil
+}
+
+// SetLedgerState is part of idb.IndexerDB
+func (db *IndexerDb) SetLedgerState(ledger idb.LedgerState) {
+	db.accountingLock.Lock()
+	defer db.accountingLock.Unlock()
+	db.ledger = ledger
+}
+
+// loadCaches fills the caches read by the writer unless they were already loaded.
+// It reads tables created by the migrations so it must not run before the blocking
+// migrations finished. Must be called with `accountingLock` held.
+func (db *IndexerDb) loadCaches() error {
+	if db.cachesLoaded {
+		return nil
+	}
+
+	err := db.loadDAOApps()
+	if err != nil {
+		return fmt.Errorf("loadCaches() err: %w", err)
+	}
+	err = db.loadRelevance()
+	if err != nil {
+		return fmt.Errorf("loadCaches() err: %w", err)
+	}
+	err = db.loadTreasuries()
+	if err != nil {
+		return fmt.Errorf("loadCaches() err: %w", err)
+	}
+	db.cachesLoaded = true
+
+	return nil
+}
+
+// loadDAOApps fills the SigmaDAO app cache from the `app` table. Must be called with
+// `accountingLock` held.
+func (db *IndexerDb) loadDAOApps() error {
+	rows, err := db.db.Query(context.Background(), "SELECT index FROM app WHERE NOT deleted")
+	if err != nil {
+		return fmt.Errorf("loadDAOApps() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var apps []basics.AppIndex
+	for rows.Next() {
+		var app uint64
+		err = rows.Scan(&app)
+		if err != nil {
+			return fmt.Errorf("loadDAOApps() scan err: %w", err)
+		}
+		apps = append(apps, basics.AppIndex(app))
+	}
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("loadDAOApps() rows err: %w", err)
+	}
+
+	db.log.Infof("loadDAOApps() loaded %d dao apps", len(apps))
+	db.daoApps = writer.MakeDAOAppCache(apps)
+
+	return nil
+}
+
+// loadTreasuries fills the treasury cache from the `dao_treasury` table. Must be
+// called with `accountingLock` held.
+func (db *IndexerDb) loadTreasuries() error {
+	rows, err := db.db.Query(
+		context.Background(), "SELECT DISTINCT app, addr FROM dao_treasury")
+	if err != nil {
+		return fmt.Errorf("loadTreasuries() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var treasuries []writer.Treasury
+	for rows.Next() {
+		var app uint64
+		var addr []byte
+		err = rows.Scan(&app, &addr)
+		if err != nil {
+			return fmt.Errorf("loadTreasuries() scan err: %w", err)
+		}
+		treasury := writer.Treasury{App: basics.AppIndex(app)}
+		copy(treasury.Address[:], addr)
+		treasuries = append(treasuries, treasury)
+	}
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("loadTreasuries() rows err: %w", err)
+	}
+
+	db.log.Infof("loadTreasuries() loaded %d dao treasuries", len(treasuries))
+	db.treasuries = writer.MakeTreasuryCache(treasuries)
+
+	return nil
+}
+
+// loadRelevance sets up the relevance policy. Unless full indexing is enabled, the
+// accounts and assets already known to be SigmaDAO related are read from the database.
+// Must be called with `accountingLock` held.
+func (db *IndexerDb) loadRelevance() error {
+	if db.fullIndexing {
+		db.log.Info("loadRelevance() full indexing enabled, all accounts and assets are written")
+		db.relevance = writer.FullIndexing{}
+		return nil
+	}
+
+	ctx := context.Background()
+	var accounts []basics.Address
+	var assets []basics.AssetIndex
+
+	// Creators, proposal accounts and their funding accounts, voters and governance
+	// token holders. Escrows are derived from the app ids below.
+	query := `SELECT creator FROM app
+		UNION SELECT addr FROM account_app
+		UNION SELECT from_addr FROM proposal WHERE from_addr IS NOT NULL
+		UNION SELECT aa.addr FROM account_asset aa JOIN dao ON aa.assetid = dao.gov_token_id`
+	rows, err := db.db.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("loadRelevance() query accounts err: %w", err)
+	}
+	for rows.Next() {
+		var addr []byte
+		err = rows.Scan(&addr)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("loadRelevance() scan account err: %w", err)
+		}
+		var address basics.Address
+		copy(address[:], addr)
+		accounts = append(accounts, address)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("loadRelevance() accounts rows err: %w", err)
+	}
+
+	rows, err = db.db.Query(ctx, "SELECT index FROM app")
+	if err != nil {
+		return fmt.Errorf("loadRelevance() query apps err: %w", err)
+	}
+	for rows.Next() {
+		var app uint64
+		err = rows.Scan(&app)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("loadRelevance() scan app err: %w", err)
+		}
+		accounts = append(accounts, basics.AppIndex(app).Address())
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("loadRelevance() apps rows err: %w", err)
+	}
+
+	rows, err = db.db.Query(
+		ctx, "SELECT DISTINCT gov_token_id FROM dao WHERE gov_token_id IS NOT NULL")
+	if err != nil {
+		return fmt.Errorf("loadRelevance() query assets err: %w", err)
+	}
+	for rows.Next() {
+		var asset uint64
+		err = rows.Scan(&asset)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("loadRelevance() scan asset err: %w", err)
+		}
+		assets = append(assets, basics.AssetIndex(asset))
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("loadRelevance() assets rows err: %w", err)
+	}
+
+	db.log.Infof(
+		"loadRelevance() loaded %d dao related accounts and %d assets", len(accounts),
+		len(assets))
+	db.relevance = writer.MakeDAORelevance(accounts, assets)
+
+	return nil
+}
+
+// DeleteNonDAOAppRows is part of idb.IndexerDb.
+func (db *IndexerDb) DeleteNonDAOAppRows(ctx context.Context) (uint64, error) {
+	db.accountingLock.Lock()
+	defer db.accountingLock.Unlock()
+
+	// Rows of deleted SigmaDAO apps are kept, they are not foreign.
+	query := "DELETE FROM account_app WHERE app NOT IN (SELECT index FROM app)"
+	var deleted uint64
+	f := func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query)
+		if err != nil {
+			return fmt.Errorf("DeleteNonDAOAppRows() exec err: %w", err)
+		}
+		deleted = uint64(tag.RowsAffected())
+		return nil
+	}
+	err := db.txWithRetry(serializable, f)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteNonDAOAppRows() err: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// BackfillDAOApps is part of idb.IndexerDB
+func (db *IndexerDb) BackfillDAOApps(ctx context.Context, ledger idb.DAOBackfillLedger) (uint64, error) {
+	db.accountingLock.Lock()
+	defer db.accountingLock.Unlock()
+
+	err := db.loadCaches()
+	if err != nil {
+		return 0, fmt.Errorf("BackfillDAOApps() err: %w", err)
+	}
+
+	header := ledger.BlockHeader()
+
+	var daoAppChanges writer.DAOAppChanges
+	f := func(tx pgx.Tx) error {
+		importstate, err := db.getImportState(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("BackfillDAOApps() err: %w", err)
+		}
+		if header.Round+1 != basics.Round(importstate.NextRoundToAccount) {
+			return fmt.Errorf(
+				"BackfillDAOApps() ledger is at round %d but next round to account is %d",
+				header.Round, importstate.NextRoundToAccount)
+		}
+
+		var accountDeltas ledgercore.AccountDeltas
+		add := func(appID basics.AppIndex, creator basics.Address, params *basics.AppParams) error {
+			if db.daoApps.Contains(appID) {
+				return nil
+			}
+			if _, ok := db.daoPrograms.Match(params.ApprovalProgram); !ok {
+				return nil
+			}
+
+			// There is one resource per account and app, the creator can be opted in.
+			var creatorLocalState *basics.AppLocalState
+			addLocalState := func(address basics.Address, localState *basics.AppLocalState) error {
+				if address == creator {
+					creatorLocalState = localState
+					return nil
+				}
+				accountDeltas.UpsertAppResource(
+					address, appID, ledgercore.AppParamsDelta{},
+					ledgercore.AppLocalStateDelta{LocalState: localState})
+				return nil
+			}
+			err := ledger.LocalStates(appID, addLocalState)
+			if err != nil {
+				return fmt.Errorf("app %d local states err: %w", appID, err)
+			}
+			accountDeltas.UpsertAppResource(
+				creator, appID, ledgercore.AppParamsDelta{Params: params},
+				ledgercore.AppLocalStateDelta{LocalState: creatorLocalState})
+			return nil
+		}
+		err = ledger.Apps(add)
+		if err != nil {
+			return fmt.Errorf("BackfillDAOApps() err: %w", err)
+		}
+
+		w, err := writer.MakeWriter(
+			tx, db.daoPrograms, db.daoApps, db.relevance, db.treasuries, nil)
+		if err != nil {
+			return fmt.Errorf("BackfillDAOApps() err: %w", err)
+		}
+		defer w.Close()
+
+		err = w.AddDAOApps(&header, &accountDeltas)
+		if err != nil {
+			return fmt.Errorf("BackfillDAOApps() err: %w", err)
+		}
+		daoAppChanges = w.DAOAppChanges()
+
+		return nil
+	}
+	err = db.txWithRetry(serializable, f)
+	if err != nil {
+		return 0, fmt.Errorf("BackfillDAOApps() err: %w", err)
+	}
+	db.daoApps.Apply(daoAppChanges)
+
+	return uint64(len(daoAppChanges)), nil
+}
+
 // Returns ErrorNotInitialized if genesis is not loaded.
 // If `tx` is nil, use a normal query.
 func (db *IndexerDb) getNextRoundToAccount(ctx context.Context, tx pgx.Tx) (uint64, error) {
@@ -488,12 +916,12 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		partNumber++
 	}
 	if !tf.BeforeTime.IsZero() {
-		whereParts = append(whereParts, fmt.Sprintf("h.realtime < $%d", partNumber))
+		whereParts = append(whereParts, fmt.Sprintf("t.round_time < $%d", partNumber))
 		whereArgs = append(whereArgs, tf.BeforeTime)
 		partNumber++
 	}
 	if !tf.AfterTime.IsZero() {
-		whereParts = append(whereParts, fmt.Sprintf("h.realtime > $%d", partNumber))
+		whereParts = append(whereParts, fmt.Sprintf("t.round_time > $%d", partNumber))
 		whereArgs = append(whereArgs, tf.AfterTime)
 		partNumber++
 	}
@@ -589,9 +1017,19 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		whereParts = append(whereParts, "(t.txn -> 'txn' -> 'rekey') IS NOT NULL")
 	}
 
+	// Only transaction groups calling a SigmaDAO app are stored, so other transactions
+	// are never found.
+	if tf.ReturnInnerTxnOnly {
+		query = "SELECT t.round, t.intra, t.txn, NULL, t.extra, t.asset, t.round_time FROM dao_txn t"
+	} else {
+		query = "SELECT t.round, t.intra, t.txn, root.txn, t.extra, t.asset, t.round_time FROM dao_txn t"
+	}
+	if joinParticipation {
+		query += " JOIN dao_txn_participation p ON t.round = p.round AND t.intra = p.intra"
+	}
 	// join in the root transaction if the returnInnerTxnOnly flag is false
 	if !tf.ReturnInnerTxnOnly {
-		query += " LEFT OUTER JOIN txn root ON t.round = root.round AND (t.extra->>'root-intra')::int = root.intra"
+		query += " LEFT OUTER JOIN dao_txn root ON t.round = root.round AND (t.extra->>'root-intra')::int = root.intra"
 	}
 
 	if len(whereParts) > 0 {
@@ -599,10 +1037,10 @@ func buildTransactionQuery(tf idb.TransactionFilter) (query string, whereArgs []
 		query += " WHERE " + whereStr
 	}
 	if joinParticipation {
-		// this should match the index on txn_particpation
+		// this should match the primary key on dao_txn_participation
 		query += " ORDER BY p.addr, p.round DESC, p.intra DESC"
 	} else {
-		// this should explicitly match the primary key on txn (round,intra)
+		// this should explicitly match the primary key on dao_txn (round,intra)
 		query += " ORDER BY t.round, t.intra"
 	}
 	if tf.Limit != 0 {
@@ -875,8 +1313,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		var addr []byte
 		var microalgos uint64
 		var rewardstotal uint64
-		var createdat sql.NullInt64
-		var closedat sql.NullInt64
 		var deleted sql.NullBool
 		var rewardsbase uint64
 		var keytype *string
@@ -888,44 +1324,36 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		var holdingAssetids []byte
 		var holdingAmount []byte
 		var holdingFrozen []byte
-		var holdingCreatedBytes []byte
-		var holdingClosedBytes []byte
 		var holdingDeletedBytes []byte
 
 		// assetParams* are a pair of lists that should merge together
 		var assetParamsIds []byte
 		var assetParamsStr []byte
-		var assetParamsCreatedBytes []byte
-		var assetParamsClosedBytes []byte
 		var assetParamsDeletedBytes []byte
 
 		// appParam* are a pair of lists that should merge together
 		var appParamIndexes []byte // [appId, ...]
 		var appParams []byte       // [{AppParams}, ...]
-		var appCreatedBytes []byte
-		var appClosedBytes []byte
 		var appDeletedBytes []byte
 
 		// localState* are a pair of lists that should merge together
 		var localStateAppIds []byte // [appId, ...]
 		var localStates []byte      // [{local state}, ...]
-		var localStateCreatedBytes []byte
-		var localStateClosedBytes []byte
 		var localStateDeletedBytes []byte
 
 		// build list of columns to scan using include options like buildAccountQuery
-		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
+		cols := []interface{}{&addr, &microalgos, &rewardstotal, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
 		if req.opts.IncludeAssetHoldings {
-			cols = append(cols, &holdingAssetids, &holdingAmount, &holdingFrozen, &holdingCreatedBytes, &holdingClosedBytes, &holdingDeletedBytes)
+			cols = append(cols, &holdingAssetids, &holdingAmount, &holdingFrozen, &holdingDeletedBytes)
 		}
 		if req.opts.IncludeAssetParams {
-			cols = append(cols, &assetParamsIds, &assetParamsStr, &assetParamsCreatedBytes, &assetParamsClosedBytes, &assetParamsDeletedBytes)
+			cols = append(cols, &assetParamsIds, &assetParamsStr, &assetParamsDeletedBytes)
 		}
 		if req.opts.IncludeAppParams {
-			cols = append(cols, &appParamIndexes, &appParams, &appCreatedBytes, &appClosedBytes, &appDeletedBytes)
+			cols = append(cols, &appParamIndexes, &appParams, &appDeletedBytes)
 		}
 		if req.opts.IncludeAppLocalState {
-			cols = append(cols, &localStateAppIds, &localStates, &localStateCreatedBytes, &localStateClosedBytes, &localStateDeletedBytes)
+			cols = append(cols, &localStateAppIds, &localStates, &localStateDeletedBytes)
 		}
 
 		err := req.rows.Scan(cols...)
@@ -939,11 +1367,9 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 		var aaddr basics.Address
 		copy(aaddr[:], addr)
 		account.Address = aaddr.String()
-		account.Round = uint64(req.blockheader.Round)
+		account.Round = req.round
 		account.AmountWithoutPendingRewards = microalgos
 		account.Rewards = rewardstotal
-		account.CreatedAtRound = nullableInt64Ptr(createdat)
-		account.ClosedAtRound = nullableInt64Ptr(closedat)
 		account.Deleted = nullableBoolPtr(deleted)
 		account.RewardBase = new(uint64)
 		*account.RewardBase = rewardsbase
@@ -1008,24 +1434,9 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 			account.TotalCreatedAssets = ad.TotalAssetParams
 		}
 
-		if account.Status == "NotParticipating" {
-			account.PendingRewards = 0
-		} else {
-			// TODO: pending rewards calculation doesn't belong in database layer (this is just the most covenient place which has all the data)
-			proto, ok := config.Consensus[req.blockheader.CurrentProtocol]
-			if !ok {
-				err = fmt.Errorf("get protocol err (%s)", req.blockheader.CurrentProtocol)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
-			rewardsUnits := uint64(0)
-			if proto.RewardUnit != 0 {
-				rewardsUnits = microalgos / proto.RewardUnit
-			}
-			rewardsDelta := req.blockheader.RewardsLevel - rewardsbase
-			account.PendingRewards = rewardsUnits * rewardsDelta
-		}
-		account.Amount = microalgos + account.PendingRewards
+		// Block headers are not stored, so the rewards level needed for pending rewards
+		// is unknown. The amount is the balance as of the last rewards update.
+		account.Amount = microalgos
 		// not implemented: account.Rewards sum of all rewards ever
 
 		const nullarraystr = "[null]"
@@ -1052,20 +1463,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			var holdingCreated []*uint64
-			err = encoding.DecodeJSON(holdingCreatedBytes, &holdingCreated)
-			if err != nil {
-				err = fmt.Errorf("parsing json holding created ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
-			var holdingClosed []*uint64
-			err = encoding.DecodeJSON(holdingClosedBytes, &holdingClosed)
-			if err != nil {
-				err = fmt.Errorf("parsing json holding closed ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
 			var holdingDeleted []*bool
 			err = encoding.DecodeJSON(holdingDeletedBytes, &holdingDeleted)
 			if err != nil {
@@ -1074,9 +1471,9 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				break
 			}
 
-			if len(hamounts) != len(haids) || len(hfrozen) != len(haids) || len(holdingCreated) != len(haids) || len(holdingClosed) != len(haids) || len(holdingDeleted) != len(haids) {
-				err = fmt.Errorf("account asset holding unpacking, all should be %d:  %d amounts, %d frozen, %d created, %d closed, %d deleted",
-					len(haids), len(hamounts), len(hfrozen), len(holdingCreated), len(holdingClosed), len(holdingDeleted))
+			if len(hamounts) != len(haids) || len(hfrozen) != len(haids) || len(holdingDeleted) != len(haids) {
+				err = fmt.Errorf("account asset holding unpacking, all should be %d:  %d amounts, %d frozen, %d deleted",
+					len(haids), len(hamounts), len(hfrozen), len(holdingDeleted))
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
@@ -1095,12 +1492,10 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 					continue
 				}
 				tah := models.AssetHolding{
-					Amount:          hamounts[i],
-					IsFrozen:        hfrozen[i],
-					AssetId:         assetid,
-					OptedOutAtRound: holdingClosed[i],
-					OptedInAtRound:  holdingCreated[i],
-					Deleted:         holdingDeleted[i],
+					Amount:   hamounts[i],
+					IsFrozen: hfrozen[i],
+					AssetId:  assetid,
+					Deleted:  holdingDeleted[i],
 				}
 				av = append(av, tah)
 			}
@@ -1121,20 +1516,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			var assetCreated []*uint64
-			err = encoding.DecodeJSON(assetParamsCreatedBytes, &assetCreated)
-			if err != nil {
-				err = fmt.Errorf("parsing json asset created ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
-			var assetClosed []*uint64
-			err = encoding.DecodeJSON(assetParamsClosedBytes, &assetClosed)
-			if err != nil {
-				err = fmt.Errorf("parsing json asset closed ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
 			var assetDeleted []*bool
 			err = encoding.DecodeJSON(assetParamsDeletedBytes, &assetDeleted)
 			if err != nil {
@@ -1143,9 +1524,9 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				break
 			}
 
-			if len(assetParams) != len(assetids) || len(assetCreated) != len(assetids) || len(assetClosed) != len(assetids) || len(assetDeleted) != len(assetids) {
-				err = fmt.Errorf("account asset unpacking, all should be %d:  %d assetids, %d created, %d closed, %d deleted",
-					len(assetParams), len(assetids), len(assetCreated), len(assetClosed), len(assetDeleted))
+			if len(assetParams) != len(assetids) || len(assetDeleted) != len(assetids) {
+				err = fmt.Errorf("account asset unpacking, all should be %d:  %d assetids, %d deleted",
+					len(assetParams), len(assetids), len(assetDeleted))
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
@@ -1166,10 +1547,8 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				ap := assetParams[i]
 
 				tma := models.Asset{
-					Index:            assetid,
-					CreatedAtRound:   assetCreated[i],
-					DestroyedAtRound: assetClosed[i],
-					Deleted:          assetDeleted[i],
+					Index:   assetid,
+					Deleted: assetDeleted[i],
 					Params: models.AssetParams{
 						Creator:       account.Address,
 						Total:         ap.Total,
@@ -1203,20 +1582,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			var appCreated []*uint64
-			err = encoding.DecodeJSON(appCreatedBytes, &appCreated)
-			if err != nil {
-				err = fmt.Errorf("parsing json app created ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
-			var appClosed []*uint64
-			err = encoding.DecodeJSON(appClosedBytes, &appClosed)
-			if err != nil {
-				err = fmt.Errorf("parsing json app closed ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
 			var appDeleted []*bool
 			err = encoding.DecodeJSON(appDeletedBytes, &appDeleted)
 			if err != nil {
@@ -1231,8 +1596,8 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			if len(appIds) != len(apps) || len(appClosed) != len(apps) || len(appCreated) != len(apps) || len(appDeleted) != len(apps) {
-				err = fmt.Errorf("account app unpacking, all should be %d:  %d appids, %d appClosed, %d appCreated, %d appDeleted", len(apps), len(appIds), len(appClosed), len(appCreated), len(appDeleted))
+			if len(appIds) != len(apps) || len(appDeleted) != len(apps) {
+				err = fmt.Errorf("account app unpacking, all should be %d:  %d appids, %d appDeleted", len(apps), len(appIds), len(appDeleted))
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
@@ -1241,8 +1606,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 			outpos := 0
 			for i, appid := range appIds {
 				aout[outpos].Id = appid
-				aout[outpos].CreatedAtRound = appCreated[i]
-				aout[outpos].DeletedAtRound = appClosed[i]
 				aout[outpos].Deleted = appDeleted[i]
 				aout[outpos].Params.Creator = &account.Address
 
@@ -1282,20 +1645,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			var appCreated []*uint64
-			err = encoding.DecodeJSON(localStateCreatedBytes, &appCreated)
-			if err != nil {
-				err = fmt.Errorf("parsing json ls created ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
-			var appClosed []*uint64
-			err = encoding.DecodeJSON(localStateClosedBytes, &appClosed)
-			if err != nil {
-				err = fmt.Errorf("parsing json ls closed ids, %v", err)
-				req.out <- idb.AccountRow{Error: err}
-				break
-			}
 			var appDeleted []*bool
 			err = encoding.DecodeJSON(localStateDeletedBytes, &appDeleted)
 			if err != nil {
@@ -1309,8 +1658,8 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
-			if len(appIds) != len(ls) || len(appClosed) != len(ls) || len(appCreated) != len(ls) || len(appDeleted) != len(ls) {
-				err = fmt.Errorf("account app unpacking, all should be %d:  %d appids, %d appClosed, %d appCreated, %d appDeleted", len(ls), len(appIds), len(appClosed), len(appCreated), len(appDeleted))
+			if len(appIds) != len(ls) || len(appDeleted) != len(ls) {
+				err = fmt.Errorf("account app unpacking, all should be %d:  %d appids, %d appDeleted", len(ls), len(appIds), len(appDeleted))
 				req.out <- idb.AccountRow{Error: err}
 				break
 			}
@@ -1318,8 +1667,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 			aout := make([]models.ApplicationLocalState, len(ls))
 			for i, appid := range appIds {
 				aout[i].Id = appid
-				aout[i].OptedInAtRound = appCreated[i]
-				aout[i].ClosedOutAtRound = appClosed[i]
 				aout[i].Deleted = appDeleted[i]
 				aout[i].Schema = models.ApplicationStateSchema{
 					NumByteSlice: ls[i].Schema.NumByteSlice,
@@ -1342,13 +1689,6 @@ func (db *IndexerDb) yieldAccountsThread(req *getAccountsRequest) {
 	}
 }
 
-func nullableInt64Ptr(x sql.NullInt64) *uint64 {
-	if !x.Valid {
-		return nil
-	}
-	return uint64Ptr(uint64(x.Int64))
-}
-
 func nullableBoolPtr(x sql.NullBool) *bool {
 	if !x.Valid {
 		return nil
@@ -1430,12 +1770,12 @@ func addrStr(addr basics.Address) *string {
 }
 
 type getAccountsRequest struct {
-	opts        idb.AccountQueryOptions
-	blockheader bookkeeping.BlockHeader
-	query       string
-	rows        pgx.Rows
-	out         chan idb.AccountRow
-	start       time.Time
+	opts  idb.AccountQueryOptions
+	round uint64
+	query string
+	rows  pgx.Rows
+	out   chan idb.AccountRow
+	start time.Time
 }
 
 // GetAccounts is part of idb.IndexerDB
@@ -1487,6 +1827,7 @@ func (db *IndexerDb) GetAccounts(ctx context.Context, opts idb.AccountQueryOptio
 	query, whereArgs := db.buildAccountQuery(opts, false)
 	req := &getAccountsRequest{
 		opts:  opts,
+		round: round,
 		query: query,
 		out:   out,
 		start: time.Now(),
@@ -1547,14 +1888,12 @@ func (db *IndexerDb) checkAccountResourceLimit(ctx context.Context, tx pgx.Tx, o
 		var addr []byte
 		var microalgos uint64
 		var rewardstotal uint64
-		var createdat sql.NullInt64
-		var closedat sql.NullInt64
 		var deleted sql.NullBool
 		var rewardsbase uint64
 		var keytype *string
 		var accountDataJSONStr []byte
 		var holdingCount, assetCount, appCount, lsCount sql.NullInt64
-		cols := []interface{}{&addr, &microalgos, &rewardstotal, &createdat, &closedat, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
+		cols := []interface{}{&addr, &microalgos, &rewardstotal, &deleted, &rewardsbase, &keytype, &accountDataJSONStr}
 		if countOnly {
 			if o.IncludeAssetHoldings {
 				cols = append(cols, &holdingCount)
@@ -1899,12 +2238,10 @@ func (db *IndexerDb) yieldAssetsThread(filter idb.AssetsQuery, rows pgx.Rows, ou
 		var index uint64
 		var creatorAddr []byte
 		var paramsJSONStr []byte
-		var created *uint64
-		var closed *uint64
 		var deleted *bool
 		var err error
 
-		err = rows.Scan(&index, &creatorAddr, &paramsJSONStr, &created, &closed, &deleted)
+		err = rows.Scan(&index, &creatorAddr, &paramsJSONStr, &deleted)
 		if err != nil {
 			out <- idb.AssetRow{Error: err}
 			break
@@ -1915,12 +2252,10 @@ func (db *IndexerDb) yieldAssetsThread(filter idb.AssetsQuery, rows pgx.Rows, ou
 			break
 		}
 		rec := idb.AssetRow{
-			AssetID:      index,
-			Creator:      creatorAddr,
-			Params:       params,
-			CreatedRound: created,
-			ClosedRound:  closed,
-			Deleted:      deleted,
+			AssetID: index,
+			Creator: creatorAddr,
+			Params:  params,
+			Deleted: deleted,
 		}
 		out <- rec
 	}
@@ -2028,22 +2363,18 @@ func (db *IndexerDb) yieldAssetBalanceThread(rows pgx.Rows, out chan<- idb.Asset
 		var assetID uint64
 		var amount uint64
 		var frozen bool
-		var created *uint64
-		var closed *uint64
 		var deleted *bool
-		err := rows.Scan(&addr, &assetID, &amount, &frozen, &created, &closed, &deleted)
+		err := rows.Scan(&addr, &assetID, &amount, &frozen, &deleted)
 		if err != nil {
 			out <- idb.AssetBalanceRow{Error: err}
 			break
 		}
 		rec := idb.AssetBalanceRow{
-			Address:      addr,
-			AssetID:      assetID,
-			Amount:       amount,
-			Frozen:       frozen,
-			ClosedRound:  closed,
-			CreatedRound: created,
-			Deleted:      deleted,
+			Address: addr,
+			AssetID: assetID,
+			Amount:  amount,
+			Frozen:  frozen,
+			Deleted: deleted,
 		}
 		out <- rec
 	}
@@ -2056,7 +2387,7 @@ func (db *IndexerDb) yieldAssetBalanceThread(rows pgx.Rows, out chan<- idb.Asset
 func (db *IndexerDb) Applications(ctx context.Context, filter idb.ApplicationQuery) (<-chan idb.ApplicationRow, uint64) {
 	out := make(chan idb.ApplicationRow, 1)
 
-	query := `SELECT index, creator, params deleted FROM app `
+	query := `SELECT index, creator, params, deleted FROM app `
 
 	const maxWhereParts = 4
 	whereParts := make([]string, 0, maxWhereParts)
@@ -2137,19 +2468,16 @@ func (db *IndexerDb) yieldApplicationsThread(rows pgx.Rows, out chan idb.Applica
 		var index uint64
 		var creator []byte
 		var paramsjson []byte
-		var created *uint64
-		var closed *uint64
-		var deleted *bool
-		err := rows.Scan(&index, &creator, &paramsjson, &created, &closed, &deleted)
+		var deleted bool
+		err := rows.Scan(&index, &creator, &paramsjson, &deleted)
 		if err != nil {
 			out <- idb.ApplicationRow{Error: err}
 			break
 		}
 		var rec idb.ApplicationRow
 		rec.Application.Id = index
-		rec.Application.CreatedAtRound = created
-		rec.Application.DeletedAtRound = closed
-		rec.Application.Deleted = deleted
+		rec.Application.Deleted = new(bool)
+		*rec.Application.Deleted = deleted
 		ap, err := encoding.DecodeAppParams(paramsjson)
 		if err != nil {
 			rec.Error = fmt.Errorf("app=%d json err: %w", index, err)
@@ -2271,18 +2599,14 @@ func (db *IndexerDb) yieldAppLocalStateThread(rows pgx.Rows, out chan idb.AppLoc
 		var index uint64
 		var address []byte
 		var statejson []byte
-		var created *uint64
-		var closed *uint64
 		var deleted *bool
-		err := rows.Scan(&index, &address, &statejson, &created, &closed, &deleted)
+		err := rows.Scan(&index, &address, &statejson, &deleted)
 		if err != nil {
 			out <- idb.AppLocalStateRow{Error: err}
 			break
 		}
 		var rec idb.AppLocalStateRow
 		rec.AppLocalState.Id = index
-		rec.AppLocalState.OptedInAtRound = created
-		rec.AppLocalState.ClosedOutAtRound = closed
 		rec.AppLocalState.Deleted = deleted
 
 		ls, err := encoding.DecodeAppLocalState(statejson)
@@ -2303,6 +2627,960 @@ func (db *IndexerDb) yieldAppLocalStateThread(rows pgx.Rows, out chan idb.AppLoc
 	}
 }
 
+// DAOs is part of idb.IndexerDB
+func (db *IndexerDb) DAOs(ctx context.Context, filter idb.DAOQuery) (<-chan idb.DAORow, uint64) {
+	out := make(chan idb.DAORow, 1)
+
+	query := `SELECT d.app, d.creator, a.version, d.name, d.url, d.gov_token_id, d.deposit,
+		d.min_support, d.min_duration, d.max_duration, d.closed_round, d.deleted
+		FROM dao d JOIN app a ON a.index = d.app`
+
+	const maxWhereParts = 8
+	whereParts := make([]string, 0, maxWhereParts)
+	whereArgs := make([]interface{}, 0, maxWhereParts)
+	partNumber := 1
+	if filter.ApplicationID != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("d.app = $%d", partNumber))
+		whereArgs = append(whereArgs, filter.ApplicationID)
+		partNumber++
+	}
+	if filter.ApplicationIDGreaterThan != 0 {
+		whereParts = append(whereParts, fmt.Sprintf("d.app > $%d", partNumber))
+		whereArgs = append(whereArgs, filter.ApplicationIDGreaterThan)
+		partNumber++
+	}
+	if filter.Creator != nil {
+		whereParts = append(whereParts, fmt.Sprintf("d.creator = $%d", partNumber))
+		whereArgs = append(whereArgs, filter.Creator)
+		partNumber++
+	}
+	if filter.Member != nil {
+		whereParts = append(whereParts, fmt.Sprintf(
+			`(d.creator = $%[1]d OR d.app IN (
+			SELECT app FROM dao_deposit WHERE addr = $%[1]d AND NOT deleted
+			UNION SELECT app FROM vote WHERE voter = $%[1]d AND NOT deleted))`,
+			partNumber))
+		whereArgs = append(whereArgs, filter.Member)
+		partNumber++
+	}
+	if filter.GovTokenID != nil {
+		whereParts = append(whereParts, fmt.Sprintf("d.gov_token_id = $%d", partNumber))
+		whereArgs = append(whereArgs, *filter.GovTokenID)
+		partNumber++
+	}
+	if filter.Name != "" {
+		whereParts = append(whereParts, fmt.Sprintf("d.name ILIKE $%d", partNumber))
+		whereArgs = append(whereArgs, "%"+filter.Name+"%")
+		partNumber++
+	}
+	if filter.Version != "" {
+		whereParts = append(whereParts, fmt.Sprintf("a.version = $%d", partNumber))
+		whereArgs = append(whereArgs, filter.Version)
+		partNumber++
+	}
+	if filter.Deleted != nil {
+		whereParts = append(whereParts, fmt.Sprintf("d.deleted = $%d", partNumber))
+		whereArgs = append(whereArgs, *filter.Deleted)
+		partNumber++
+	}
+	if len(whereParts) > 0 {
+		query += " WHERE " + strings.Join(whereParts, " AND ")
+	}
+	query += " ORDER BY d.app"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.DAORow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.DAORow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.DAORow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldDAOsThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldDAOsThread(rows pgx.Rows, out chan<- idb.DAORow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var creator []byte
+		var version *string
+		var rec idb.DAORow
+		dao := &rec.DAO
+		err := rows.Scan(
+			&dao.AppID, &creator, &version, &dao.Name, &dao.URL, &dao.GovTokenID,
+			&dao.Deposit, &dao.MinSupport, &dao.MinDuration, &dao.MaxDuration,
+			&dao.ClosedRound, &dao.Deleted)
+		if err != nil {
+			out <- idb.DAORow{Error: err}
+			break
+		}
+		copy(dao.Creator[:], creator)
+		if version != nil {
+			dao.Version = *version
+		}
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.DAORow{Error: err}
+	}
+}
+
+// Proposals is part of idb.IndexerDB
+func (db *IndexerDb) Proposals(ctx context.Context, filter idb.ProposalQuery) (<-chan idb.ProposalRow, uint64) {
+	out := make(chan idb.ProposalRow, 1)
+
+	query := `SELECT app, addr, proposal_id, name, url, url_hash, hash_algo, voting_start,
+		voting_end, execute_before, type, from_addr, recipient, asa_id, amount, msg, executed,
+		yes, no, abstain, status, snapshot_round, execution_round, execution_result,
+		execution_details, closed_round, deleted
+		FROM proposal WHERE app = $1`
+	whereArgs := []interface{}{filter.ApplicationID}
+	partNumber := 2
+	if filter.Address != nil {
+		query += fmt.Sprintf(" AND addr = $%d", partNumber)
+		whereArgs = append(whereArgs, filter.Address)
+		partNumber++
+	}
+	if filter.Status != "" {
+		query += fmt.Sprintf(" AND status = $%d", partNumber)
+		whereArgs = append(whereArgs, string(filter.Status))
+		partNumber++
+	}
+	if filter.Type != nil {
+		query += fmt.Sprintf(" AND type = $%d", partNumber)
+		whereArgs = append(whereArgs, *filter.Type)
+		partNumber++
+	}
+	if filter.AddressGreaterThan != nil {
+		query += fmt.Sprintf(" AND addr > $%d", partNumber)
+		whereArgs = append(whereArgs, filter.AddressGreaterThan)
+		partNumber++
+	}
+	if filter.Deleted != nil {
+		query += fmt.Sprintf(" AND deleted = $%d", partNumber)
+		whereArgs = append(whereArgs, *filter.Deleted)
+		partNumber++
+	}
+	query += " ORDER BY addr"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.ProposalRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.ProposalRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.ProposalRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldProposalsThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+// addressPtr converts a nullable address column, nil if `addr` is NULL.
+func addressPtr(addr []byte) *basics.Address {
+	if addr == nil {
+		return nil
+	}
+	var res basics.Address
+	copy(res[:], addr)
+	return &res
+}
+
+func (db *IndexerDb) yieldProposalsThread(rows pgx.Rows, out chan<- idb.ProposalRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var addr []byte
+		var from []byte
+		var recipient []byte
+		var status *string
+		var rec idb.ProposalRow
+		proposal := &rec.Proposal
+		err := rows.Scan(
+			&proposal.AppID, &addr, &proposal.ProposalID, &proposal.Name, &proposal.URL,
+			&proposal.URLHash, &proposal.HashAlgo, &proposal.VotingStart, &proposal.VotingEnd,
+			&proposal.ExecuteBefore, &proposal.Type, &from, &recipient, &proposal.AsaID,
+			&proposal.Amount, &proposal.Msg, &proposal.Executed, &proposal.Yes, &proposal.No,
+			&proposal.Abstain, &status, &proposal.SnapshotRound, &proposal.ExecutionRound,
+			&proposal.ExecutionResult, &proposal.ExecutionDetails, &proposal.ClosedRound,
+			&proposal.Deleted)
+		if err != nil {
+			out <- idb.ProposalRow{Error: err}
+			break
+		}
+		copy(proposal.Address[:], addr)
+		proposal.From = addressPtr(from)
+		proposal.Recipient = addressPtr(recipient)
+		if status != nil {
+			proposal.Status = idb.ProposalStatus(*status)
+		}
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.ProposalRow{Error: err}
+	}
+}
+
+// Votes is part of idb.IndexerDB
+func (db *IndexerDb) Votes(ctx context.Context, filter idb.VoteQuery) (<-chan idb.VoteRow, uint64) {
+	out := make(chan idb.VoteRow, 1)
+
+	query := `SELECT app, proposal, voter, vote_option, weight, round, closed_round, deleted
+		FROM vote WHERE app = $1 AND proposal = $2`
+	whereArgs := []interface{}{filter.ApplicationID, filter.Proposal}
+	partNumber := 3
+	if filter.Voter != nil {
+		query += fmt.Sprintf(" AND voter = $%d", partNumber)
+		whereArgs = append(whereArgs, filter.Voter)
+		partNumber++
+	}
+	if filter.Option != "" {
+		query += fmt.Sprintf(" AND vote_option = $%d", partNumber)
+		whereArgs = append(whereArgs, filter.Option)
+		partNumber++
+	}
+	if filter.VoterGreaterThan != nil {
+		query += fmt.Sprintf(" AND voter > $%d", partNumber)
+		whereArgs = append(whereArgs, filter.VoterGreaterThan)
+		partNumber++
+	}
+	if filter.Deleted != nil {
+		query += fmt.Sprintf(" AND deleted = $%d", partNumber)
+		whereArgs = append(whereArgs, *filter.Deleted)
+		partNumber++
+	}
+	query += " ORDER BY voter"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.VoteRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.VoteRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.VoteRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldVotesThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldVotesThread(rows pgx.Rows, out chan<- idb.VoteRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var proposal []byte
+		var voter []byte
+		var rec idb.VoteRow
+		vote := &rec.Vote
+		err := rows.Scan(
+			&vote.AppID, &proposal, &voter, &vote.Option, &vote.Weight, &vote.Round,
+			&vote.ClosedRound, &vote.Deleted)
+		if err != nil {
+			out <- idb.VoteRow{Error: err}
+			break
+		}
+		copy(vote.Proposal[:], proposal)
+		copy(vote.Voter[:], voter)
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.VoteRow{Error: err}
+	}
+}
+
+// ProposalTallies is part of idb.IndexerDB
+func (db *IndexerDb) ProposalTallies(ctx context.Context, filter idb.ProposalTallyQuery) (<-chan idb.ProposalTallyRow, uint64) {
+	out := make(chan idb.ProposalTallyRow, 1)
+
+	query := `SELECT round, yes, no, abstain FROM proposal_tally_history WHERE app = $1 AND addr = $2`
+	whereArgs := []interface{}{filter.ApplicationID, filter.Proposal}
+	partNumber := 3
+	if filter.MinRound != 0 {
+		query += fmt.Sprintf(" AND round >= $%d", partNumber)
+		whereArgs = append(whereArgs, filter.MinRound)
+		partNumber++
+	}
+	if filter.MaxRound != 0 {
+		query += fmt.Sprintf(" AND round <= $%d", partNumber)
+		whereArgs = append(whereArgs, filter.MaxRound)
+		partNumber++
+	}
+	query += " ORDER BY round"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.ProposalTallyRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.ProposalTallyRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.ProposalTallyRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldProposalTalliesThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldProposalTalliesThread(rows pgx.Rows, out chan<- idb.ProposalTallyRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec idb.ProposalTallyRow
+		err := rows.Scan(&rec.Tally.Round, &rec.Tally.Yes, &rec.Tally.No, &rec.Tally.Abstain)
+		if err != nil {
+			out <- idb.ProposalTallyRow{Error: err}
+			break
+		}
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.ProposalTallyRow{Error: err}
+	}
+}
+
+// ProposalHolders is part of idb.IndexerDB
+func (db *IndexerDb) ProposalHolders(ctx context.Context, filter idb.ProposalHolderQuery) (<-chan idb.ProposalHolderRow, uint64) {
+	out := make(chan idb.ProposalHolderRow, 1)
+
+	query := `SELECT holder, amount, round FROM proposal_holder_snapshot
+		WHERE app = $1 AND proposal = $2`
+	whereArgs := []interface{}{filter.ApplicationID, filter.Proposal}
+	partNumber := 3
+	if filter.AddressGreaterThan != nil {
+		query += fmt.Sprintf(" AND holder > $%d", partNumber)
+		whereArgs = append(whereArgs, filter.AddressGreaterThan)
+		partNumber++
+	}
+	query += " ORDER BY holder"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.ProposalHolderRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.ProposalHolderRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.ProposalHolderRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldProposalHoldersThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldProposalHoldersThread(rows pgx.Rows, out chan<- idb.ProposalHolderRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var holder []byte
+		var rec idb.ProposalHolderRow
+		err := rows.Scan(&holder, &rec.Holder.Amount, &rec.Holder.Round)
+		if err != nil {
+			out <- idb.ProposalHolderRow{Error: err}
+			break
+		}
+		copy(rec.Holder.Address[:], holder)
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.ProposalHolderRow{Error: err}
+	}
+}
+
+// buildTreasuryQuery returns the where clause and arguments of a treasury query, the
+// columns are prefixed with `t.`.
+func buildTreasuryQuery(filter idb.TreasuryQuery, history bool) (string, []interface{}) {
+	whereParts := []string{"t.app = $1"}
+	whereArgs := []interface{}{filter.ApplicationID}
+	partNumber := 2
+	if filter.Address != nil {
+		whereParts = append(whereParts, fmt.Sprintf("t.addr = $%d", partNumber))
+		whereArgs = append(whereArgs, filter.Address)
+		partNumber++
+	}
+	if filter.AssetID != nil {
+		whereParts = append(whereParts, fmt.Sprintf("t.asset = $%d", partNumber))
+		whereArgs = append(whereArgs, *filter.AssetID)
+		partNumber++
+	}
+	if history && (filter.MinRound != 0) {
+		whereParts = append(whereParts, fmt.Sprintf("t.round >= $%d", partNumber))
+		whereArgs = append(whereArgs, filter.MinRound)
+		partNumber++
+	}
+	if history && (filter.MaxRound != 0) {
+		whereParts = append(whereParts, fmt.Sprintf("t.round <= $%d", partNumber))
+		whereArgs = append(whereArgs, filter.MaxRound)
+		partNumber++
+	}
+	return " WHERE " + strings.Join(whereParts, " AND "), whereArgs
+}
+
+// TreasuryBalances is part of idb.IndexerDB
+func (db *IndexerDb) TreasuryBalances(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryBalanceRow, uint64) {
+	out := make(chan idb.TreasuryBalanceRow, 1)
+
+	where, whereArgs := buildTreasuryQuery(filter, false)
+	query := "SELECT t.addr, t.asset, t.amount, t.round FROM dao_treasury t" + where +
+		" ORDER BY t.addr, t.asset"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.TreasuryBalanceRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.TreasuryBalanceRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.TreasuryBalanceRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldTreasuryBalancesThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldTreasuryBalancesThread(rows pgx.Rows, out chan<- idb.TreasuryBalanceRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var addr []byte
+		var rec idb.TreasuryBalanceRow
+		err := rows.Scan(&addr, &rec.Balance.AssetID, &rec.Balance.Amount, &rec.Balance.Round)
+		if err != nil {
+			out <- idb.TreasuryBalanceRow{Error: err}
+			break
+		}
+		copy(rec.Balance.Address[:], addr)
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.TreasuryBalanceRow{Error: err}
+	}
+}
+
+// TreasuryHistory is part of idb.IndexerDB
+func (db *IndexerDb) TreasuryHistory(ctx context.Context, filter idb.TreasuryQuery) (<-chan idb.TreasuryChangeRow, uint64) {
+	out := make(chan idb.TreasuryChangeRow, 1)
+
+	where, whereArgs := buildTreasuryQuery(filter, true)
+	// GREATEST() ignores NULL, so opening balances have no inflow or outflow.
+	query := `SELECT t.addr, t.asset, t.round, t.amount, GREATEST(t.change, 0),
+		GREATEST(-t.change, 0), t.change IS NULL FROM dao_treasury_history t` + where +
+		" ORDER BY t.round, t.addr, t.asset"
+	if filter.Limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.TreasuryChangeRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.TreasuryChangeRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.TreasuryChangeRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldTreasuryHistoryThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldTreasuryHistoryThread(rows pgx.Rows, out chan<- idb.TreasuryChangeRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var addr []byte
+		var rec idb.TreasuryChangeRow
+		err := rows.Scan(
+			&addr, &rec.Change.AssetID, &rec.Change.Round, &rec.Change.Amount,
+			&rec.Change.Inflow, &rec.Change.Outflow, &rec.Change.Opening)
+		if err != nil {
+			out <- idb.TreasuryChangeRow{Error: err}
+			break
+		}
+		copy(rec.Change.Address[:], addr)
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.TreasuryChangeRow{Error: err}
+	}
+}
+
+// DAOEvents is part of idb.IndexerDB
+func (db *IndexerDb) DAOEvents(ctx context.Context, filter idb.DAOEventQuery) (<-chan idb.DAOEventRow, uint64) {
+	out := make(chan idb.DAOEventRow, 1)
+
+	whereArgs := []interface{}{filter.MinRound, filter.MaxRound, idb.StreamedDAOMethods()}
+	appFilter := ""
+	if filter.ApplicationID != 0 {
+		whereArgs = append(whereArgs, filter.ApplicationID)
+		appFilter = " AND app = $4"
+	}
+	// Method calls come first in a round, status changes have no offset.
+	query := `SELECT round, intra, app, txid, sender, proposal, method, args, NULL AS status
+		FROM dao_event WHERE round >= $1 AND round <= $2 AND method = ANY($3)` + appFilter + `
+		UNION ALL
+		SELECT round, NULL, app, NULL, NULL, addr, NULL, NULL, status
+		FROM proposal_status_history WHERE round >= $1 AND round <= $2` + appFilter + `
+		ORDER BY round, intra NULLS LAST, app, proposal`
+
+	tx, err := db.db.BeginTx(ctx, readonlyRepeatableRead)
+	if err != nil {
+		out <- idb.DAOEventRow{Error: err}
+		close(out)
+		return out, 0
+	}
+
+	round, err := db.getMaxRoundAccounted(ctx, tx)
+	if err != nil {
+		out <- idb.DAOEventRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	rows, err := tx.Query(ctx, query, whereArgs...)
+	if err != nil {
+		out <- idb.DAOEventRow{Error: err}
+		close(out)
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		return out, round
+	}
+
+	go func() {
+		db.yieldDAOEventsThread(rows, out)
+		// Because we return a channel into a "callWithTimeout" function,
+		// We need to make sure that rollback is called before close()
+		// otherwise we can end up with a situation where "callWithTimeout"
+		// will cancel our context, resulting in connection pool churn
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			db.log.Printf("rollback error: %s", rerr)
+		}
+		close(out)
+	}()
+	return out, round
+}
+
+func (db *IndexerDb) yieldDAOEventsThread(rows pgx.Rows, out chan<- idb.DAOEventRow) {
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec idb.DAOEventRow
+		var intra *int
+		var txid []byte
+		var sender []byte
+		var proposal []byte
+		var method *string
+		var args []byte
+		var status *string
+		err := rows.Scan(
+			&rec.Event.Round, &intra, &rec.Event.AppID, &txid, &sender, &proposal, &method,
+			&args, &status)
+		if err != nil {
+			out <- idb.DAOEventRow{Error: err}
+			break
+		}
+		rec.Event.Proposal = addressPtr(proposal)
+
+		if status != nil {
+			rec.Event.Kind = idb.DAOEventStatusChanged
+			rec.Event.Status = idb.ProposalStatus(*status)
+			out <- rec
+			continue
+		}
+
+		rec.Event.Method = idb.DAOMethod(*method)
+		kind, ok := idb.DAOMethodEventKind(rec.Event.Method)
+		if !ok {
+			out <- idb.DAOEventRow{
+				Error: fmt.Errorf("yieldDAOEventsThread() unknown method %s", *method)}
+			break
+		}
+		rec.Event.Kind = kind
+		rec.Event.Intra = uint(*intra)
+		rec.Event.Txid = string(txid)
+		copy(rec.Event.Sender[:], sender)
+		rec.Event.Args, err = encoding.DecodeDAOEventArgs(args)
+		if err != nil {
+			out <- idb.DAOEventRow{Error: err}
+			break
+		}
+		out <- rec
+	}
+	if err := rows.Err(); err != nil {
+		out <- idb.DAOEventRow{Error: err}
+	}
+}
+
+// SetWebhooks is part of idb.IndexerDB
+func (db *IndexerDb) SetWebhooks(webhooks []idb.Webhook) error {
+	if db.readonly {
+		db.log.Warn("SetWebhooks() read only mode, not persisting webhooks")
+		return nil
+	}
+
+	names := make([]string, len(webhooks))
+	for i, webhook := range webhooks {
+		names[i] = webhook.Name
+	}
+	f := func(tx pgx.Tx) error {
+		// Deletes the pending deliveries of the removed webhooks as well.
+		_, err := tx.Exec(
+			context.Background(), "DELETE FROM webhook WHERE NOT (name = ANY($1))", names)
+		if err != nil {
+			return fmt.Errorf("delete err: %w", err)
+		}
+
+		for _, webhook := range webhooks {
+			var apps []uint64
+			if len(webhook.ApplicationIDs) > 0 {
+				apps = webhook.ApplicationIDs
+			}
+			var kinds []string
+			for _, kind := range webhook.Kinds {
+				kinds = append(kinds, string(kind))
+			}
+			_, err = tx.Exec(
+				context.Background(),
+				`INSERT INTO webhook (name, url, secret, apps, kinds) VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (name) DO UPDATE SET
+				url = EXCLUDED.url, secret = EXCLUDED.secret, apps = EXCLUDED.apps,
+				kinds = EXCLUDED.kinds`,
+				webhook.Name, webhook.URL, webhook.Secret, apps, kinds)
+			if err != nil {
+				return fmt.Errorf("upsert webhook %s err: %w", webhook.Name, err)
+			}
+		}
+		return nil
+	}
+	err := db.txWithRetry(serializable, f)
+	if err != nil {
+		return fmt.Errorf("SetWebhooks() err: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		db.log.Infof("SetWebhooks() registered webhook %s", webhook.Name)
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries is part of idb.IndexerDB
+func (db *IndexerDb) ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]idb.WebhookDelivery, error) {
+	// SKIP LOCKED leaves the deliveries claimed concurrently to the other claim.
+	query := `WITH claimed AS (
+		UPDATE webhook_delivery SET
+		next_attempt = now() + $2::bigint * interval '1 millisecond',
+		attempts = attempts + 1
+		WHERE id IN (SELECT id FROM webhook_delivery
+			WHERE status = '` + string(idb.WebhookDeliveryPending) + `' AND next_attempt <= now()
+			ORDER BY next_attempt, id LIMIT $1 FOR UPDATE SKIP LOCKED)
+		RETURNING id, webhook, round, kind, payload, attempts)
+		SELECT c.id, c.round, c.kind, c.payload, c.attempts, w.name, w.url, w.secret
+		FROM claimed c JOIN webhook w ON w.name = c.webhook
+		ORDER BY c.id`
+
+	rows, err := db.db.Query(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("ClaimWebhookDeliveries() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var res []idb.WebhookDelivery
+	for rows.Next() {
+		var delivery idb.WebhookDelivery
+		var kind string
+		err = rows.Scan(
+			&delivery.ID, &delivery.Round, &kind, &delivery.Payload, &delivery.Attempts,
+			&delivery.Webhook.Name, &delivery.Webhook.URL, &delivery.Webhook.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("ClaimWebhookDeliveries() scan err: %w", err)
+		}
+		delivery.Kind = idb.DAOEventKind(kind)
+		res = append(res, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ClaimWebhookDeliveries() rows err: %w", err)
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery is part of idb.IndexerDB
+func (db *IndexerDb) UpdateWebhookDelivery(ctx context.Context, update idb.WebhookDeliveryUpdate) error {
+	query := `UPDATE webhook_delivery SET
+		status = $2, next_attempt = $3, last_error = NULLIF($4, ''),
+		delivered_at = CASE WHEN $2::text = '` + string(idb.WebhookDeliveryDelivered) + `' THEN now() END
+		WHERE id = $1`
+	_, err := db.db.Exec(
+		ctx, query, update.ID, string(update.Status), update.NextAttempt, update.Error)
+	if err != nil {
+		return fmt.Errorf("UpdateWebhookDelivery() exec err: %w", err)
+	}
+	return nil
+}
+
+// APIKeys is part of idb.IndexerDB
+func (db *IndexerDb) APIKeys(ctx context.Context) ([]idb.APIKey, error) {
+	query := "SELECT name, key_hash, scopes, rate_limit, burst, expires_at FROM api_key ORDER BY name"
+	rows, err := db.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("APIKeys() query err: %w", err)
+	}
+	defer rows.Close()
+
+	var res []idb.APIKey
+	for rows.Next() {
+		var key idb.APIKey
+		var hash []byte
+		var scopes []string
+		var burst int32
+		var expiresAt *time.Time
+		err = rows.Scan(&key.Name, &hash, &scopes, &key.RateLimit, &burst, &expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("APIKeys() scan err: %w", err)
+		}
+
+		// A bad row fails the whole set, so that a typo does not go unnoticed.
+		if len(hash) != len(key.Hash) {
+			return nil, fmt.Errorf("APIKeys() key %s has a %d byte hash", key.Name, len(hash))
+		}
+		copy(key.Hash[:], hash)
+		for _, scope := range scopes {
+			if !idb.IsAPIKeyScopeValid(idb.APIKeyScope(scope)) {
+				return nil, fmt.Errorf("APIKeys() key %s has an unknown scope %s", key.Name, scope)
+			}
+			key.Scopes = append(key.Scopes, idb.APIKeyScope(scope))
+		}
+		if key.RateLimit < 0 || burst < 0 {
+			return nil, fmt.Errorf("APIKeys() key %s has a negative rate limit", key.Name)
+		}
+		key.Burst = uint32(burst)
+		if expiresAt != nil {
+			key.ExpiresAt = *expiresAt
+		}
+		res = append(res, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("APIKeys() rows err: %w", err)
+	}
+	return res, nil
+}
+
 // Health is part of idb.IndexerDB
 func (db *IndexerDb) Health(ctx context.Context) (idb.Health, error) {
 	migrationRequired := false
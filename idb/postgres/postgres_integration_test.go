@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/algorand/go-algorand/crypto"
 	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/data/bookkeeping"
 	"github.com/algorand/go-algorand/data/transactions"
 	"github.com/algorand/go-algorand/protocol"
 	"github.com/algorand/go-algorand/rpcs"
@@ -1921,3 +1923,716 @@ func TestTransactionFilterAssetAmount(t *testing.T) {
 	assert.Equal(t, txnF, *row.Txn)
 
 }
+
+func TestProposalTallies(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appID := uint64(3)
+	proposal := test.AccountB
+	for round := uint64(2); round <= 5; round++ {
+		_, err := db.db.Exec(
+			context.Background(),
+			`INSERT INTO proposal_tally_history (app, addr, round, yes, no, abstain)
+			VALUES ($1, $2, $3, $4, 0, 0)`,
+			appID, proposal[:], round, round*10)
+		require.NoError(t, err)
+	}
+	// Another proposal.
+	_, err := db.db.Exec(
+		context.Background(),
+		`INSERT INTO proposal_tally_history (app, addr, round, yes, no, abstain)
+		VALUES ($1, $2, 3, 1, 1, 1)`,
+		appID, test.AccountC[:])
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name     string
+		filter   idb.ProposalTallyQuery
+		expected []uint64
+	}{
+		{
+			name:     "all",
+			filter:   idb.ProposalTallyQuery{ApplicationID: appID, Proposal: proposal[:]},
+			expected: []uint64{2, 3, 4, 5},
+		},
+		{
+			name: "round range",
+			filter: idb.ProposalTallyQuery{
+				ApplicationID: appID, Proposal: proposal[:], MinRound: 3, MaxRound: 4},
+			expected: []uint64{3, 4},
+		},
+		{
+			name: "limit",
+			filter: idb.ProposalTallyQuery{
+				ApplicationID: appID, Proposal: proposal[:], MinRound: 3, Limit: 1},
+			expected: []uint64{3},
+		},
+		{
+			name:   "unknown app",
+			filter: idb.ProposalTallyQuery{ApplicationID: 4, Proposal: proposal[:]},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rowsCh, _ := db.ProposalTallies(context.Background(), tc.filter)
+
+			var rounds []uint64
+			for row := range rowsCh {
+				require.NoError(t, row.Error)
+				assert.Equal(t, row.Tally.Round*10, row.Tally.Yes)
+				rounds = append(rounds, row.Tally.Round)
+			}
+			assert.Equal(t, tc.expected, rounds)
+		})
+	}
+}
+
+func TestProposalHolders(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appID := uint64(3)
+	proposal := test.AccountA
+	holders := []basics.Address{test.AccountB, test.AccountC, test.AccountD}
+	for i, holder := range holders {
+		_, err := db.db.Exec(
+			context.Background(),
+			`INSERT INTO proposal_holder_snapshot (app, proposal, holder, amount, round)
+			VALUES ($1, $2, $3, $4, 7)`,
+			appID, proposal[:], holder[:], uint64(i+1))
+		require.NoError(t, err)
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return bytes.Compare(holders[i][:], holders[j][:]) < 0
+	})
+
+	// Page through the snapshot.
+	var next []byte
+	var res []basics.Address
+	for {
+		filter := idb.ProposalHolderQuery{
+			ApplicationID:      appID,
+			Proposal:           proposal[:],
+			AddressGreaterThan: next,
+			Limit:              2,
+		}
+		rowsCh, _ := db.ProposalHolders(context.Background(), filter)
+
+		count := 0
+		for row := range rowsCh {
+			require.NoError(t, row.Error)
+			assert.Equal(t, uint64(7), row.Holder.Round)
+			res = append(res, row.Holder.Address)
+			next = append([]byte(nil), row.Holder.Address[:]...)
+			count++
+		}
+		if count < 2 {
+			break
+		}
+	}
+	assert.Equal(t, holders, res)
+}
+
+func TestTreasuryQueries(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appID := uint64(3)
+	treasury := test.AccountA
+	queries := []string{
+		`INSERT INTO dao_treasury (app, addr, asset, amount, round) VALUES
+			($1, $2, 0, 600, 4), ($1, $2, 9, 80, 4)`,
+		`INSERT INTO dao_treasury_history (app, addr, asset, round, amount, change) VALUES
+			($1, $2, 0, 3, 900, NULL), ($1, $2, 9, 3, 50, NULL),
+			($1, $2, 0, 4, 600, -300), ($1, $2, 9, 4, 80, 30)`,
+	}
+	for _, query := range queries {
+		_, err := db.db.Exec(context.Background(), query, appID, treasury[:])
+		require.NoError(t, err)
+	}
+
+	rowsCh, _ := db.TreasuryBalances(context.Background(), idb.TreasuryQuery{ApplicationID: appID})
+	var balances []idb.TreasuryBalance
+	for row := range rowsCh {
+		require.NoError(t, row.Error)
+		balances = append(balances, row.Balance)
+	}
+	expectedBalances := []idb.TreasuryBalance{
+		{Address: treasury, AssetID: 0, Amount: 600, Round: 4},
+		{Address: treasury, AssetID: 9, Amount: 80, Round: 4},
+	}
+	assert.Equal(t, expectedBalances, balances)
+
+	assetID := uint64(0)
+	filter := idb.TreasuryQuery{ApplicationID: appID, AssetID: &assetID}
+	historyCh, _ := db.TreasuryHistory(context.Background(), filter)
+	var history []idb.TreasuryChange
+	for row := range historyCh {
+		require.NoError(t, row.Error)
+		history = append(history, row.Change)
+	}
+	expectedHistory := []idb.TreasuryChange{
+		{Address: treasury, AssetID: 0, Round: 3, Amount: 900, Opening: true},
+		{Address: treasury, AssetID: 0, Round: 4, Amount: 600, Outflow: 300},
+	}
+	assert.Equal(t, expectedHistory, history)
+
+	filter = idb.TreasuryQuery{ApplicationID: appID, MinRound: 4}
+	historyCh, _ = db.TreasuryHistory(context.Background(), filter)
+	history = nil
+	for row := range historyCh {
+		require.NoError(t, row.Error)
+		history = append(history, row.Change)
+	}
+	expectedHistory = []idb.TreasuryChange{
+		{Address: treasury, AssetID: 0, Round: 4, Amount: 600, Outflow: 300},
+		{Address: treasury, AssetID: 9, Round: 4, Amount: 80, Inflow: 30},
+	}
+	assert.Equal(t, expectedHistory, history)
+}
+
+func TestDAOs(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	queries := []string{
+		`INSERT INTO app (index, creator, params, version, deleted) VALUES
+			(3, $1, '{}', 'v1', FALSE), (4, $2, '{}', 'v2', FALSE), (5, $1, 'null', 'v1', TRUE)`,
+		`INSERT INTO dao (app, creator, name, gov_token_id, closed_round, deleted) VALUES
+			(3, $1, 'Red Team', 10, NULL, FALSE), (4, $2, 'blue team', 11, NULL, FALSE),
+			(5, $1, 'Old Red', 10, 8, TRUE)`,
+	}
+	for _, query := range queries {
+		_, err := db.db.Exec(context.Background(), query, test.AccountA[:], test.AccountB[:])
+		require.NoError(t, err)
+	}
+
+	getApps := func(filter idb.DAOQuery) []uint64 {
+		rowsCh, _ := db.DAOs(context.Background(), filter)
+		var res []uint64
+		for row := range rowsCh {
+			require.NoError(t, row.Error)
+			res = append(res, row.DAO.AppID)
+		}
+		return res
+	}
+
+	govTokenID := uint64(10)
+	notDeleted := false
+	assert.Equal(t, []uint64{3, 4, 5}, getApps(idb.DAOQuery{}))
+	assert.Equal(t, []uint64{3, 5}, getApps(idb.DAOQuery{Creator: test.AccountA[:]}))
+	assert.Equal(t, []uint64{3, 5}, getApps(idb.DAOQuery{GovTokenID: &govTokenID}))
+	assert.Equal(t, []uint64{3, 5}, getApps(idb.DAOQuery{Name: "red"}))
+	assert.Equal(t, []uint64{4}, getApps(idb.DAOQuery{Version: "v2"}))
+	assert.Equal(t, []uint64{3, 4}, getApps(idb.DAOQuery{Deleted: &notDeleted}))
+	assert.Equal(t, []uint64{4}, getApps(idb.DAOQuery{ApplicationIDGreaterThan: 3, Limit: 1}))
+
+	// Members are creators, depositors and voters.
+	queries = []string{
+		`INSERT INTO dao_deposit (app, addr, amount, round, deleted) VALUES
+			(4, $1, 10, 2, FALSE), (3, $2, 10, 2, TRUE)`,
+		`INSERT INTO vote (app, proposal, voter, round, deleted) VALUES
+			(5, $2, $1, 2, FALSE)`,
+	}
+	for _, query := range queries {
+		_, err := db.db.Exec(context.Background(), query, test.AccountC[:], test.AccountB[:])
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []uint64{4, 5}, getApps(idb.DAOQuery{Member: test.AccountC[:]}))
+	assert.Equal(t, []uint64{4}, getApps(idb.DAOQuery{Member: test.AccountB[:]}))
+
+	rowsCh, _ := db.DAOs(context.Background(), idb.DAOQuery{ApplicationID: 5})
+	row, ok := <-rowsCh
+	require.True(t, ok)
+	require.NoError(t, row.Error)
+	name := "Old Red"
+	closedRound := uint64(8)
+	expected := idb.DAO{
+		AppID:       5,
+		Creator:     test.AccountA,
+		Version:     "v1",
+		Name:        &name,
+		GovTokenID:  &govTokenID,
+		ClosedRound: &closedRound,
+		Deleted:     true,
+	}
+	assert.Equal(t, expected, row.DAO)
+}
+
+func TestProposals(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appID := uint64(3)
+	query := `INSERT INTO proposal
+		(app, addr, proposal_id, type, from_addr, amount, executed, yes, no, abstain, status,
+		closed_round, deleted)
+		VALUES ($1, $2, $3, $4, $5, 100, FALSE, 7, 1, 0, $6, $7, $8)`
+	closedRound := uint64(9)
+	args := [][]interface{}{
+		{appID, test.AccountA[:], 1, 1, test.AccountD[:], "voting", nil, false},
+		{appID, test.AccountB[:], 2, 3, nil, "passed", nil, false},
+		{appID, test.AccountC[:], 3, 1, nil, nil, closedRound, true},
+		{appID + 1, test.AccountD[:], 1, 1, nil, "voting", nil, false},
+	}
+	for _, a := range args {
+		_, err := db.db.Exec(context.Background(), query, a...)
+		require.NoError(t, err)
+	}
+	addresses := []basics.Address{test.AccountA, test.AccountB, test.AccountC}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	getProposals := func(filter idb.ProposalQuery) []basics.Address {
+		rowsCh, _ := db.Proposals(context.Background(), filter)
+		var res []basics.Address
+		for row := range rowsCh {
+			require.NoError(t, row.Error)
+			assert.Equal(t, appID, row.Proposal.AppID)
+			res = append(res, row.Proposal.Address)
+		}
+		return res
+	}
+
+	proposalType := uint64(3)
+	notDeleted := false
+	assert.Equal(t, addresses, getProposals(idb.ProposalQuery{ApplicationID: appID}))
+	assert.Equal(
+		t, []basics.Address{test.AccountA},
+		getProposals(idb.ProposalQuery{ApplicationID: appID, Status: idb.ProposalVoting}))
+	assert.Equal(
+		t, []basics.Address{test.AccountB},
+		getProposals(idb.ProposalQuery{ApplicationID: appID, Type: &proposalType}))
+	assert.Len(t, getProposals(idb.ProposalQuery{ApplicationID: appID, Deleted: &notDeleted}), 2)
+	assert.Equal(
+		t, addresses[1:2],
+		getProposals(idb.ProposalQuery{
+			ApplicationID: appID, AddressGreaterThan: addresses[0][:], Limit: 1}))
+
+	rowsCh, _ := db.Proposals(
+		context.Background(), idb.ProposalQuery{ApplicationID: appID, Address: test.AccountA[:]})
+	row, ok := <-rowsCh
+	require.True(t, ok)
+	require.NoError(t, row.Error)
+	proposalID := uint64(1)
+	transferType := uint64(1)
+	amount := uint64(100)
+	from := test.AccountD
+	expected := idb.Proposal{
+		AppID:      appID,
+		Address:    test.AccountA,
+		ProposalID: &proposalID,
+		Type:       &transferType,
+		From:       &from,
+		Amount:     &amount,
+		Yes:        7,
+		No:         1,
+		Status:     idb.ProposalVoting,
+	}
+	assert.Equal(t, expected, row.Proposal)
+}
+
+func TestVotes(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	appID := uint64(3)
+	proposal := test.AccountA
+	query := `INSERT INTO vote (app, proposal, voter, vote_option, weight, round, deleted)
+		VALUES ($1, $2, $3, $4, $5, 6, $6)`
+	args := [][]interface{}{
+		{appID, proposal[:], test.AccountB[:], "yes", 10, false},
+		{appID, proposal[:], test.AccountC[:], "no", 20, false},
+		{appID, proposal[:], test.AccountD[:], nil, nil, true},
+		{appID, test.AccountB[:], test.AccountC[:], "yes", 5, false},
+	}
+	for _, a := range args {
+		_, err := db.db.Exec(context.Background(), query, a...)
+		require.NoError(t, err)
+	}
+	voters := []basics.Address{test.AccountB, test.AccountC, test.AccountD}
+	sort.Slice(voters, func(i, j int) bool {
+		return bytes.Compare(voters[i][:], voters[j][:]) < 0
+	})
+
+	getVoters := func(filter idb.VoteQuery) []basics.Address {
+		filter.ApplicationID = appID
+		filter.Proposal = proposal[:]
+		rowsCh, _ := db.Votes(context.Background(), filter)
+		var res []basics.Address
+		for row := range rowsCh {
+			require.NoError(t, row.Error)
+			assert.Equal(t, proposal, row.Vote.Proposal)
+			res = append(res, row.Vote.Voter)
+		}
+		return res
+	}
+
+	notDeleted := false
+	assert.Equal(t, voters, getVoters(idb.VoteQuery{}))
+	assert.Equal(t, []basics.Address{test.AccountC}, getVoters(idb.VoteQuery{Option: "no"}))
+	assert.Equal(t, []basics.Address{test.AccountB}, getVoters(idb.VoteQuery{Voter: test.AccountB[:]}))
+	assert.Len(t, getVoters(idb.VoteQuery{Deleted: &notDeleted}), 2)
+	assert.Equal(
+		t, voters[1:2], getVoters(idb.VoteQuery{VoterGreaterThan: voters[0][:], Limit: 1}))
+
+	rowsCh, _ := db.Votes(
+		context.Background(),
+		idb.VoteQuery{ApplicationID: appID, Proposal: proposal[:], Voter: test.AccountD[:]})
+	row, ok := <-rowsCh
+	require.True(t, ok)
+	require.NoError(t, row.Error)
+	expected := idb.Vote{
+		AppID:    appID,
+		Proposal: proposal,
+		Voter:    test.AccountD,
+		Round:    6,
+		Deleted:  true,
+	}
+	assert.Equal(t, expected, row.Vote)
+}
+
+func TestDAOEvents(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	insertEvent := func(round uint64, intra int, app uint64, method idb.DAOMethod, proposal []byte) {
+		args := idb.DAOEventArgs{}
+		_, err := db.db.Exec(
+			context.Background(),
+			`INSERT INTO dao_event (round, intra, app, txid, sender, proposal, method, args)
+			VALUES ($1, $2, $3, 'TXID', $4, $5, $6, $7)`,
+			round, intra, app, test.AccountA[:], proposal, string(method),
+			encoding.EncodeDAOEventArgs(&args))
+		require.NoError(t, err)
+	}
+	insertStatus := func(round uint64, app uint64, proposal basics.Address, status idb.ProposalStatus) {
+		_, err := db.db.Exec(
+			context.Background(),
+			`INSERT INTO proposal_status_history (app, addr, round, status)
+			VALUES ($1, $2, $3, $4)`,
+			app, proposal[:], round, string(status))
+		require.NoError(t, err)
+	}
+
+	insertStatus(2, 3, test.AccountC, idb.ProposalPending)
+	insertStatus(2, 3, test.AccountB, idb.ProposalPending)
+	insertEvent(2, 4, 3, idb.DAOMethodRegisterVote, test.AccountB[:])
+	insertEvent(2, 1, 3, idb.DAOMethodAddProposal, test.AccountB[:])
+	// Not streamed.
+	insertEvent(2, 5, 3, idb.DAOMethodOptinGovToken, nil)
+	insertEvent(3, 0, 7, idb.DAOMethodDepositVoteToken, nil)
+	insertStatus(4, 3, test.AccountB, idb.ProposalVoting)
+
+	type event struct {
+		round uint64
+		kind  idb.DAOEventKind
+		app   uint64
+	}
+	testcases := []struct {
+		name     string
+		filter   idb.DAOEventQuery
+		expected []event
+	}{
+		{
+			name:   "all",
+			filter: idb.DAOEventQuery{MinRound: 0, MaxRound: 10},
+			expected: []event{
+				{2, idb.DAOEventProposalCreated, 3},
+				{2, idb.DAOEventVoteRegistered, 3},
+				{2, idb.DAOEventStatusChanged, 3},
+				{2, idb.DAOEventStatusChanged, 3},
+				{3, idb.DAOEventDepositChanged, 7},
+				{4, idb.DAOEventStatusChanged, 3},
+			},
+		},
+		{
+			name:   "app",
+			filter: idb.DAOEventQuery{ApplicationID: 7, MinRound: 0, MaxRound: 10},
+			expected: []event{
+				{3, idb.DAOEventDepositChanged, 7},
+			},
+		},
+		{
+			name:   "round range",
+			filter: idb.DAOEventQuery{MinRound: 3, MaxRound: 3},
+			expected: []event{
+				{3, idb.DAOEventDepositChanged, 7},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rowsCh, _ := db.DAOEvents(context.Background(), tc.filter)
+
+			var events []event
+			for row := range rowsCh {
+				require.NoError(t, row.Error)
+				events = append(events, event{row.Event.Round, row.Event.Kind, row.Event.AppID})
+			}
+			assert.Equal(t, tc.expected, events)
+		})
+	}
+
+	// Status changes of a round are ordered by proposal address.
+	rowsCh, _ := db.DAOEvents(context.Background(), idb.DAOEventQuery{MinRound: 2, MaxRound: 2})
+	var rows []idb.DAOEventRow
+	for row := range rowsCh {
+		require.NoError(t, row.Error)
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 4)
+	assert.Equal(t, uint(1), rows[0].Event.Intra)
+	assert.Equal(t, "TXID", rows[0].Event.Txid)
+	assert.Equal(t, test.AccountA, rows[0].Event.Sender)
+	assert.Equal(t, idb.DAOMethodAddProposal, rows[0].Event.Method)
+	first, second := test.AccountB, test.AccountC
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+	require.NotNil(t, rows[2].Event.Proposal)
+	assert.Equal(t, first, *rows[2].Event.Proposal)
+	assert.Equal(t, idb.ProposalPending, rows[2].Event.Status)
+	require.NotNil(t, rows[3].Event.Proposal)
+	assert.Equal(t, second, *rows[3].Event.Proposal)
+}
+
+// AddBlock() publishes the events of every committed round.
+func TestAddBlockPublishesDAOEvents(t *testing.T) {
+	db, shutdownFunc, proc, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	db.daoEventHub = idb.MakeDAOEventHub()
+	sub := db.daoEventHub.Subscribe()
+	defer sub.Close()
+
+	block, err := test.MakeBlockForTxns(test.MakeGenesisBlock().BlockHeader)
+	require.NoError(t, err)
+	err = proc.Process(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	batch := <-sub.C
+	assert.Equal(t, uint64(1), batch.Round)
+	assert.Empty(t, batch.Events)
+}
+
+func TestWebhookOutbox(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	webhooks := []idb.Webhook{
+		{Name: "a", URL: "http://a", Secret: "sa", ApplicationIDs: []uint64{3}},
+		{Name: "b", URL: "http://b", Secret: "sb", Kinds: []idb.DAOEventKind{idb.DAOEventStatusChanged}},
+	}
+	require.NoError(t, db.SetWebhooks(webhooks))
+
+	var apps []uint64
+	var kinds []string
+	row := db.db.QueryRow(context.Background(), "SELECT apps, kinds FROM webhook WHERE name = 'b'")
+	require.NoError(t, row.Scan(&apps, &kinds))
+	assert.Nil(t, apps)
+	assert.Equal(t, []string{"status-changed"}, kinds)
+
+	_, err := db.db.Exec(
+		context.Background(),
+		`INSERT INTO webhook_delivery (webhook, round, kind, payload, status, next_attempt) VALUES
+		('a', 5, 'vote-registered', '{"round": 5}', 'pending', now()),
+		('b', 5, 'status-changed', '{"round": 5}', 'pending', now()),
+		('a', 6, 'vote-registered', '{"round": 6}', 'pending', now() + interval '1 hour'),
+		('a', 4, 'vote-registered', '{"round": 4}', 'delivered', now())`)
+	require.NoError(t, err)
+
+	// Only the due pending deliveries are claimed.
+	deliveries, err := db.ClaimWebhookDeliveries(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, uint64(5), deliveries[0].Round)
+	assert.Equal(t, idb.DAOEventVoteRegistered, deliveries[0].Kind)
+	assert.Equal(t, uint32(1), deliveries[0].Attempts)
+	assert.Equal(t, webhooks[0].URL, deliveries[0].Webhook.URL)
+	assert.Equal(t, webhooks[0].Secret, deliveries[0].Webhook.Secret)
+	assert.JSONEq(t, `{"round": 5}`, string(deliveries[0].Payload))
+	assert.Equal(t, "b", deliveries[1].Webhook.Name)
+
+	// Leased deliveries are not claimed again.
+	leased, err := db.ClaimWebhookDeliveries(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	err = db.UpdateWebhookDelivery(context.Background(), idb.WebhookDeliveryUpdate{
+		ID:          deliveries[0].ID,
+		Status:      idb.WebhookDeliveryDelivered,
+		NextAttempt: time.Now(),
+	})
+	require.NoError(t, err)
+	// A failed attempt that is due again.
+	err = db.UpdateWebhookDelivery(context.Background(), idb.WebhookDeliveryUpdate{
+		ID:          deliveries[1].ID,
+		Status:      idb.WebhookDeliveryPending,
+		NextAttempt: time.Now().Add(-time.Second),
+		Error:       "status 500",
+	})
+	require.NoError(t, err)
+
+	var status string
+	var lastError *string
+	var deliveredAt *time.Time
+	row = db.db.QueryRow(
+		context.Background(),
+		"SELECT status, last_error, delivered_at FROM webhook_delivery WHERE id = $1",
+		deliveries[0].ID)
+	require.NoError(t, row.Scan(&status, &lastError, &deliveredAt))
+	assert.Equal(t, string(idb.WebhookDeliveryDelivered), status)
+	assert.Nil(t, lastError)
+	assert.NotNil(t, deliveredAt)
+
+	retried, err := db.ClaimWebhookDeliveries(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, deliveries[1].ID, retried[0].ID)
+	assert.Equal(t, uint32(2), retried[0].Attempts)
+
+	// Removing a webhook drops its deliveries.
+	require.NoError(t, db.SetWebhooks(webhooks[1:]))
+	var count int
+	row = db.db.QueryRow(context.Background(), "SELECT count(*) FROM webhook_delivery")
+	require.NoError(t, row.Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestAPIKeys(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	keys, err := db.APIKeys(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = db.db.Exec(
+		context.Background(),
+		`INSERT INTO api_key (name, key_hash, scopes, rate_limit, burst, expires_at) VALUES
+		('frontend', sha256('secret-a'), '{public}', 2.5, 5, NULL),
+		('admin', sha256('secret-b'), '{public,dao-admin,metrics}', 0, 0, '2030-01-02T03:04:05Z')`)
+	require.NoError(t, err)
+
+	keys, err = db.APIKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, idb.APIKey{
+		Name:      "admin",
+		Hash:      idb.HashAPIKey("secret-b"),
+		Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic, idb.APIKeyScopeDAOAdmin, idb.APIKeyScopeMetrics},
+		ExpiresAt: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, idb.APIKey{
+		Name:      keys[0].Name,
+		Hash:      keys[0].Hash,
+		Scopes:    keys[0].Scopes,
+		ExpiresAt: keys[0].ExpiresAt.UTC(),
+	})
+	assert.Equal(t, idb.APIKey{
+		Name:      "frontend",
+		Hash:      idb.HashAPIKey("secret-a"),
+		Scopes:    []idb.APIKeyScope{idb.APIKeyScopePublic},
+		RateLimit: 2.5,
+		Burst:     5,
+	}, keys[1])
+
+	// An unknown scope fails the whole set.
+	_, err = db.db.Exec(
+		context.Background(),
+		"INSERT INTO api_key (name, key_hash, scopes) VALUES ('typo', sha256('secret-c'), '{pubilc}')")
+	require.NoError(t, err)
+	_, err = db.APIKeys(context.Background())
+	assert.Error(t, err)
+}
+
+// testDAOBackfillLedger is a idb.DAOBackfillLedger with a single app.
+type testDAOBackfillLedger struct {
+	header      bookkeeping.BlockHeader
+	appID       basics.AppIndex
+	creator     basics.Address
+	params      basics.AppParams
+	localStates map[basics.Address]basics.AppLocalState
+}
+
+func (l *testDAOBackfillLedger) BlockHeader() bookkeeping.BlockHeader {
+	return l.header
+}
+
+func (l *testDAOBackfillLedger) Apps(f func(appID basics.AppIndex, creator basics.Address, params *basics.AppParams) error) error {
+	return f(l.appID, l.creator, &l.params)
+}
+
+func (l *testDAOBackfillLedger) LocalStates(appID basics.AppIndex, f func(address basics.Address, localState *basics.AppLocalState) error) error {
+	for address, localState := range l.localStates {
+		localState := localState
+		err := f(address, &localState)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestBackfillDAOAppsLocalStates makes sure the local state of the accounts opted into
+// a backfilled app is written when `account_app` has no row for them.
+func TestBackfillDAOAppsLocalStates(t *testing.T) {
+	db, shutdownFunc, _, l := setupIdb(t, test.MakeGenesis())
+	defer shutdownFunc()
+	defer l.Close()
+
+	program := []byte{6, 0x81, 0x01, 0x43}
+	err := db.SetDAOPrograms([]idb.DAOProgram{{Version: "v1", ApprovalProgram: program}})
+	require.NoError(t, err)
+	require.Equal(t, 0, queryInt(db.db, "SELECT COUNT(*) FROM account_app"))
+
+	localState := func(votes uint64) basics.AppLocalState {
+		return basics.AppLocalState{
+			Schema: basics.StateSchema{NumUint: 1},
+			KeyValue: basics.TealKeyValue{
+				"votes": {Type: basics.TealUintType, Uint: votes},
+			},
+		}
+	}
+	ledger := testDAOBackfillLedger{
+		header:  test.MakeGenesisBlock().BlockHeader,
+		appID:   7,
+		creator: test.AccountA,
+		params:  basics.AppParams{ApprovalProgram: program},
+		localStates: map[basics.Address]basics.AppLocalState{
+			test.AccountA: localState(1),
+			test.AccountB: localState(2),
+			test.AccountC: localState(3),
+		},
+	}
+	count, err := db.BackfillDAOApps(context.Background(), &ledger)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	for address, expected := range ledger.localStates {
+		var localstate []byte
+		row := db.db.QueryRow(
+			context.Background(),
+			"SELECT localstate FROM account_app WHERE addr = $1 AND app = 7 AND NOT deleted",
+			address[:])
+		err = row.Scan(&localstate)
+		require.NoError(t, err, address.String())
+		actual, err := encoding.DecodeAppLocalState(localstate)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
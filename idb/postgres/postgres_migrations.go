@@ -15,6 +15,7 @@ import (
 	"github.com/algorand/indexer/idb/migration"
 	"github.com/algorand/indexer/idb/postgres/internal/encoding"
 	cad "github.com/algorand/indexer/idb/postgres/internal/migrations/convert_account_data"
+	cdt "github.com/algorand/indexer/idb/postgres/internal/migrations/create_dao_table"
 	"github.com/algorand/indexer/idb/postgres/internal/schema"
 	"github.com/algorand/indexer/idb/postgres/internal/types"
 )
@@ -50,6 +51,22 @@ func init() {
 		{upgradeNotSupported, true, "notify the user that upgrade is not supported"},
 		{dropTxnBytesColumn, true, "drop txnbytes column"},
 		{convertAccountData, true, "convert account.account_data column"},
+		{addAppVersionColumn, true, "add app.version column for SigmaDAO program versions"},
+		{createProposalTable, true, "create proposal table"},
+		{createVoteTable, true, "create vote table"},
+		{createDepositTables, true, "create dao_deposit and dao_deposit_history tables"},
+		{createDAOTable, true, "create dao table and drop app.dao_name and app.asset_id"},
+		{addProposalStatusColumn, true, "add proposal.status column"},
+		{createDAOTxnTables, true, "create dao_txn and dao_txn_participation tables"},
+		{createDAOEventTable, true, "create dao_event table"},
+		{createProposalTallyHistoryTable, true, "create proposal_tally_history table"},
+		{createProposalHolderSnapshotTable, true, "create proposal_holder_snapshot table"},
+		{createTreasuryTables, true, "create dao_treasury and dao_treasury_history tables"},
+		{addExecutionAuditColumns, true, "add proposal execution audit columns"},
+		{addClosedRoundColumns, true, "add closed_round columns and close the records of deleted daos"},
+		{createProposalStatusHistoryTable, true, "create proposal_status_history table"},
+		{createWebhookTables, true, "create webhook and webhook_delivery tables"},
+		{createAPIKeyTable, true, "create api_key table"},
 	}
 }
 
@@ -249,3 +266,327 @@ func convertAccountData(db *IndexerDb, migrationState *types.MigrationState, opt
 	*migrationState = newMigrationState
 	return nil
 }
+
+func addAppVersionColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{"ALTER TABLE app ADD COLUMN IF NOT EXISTS version text"})
+}
+
+func createProposalTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS proposal (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				proposal_id bigint,
+				name text,
+				url text,
+				url_hash bytea,
+				hash_algo text,
+				voting_start bigint,
+				voting_end bigint,
+				execute_before bigint,
+				type bigint,
+				from_addr bytea,
+				recipient bytea,
+				asa_id bigint,
+				amount numeric(20),
+				msg bytea,
+				executed bool NOT NULL,
+				yes numeric(20) NOT NULL,
+				no numeric(20) NOT NULL,
+				abstain numeric(20) NOT NULL,
+				deleted bool NOT NULL,
+				PRIMARY KEY (app, addr)
+			)`,
+		})
+}
+
+func createVoteTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS vote (
+				app bigint NOT NULL,
+				proposal bytea NOT NULL,
+				voter bytea NOT NULL,
+				vote_option text,
+				weight numeric(20),
+				round bigint NOT NULL,
+				deleted bool NOT NULL,
+				PRIMARY KEY (app, proposal, voter)
+			)`,
+			"CREATE INDEX IF NOT EXISTS vote_by_voter ON vote(voter)",
+		})
+}
+
+func createDepositTables(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS dao_deposit (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				amount numeric(20) NOT NULL,
+				deposit_lock bigint,
+				round bigint NOT NULL,
+				deleted bool NOT NULL,
+				PRIMARY KEY (app, addr)
+			)`,
+			`CREATE TABLE IF NOT EXISTS dao_deposit_history (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				amount numeric(20) NOT NULL,
+				deposit_lock bigint,
+				PRIMARY KEY (app, addr, round)
+			)`,
+		})
+}
+
+func createDAOTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	newMigrationState := *migrationState
+	newMigrationState.NextMigration++
+
+	f := func(tx pgx.Tx) error {
+		err := cdt.RunMigration(tx)
+		if err != nil {
+			return fmt.Errorf("createDAOTable() err: %w", err)
+		}
+
+		err = db.setMigrationState(tx, &newMigrationState)
+		if err != nil {
+			return fmt.Errorf("createDAOTable() err: %w", err)
+		}
+
+		return nil
+	}
+	err := db.txWithRetry(serializable, f)
+	if err != nil {
+		return fmt.Errorf("createDAOTable() err: %w", err)
+	}
+
+	*migrationState = newMigrationState
+	return nil
+}
+
+func addProposalStatusColumn(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS status text"})
+}
+
+func createDAOTxnTables(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS dao_txn (
+				round bigint NOT NULL,
+				intra integer NOT NULL,
+				typeenum smallint NOT NULL,
+				asset bigint NOT NULL,
+				txid bytea,
+				txn jsonb NOT NULL,
+				extra jsonb NOT NULL,
+				round_time timestamp with time zone NOT NULL,
+				PRIMARY KEY (round, intra)
+			)`,
+			"CREATE INDEX IF NOT EXISTS dao_txn_by_asset ON dao_txn (asset, round, intra)",
+			"CREATE INDEX IF NOT EXISTS dao_txn_by_txid ON dao_txn (txid)",
+			`CREATE TABLE IF NOT EXISTS dao_txn_participation (
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				intra integer NOT NULL,
+				PRIMARY KEY (addr, round, intra)
+			)`,
+		})
+}
+
+func createDAOEventTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS dao_event (
+				round bigint NOT NULL,
+				intra integer NOT NULL,
+				app bigint NOT NULL,
+				txid bytea NOT NULL,
+				sender bytea NOT NULL,
+				proposal bytea,
+				method text NOT NULL,
+				args jsonb NOT NULL,
+				PRIMARY KEY (round, intra)
+			)`,
+			"CREATE INDEX IF NOT EXISTS dao_event_by_app ON dao_event (app, round, intra)",
+			"CREATE INDEX IF NOT EXISTS dao_event_by_proposal ON dao_event (proposal) WHERE proposal IS NOT NULL",
+		})
+}
+
+// createProposalTallyHistoryTable creates the tally history and seeds it with the
+// current counts of every proposal, so that each series starts with a known value.
+func createProposalTallyHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS proposal_tally_history (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				yes numeric(20) NOT NULL,
+				no numeric(20) NOT NULL,
+				abstain numeric(20) NOT NULL,
+				PRIMARY KEY (app, addr, round)
+			)`,
+			`INSERT INTO proposal_tally_history (app, addr, round, yes, no, abstain)
+				SELECT p.app, p.addr, COALESCE((m.v->>'next_account_round')::bigint - 1, 0),
+				p.yes, p.no, p.abstain
+				FROM proposal p LEFT JOIN metastate m ON m.k = '` + schema.StateMetastateKey + `'
+				WHERE NOT p.deleted
+				ON CONFLICT (app, addr, round) DO NOTHING`,
+		})
+}
+
+// createProposalHolderSnapshotTable creates the holder snapshot table. Proposals whose
+// voting already started get snapshot_round 0, since their balances at voting start
+// are not known.
+func createProposalHolderSnapshotTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS snapshot_round bigint",
+			`UPDATE proposal SET snapshot_round = 0
+				WHERE snapshot_round IS NULL AND status IS NOT NULL AND status <> '` +
+				string(idb.ProposalPending) + `'`,
+			`CREATE TABLE IF NOT EXISTS proposal_holder_snapshot (
+				app bigint NOT NULL,
+				proposal bytea NOT NULL,
+				holder bytea NOT NULL,
+				amount numeric(20) NOT NULL,
+				round bigint NOT NULL,
+				PRIMARY KEY (app, proposal, holder)
+			)`,
+		})
+}
+
+// createTreasuryTables creates the treasury tables and starts tracking the `from`
+// accounts of the existing proposals from their current balances.
+func createTreasuryTables(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS dao_treasury (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				asset bigint NOT NULL,
+				amount numeric(20) NOT NULL,
+				round bigint NOT NULL,
+				PRIMARY KEY (app, addr, asset)
+			)`,
+			`CREATE TABLE IF NOT EXISTS dao_treasury_history (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				asset bigint NOT NULL,
+				round bigint NOT NULL,
+				amount numeric(20) NOT NULL,
+				change numeric(21),
+				PRIMARY KEY (app, addr, asset, round)
+			)`,
+			`WITH treasuries AS (
+				SELECT DISTINCT app, from_addr AS addr FROM proposal WHERE from_addr IS NOT NULL),
+				current_round AS (
+				SELECT COALESCE((v->>'next_account_round')::bigint - 1, 0) AS round
+				FROM metastate WHERE k = '` + schema.StateMetastateKey + `'),
+				balances AS (
+				SELECT t.app, t.addr, 0 AS asset, COALESCE(a.microalgos, 0)::numeric AS amount
+				FROM treasuries t LEFT JOIN account a ON a.addr = t.addr AND NOT a.deleted
+				UNION ALL
+				SELECT t.app, t.addr, aa.assetid, aa.amount
+				FROM treasuries t JOIN account_asset aa ON aa.addr = t.addr AND NOT aa.deleted),
+				seeded AS (
+				INSERT INTO dao_treasury (app, addr, asset, amount, round)
+				SELECT b.app, b.addr, b.asset, b.amount, COALESCE(r.round, 0)
+				FROM balances b LEFT JOIN current_round r ON TRUE
+				ON CONFLICT (app, addr, asset) DO NOTHING
+				RETURNING app, addr, asset, amount, round)
+				INSERT INTO dao_treasury_history (app, addr, asset, round, amount, change)
+				SELECT app, addr, asset, round, amount, NULL FROM seeded
+				ON CONFLICT (app, addr, asset, round) DO NOTHING`,
+		})
+}
+
+func addExecutionAuditColumns(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS execution_round bigint",
+			"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS execution_result text",
+			"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS execution_details text",
+		})
+}
+
+// addClosedRoundColumns adds the round in which dao records were closed. Records of
+// daos deleted before the cascade existed are closed with an unknown round.
+func addClosedRoundColumns(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			"ALTER TABLE dao ADD COLUMN IF NOT EXISTS closed_round bigint",
+			"ALTER TABLE proposal ADD COLUMN IF NOT EXISTS closed_round bigint",
+			"ALTER TABLE vote ADD COLUMN IF NOT EXISTS closed_round bigint",
+			"ALTER TABLE dao_deposit ADD COLUMN IF NOT EXISTS closed_round bigint",
+			`UPDATE proposal p SET deleted = TRUE FROM dao d
+				WHERE d.app = p.app AND d.deleted AND NOT p.deleted`,
+			`UPDATE vote v SET deleted = TRUE FROM dao d
+				WHERE d.app = v.app AND d.deleted AND NOT v.deleted`,
+			`UPDATE dao_deposit dd SET deleted = TRUE FROM dao d
+				WHERE d.app = dd.app AND d.deleted AND NOT dd.deleted`,
+		})
+}
+
+// createProposalStatusHistoryTable creates the history of proposal status changes.
+// Changes before the migration are not known.
+func createProposalStatusHistoryTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS proposal_status_history (
+				app bigint NOT NULL,
+				addr bytea NOT NULL,
+				round bigint NOT NULL,
+				status text NOT NULL,
+				PRIMARY KEY (app, addr, round)
+			)`,
+			"CREATE INDEX IF NOT EXISTS proposal_status_history_by_round ON proposal_status_history (round)",
+		})
+}
+
+// createWebhookTables creates the webhooks and their delivery outbox.
+func createWebhookTables(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS webhook (
+				name text PRIMARY KEY,
+				url text NOT NULL,
+				secret text NOT NULL,
+				apps bigint[],
+				kinds text[]
+			)`,
+			`CREATE TABLE IF NOT EXISTS webhook_delivery (
+				id bigserial PRIMARY KEY,
+				webhook text NOT NULL REFERENCES webhook (name) ON DELETE CASCADE,
+				round bigint NOT NULL,
+				kind text NOT NULL,
+				payload jsonb NOT NULL,
+				status text NOT NULL,
+				attempts integer NOT NULL DEFAULT 0,
+				next_attempt timestamp with time zone NOT NULL,
+				last_error text,
+				delivered_at timestamp with time zone
+			)`,
+			"CREATE INDEX IF NOT EXISTS webhook_delivery_pending ON webhook_delivery (next_attempt, id) WHERE status = 'pending'",
+		})
+}
+
+// createAPIKeyTable creates the table of REST API keys.
+func createAPIKeyTable(db *IndexerDb, migrationState *types.MigrationState, opts *idb.IndexerDbOptions) error {
+	return sqlMigration(
+		db, migrationState, []string{
+			`CREATE TABLE IF NOT EXISTS api_key (
+				name text PRIMARY KEY,
+				key_hash bytea NOT NULL UNIQUE,
+				scopes text[] NOT NULL,
+				rate_limit double precision NOT NULL DEFAULT 0,
+				burst integer NOT NULL DEFAULT 0,
+				expires_at timestamp with time zone
+			)`,
+		})
+}
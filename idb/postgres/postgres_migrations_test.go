@@ -3,12 +3,20 @@ package postgres
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/rpcs"
+	test2 "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/postgres/internal/encoding"
 	pgtest "github.com/algorand/indexer/idb/postgres/internal/testing"
 	"github.com/algorand/indexer/idb/postgres/internal/types"
+	"github.com/algorand/indexer/processor/blockprocessor"
+	"github.com/algorand/indexer/util/test"
 )
 
 func TestConvertAccountDataIncrementsMigrationNumber(t *testing.T) {
@@ -32,3 +40,136 @@ func TestConvertAccountDataIncrementsMigrationNumber(t *testing.T) {
 
 	assert.Equal(t, types.MigrationState{NextMigration: 6}, migrationState)
 }
+
+// baselineSchema is the schema of a database created before the SigmaDAO migrations.
+const baselineSchema = `
+CREATE TABLE account (
+  addr bytea primary key,
+  microalgos bigint NOT NULL,
+  rewardsbase bigint NOT NULL,
+  rewards_total bigint NOT NULL,
+  deleted bool NOT NULL,
+  keytype varchar(8),
+  account_data jsonb NOT NULL
+);
+CREATE TABLE account_asset (
+  addr bytea NOT NULL,
+  assetid bigint NOT NULL,
+  amount numeric(20) NOT NULL,
+  frozen boolean NOT NULL,
+  deleted bool NOT NULL,
+  PRIMARY KEY (addr, assetid)
+);
+CREATE INDEX account_asset_by_addr_partial ON account_asset(addr) WHERE NOT deleted;
+CREATE TABLE asset (
+  index bigint PRIMARY KEY,
+  creator_addr bytea NOT NULL,
+  params jsonb NOT NULL,
+  deleted bool NOT NULL
+);
+CREATE INDEX asset_by_creator_addr_deleted ON asset(creator_addr, deleted);
+CREATE TABLE metastate (
+  k text primary key,
+  v jsonb
+);
+CREATE TABLE app (
+  index bigint PRIMARY KEY,
+  creator bytea NOT NULL,
+  params jsonb NOT NULL,
+  dao_name CHAR(255),
+  asset_id BIGINT,
+  deleted bool NOT NULL
+);
+CREATE INDEX app_by_creator_deleted ON app(creator, deleted);
+CREATE TABLE account_app (
+  addr bytea,
+  app bigint,
+  localstate jsonb NOT NULL,
+  voting_start BIGINT,
+  voting_end BIGINT,
+  deleted bool NOT NULL,
+  PRIMARY KEY (addr, app)
+);
+CREATE INDEX account_app_by_addr_partial ON account_app(addr) WHERE NOT deleted;
+`
+
+// baselineMigrations is the number of migrations applied to a database created with
+// `baselineSchema`.
+const baselineMigrations = 19
+
+// TestUpgradeBaselineSchema makes sure a database created before the SigmaDAO
+// migrations, with a SigmaDAO app, can be opened, upgraded and written to.
+func TestUpgradeBaselineSchema(t *testing.T) {
+	pdb, connStr, shutdownFunc := pgtest.SetupPostgres(t)
+	defer shutdownFunc()
+
+	_, err := pdb.Exec(context.Background(), baselineSchema)
+	require.NoError(t, err)
+	baselineDB := IndexerDb{db: pdb}
+	err = baselineDB.setMigrationState(nil, &types.MigrationState{NextMigration: baselineMigrations})
+	require.NoError(t, err)
+
+	params := basics.AppParams{
+		GlobalState: basics.TealKeyValue{
+			"dao_name":     {Type: basics.TealBytesType, Bytes: "my dao"},
+			"gov_token_id": {Type: basics.TealUintType, Uint: 12},
+		},
+	}
+	_, err = pdb.Exec(
+		context.Background(),
+		`INSERT INTO app (index, creator, params, dao_name, asset_id, deleted)
+		VALUES ($1, $2, $3, $4, $5, FALSE)`,
+		1, test.AccountA[:], encoding.EncodeAppParams(params), "my dao", 12)
+	require.NoError(t, err)
+
+	// The tables read by the caches do not exist until the migrations ran.
+	db, availableCh, err := OpenPostgres(connStr, idb.IndexerDbOptions{}, nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	select {
+	case <-availableCh:
+	case <-time.After(time.Minute):
+		require.FailNow(t, "migrations did not finish")
+	}
+	migrationState, err := db.getMigrationState(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.MigrationState{NextMigration: len(migrations)}, migrationState)
+
+	// The dao row written by the create_dao_table migration survives the later
+	// changes of the dao table.
+	var name string
+	var govTokenID uint64
+	var closedRound *uint64
+	var deleted bool
+	row := db.db.QueryRow(
+		context.Background(),
+		"SELECT name, gov_token_id, closed_round, deleted FROM dao WHERE app = 1")
+	err = row.Scan(&name, &govTokenID, &closedRound, &deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "my dao", name)
+	assert.Equal(t, uint64(12), govTokenID)
+	assert.Nil(t, closedRound)
+	assert.False(t, deleted)
+
+	err = db.LoadGenesis(test.MakeGenesis())
+	require.NoError(t, err)
+	logger, _ := test2.NewNullLogger()
+	l, err := test.MakeTestLedger(logger)
+	require.NoError(t, err)
+	defer l.Close()
+	proc, err := blockprocessor.MakeProcessorWithLedger(logger, l, db.AddBlock)
+	require.NoError(t, err)
+
+	txn := test.MakePaymentTxn(
+		1000, 10000, 0, 0, 0, 0, test.AccountD, test.AccountE, basics.Address{},
+		basics.Address{})
+	block, err := test.MakeBlockForTxns(test.MakeGenesisBlock().BlockHeader, &txn)
+	require.NoError(t, err)
+	err = proc.Process(&rpcs.EncodedBlockCert{Block: block})
+	require.NoError(t, err)
+
+	round, err := db.GetNextRoundToAccount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), round)
+}
@@ -78,7 +78,7 @@ func TestWriteReadAccountData(t *testing.T) {
 	}
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&bookkeeping.Block{}, transactions.Payset{}, delta)
@@ -258,7 +258,7 @@ func TestWriteReadResources(t *testing.T) {
 	}
 
 	f := func(tx pgx.Tx) error {
-		w, err := writer.MakeWriter(tx)
+		w, err := writer.MakeWriter(tx, writer.DAOProgramRegistry{}, nil, nil, nil, nil)
 		require.NoError(t, err)
 
 		err = w.AddBlock(&bookkeeping.Block{}, transactions.Payset{}, delta)
@@ -0,0 +1,64 @@
+package idb
+
+import (
+	"strings"
+)
+
+// ProposalStatus is the lifecycle status of a SigmaDAO proposal.
+type ProposalStatus string
+
+// Possible proposal statuses.
+const (
+	// ProposalPending means voting has not started yet.
+	ProposalPending ProposalStatus = "pending"
+	// ProposalVoting means the proposal is open for voting.
+	ProposalVoting ProposalStatus = "voting"
+	// ProposalPassed means voting ended with enough support, and the proposal can
+	// still be executed.
+	ProposalPassed ProposalStatus = "passed"
+	// ProposalRejected means voting ended without enough support.
+	ProposalRejected ProposalStatus = "rejected"
+	// ProposalExecuted means the proposal has been executed.
+	ProposalExecuted ProposalStatus = "executed"
+	// ProposalExpired means the proposal passed but was not executed in time.
+	ProposalExpired ProposalStatus = "expired"
+)
+
+var proposalStatusEnumMap = map[ProposalStatus]struct{}{
+	ProposalPending:  {},
+	ProposalVoting:   {},
+	ProposalPassed:   {},
+	ProposalRejected: {},
+	ProposalExecuted: {},
+	ProposalExpired:  {},
+}
+
+func makeProposalStatusEnumString() string {
+	keys := make([]string, 0, len(proposalStatusEnumMap))
+	for k := range proposalStatusEnumMap {
+		keys = append(keys, string(k))
+	}
+	return strings.Join(keys, ", ")
+}
+
+// ProposalStatusEnumString is a comma-separated list of possible proposal statuses.
+var ProposalStatusEnumString = makeProposalStatusEnumString()
+
+// IsProposalStatusValid returns true if and only if `status` is one of the possible
+// proposal statuses.
+func IsProposalStatusValid(status ProposalStatus) bool {
+	_, ok := proposalStatusEnumMap[status]
+	return ok
+}
+
+// ExecutionResult is the outcome of checking the payout of an executed proposal
+// against its terms.
+type ExecutionResult string
+
+// Possible execution results.
+const (
+	// ExecutionMatch means the payout matched the proposal terms.
+	ExecutionMatch ExecutionResult = "match"
+	// ExecutionMismatch means the payout differed from the proposal terms.
+	ExecutionMismatch ExecutionResult = "mismatch"
+)
@@ -0,0 +1,62 @@
+package idb
+
+import (
+	"time"
+)
+
+// Webhook is an HTTP endpoint notified of the SigmaDAO events of the committed
+// rounds. Every matching event is written to the delivery outbox in the same
+// transaction as its block.
+type Webhook struct {
+	// Name identifies the webhook, its deliveries refer to it.
+	Name string
+
+	// URL receives the events with POST requests.
+	URL string
+
+	// Secret is the HMAC-SHA256 key signing the request bodies.
+	Secret string
+
+	// ApplicationIDs are the dao apps the webhook is notified of, all apps if empty.
+	ApplicationIDs []uint64
+
+	// Kinds are the event kinds the webhook is notified of, all kinds if empty.
+	Kinds []DAOEventKind
+}
+
+// WebhookDeliveryStatus is the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// Webhook delivery states.
+const (
+	// WebhookDeliveryPending deliveries are attempted when their next attempt is due.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered deliveries were acknowledged by the webhook.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead deliveries ran out of attempts and are not retried.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event to deliver to one webhook.
+type WebhookDelivery struct {
+	// ID is unique and stable across attempts, receivers can use it to discard
+	// repeated deliveries.
+	ID      uint64
+	Webhook Webhook
+	Round   uint64
+	Kind    DAOEventKind
+	// Payload is the request body, the event encoded in json.
+	Payload []byte
+	// Attempts is the number of attempts, including the current one.
+	Attempts uint32
+}
+
+// WebhookDeliveryUpdate is the result of a delivery attempt.
+type WebhookDeliveryUpdate struct {
+	ID     uint64
+	Status WebhookDeliveryStatus
+	// NextAttempt is when a pending delivery is attempted again.
+	NextAttempt time.Time
+	// Error describes the last failed attempt, empty on success.
+	Error string
+}
@@ -145,6 +145,12 @@ func (proc *blockProcessor) NextRoundToProcess() uint64 {
 	return uint64(proc.ledger.Latest()) + 1
 }
 
+// LedgerState is part of processor.Processor. The handler is called before the block
+// is added to the ledger, so the latest round is the one before the block.
+func (proc *blockProcessor) LedgerState() idb.LedgerState {
+	return indexerledger.MakeLedgerState(proc.ledger)
+}
+
 // Preload all resources (account data, account resources, asset/app creators) for the
 // evaluator.
 func prepareEvalResources(l *indexerledger.LedgerForEvaluator, block *bookkeeping.Block) (ledger.EvalForIndexerResources, error) {
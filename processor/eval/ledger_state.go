@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/ledger"
+	"github.com/algorand/go-algorand/ledger/ledgercore"
+)
+
+// LedgerState implements idb.LedgerState at the latest round of the ledger. The
+// block processor calls its handler before adding the block to the ledger, so that
+// is the round before the block being handled.
+type LedgerState struct {
+	Ledger *ledger.Ledger
+}
+
+// MakeLedgerState creates a LedgerState object.
+func MakeLedgerState(ld *ledger.Ledger) LedgerState {
+	return LedgerState{Ledger: ld}
+}
+
+// Account is part of idb.LedgerState.
+func (l LedgerState) Account(address basics.Address) (ledgercore.AccountData, error) {
+	accountData, _, err := l.Ledger.LookupWithoutRewards(l.Ledger.Latest(), address)
+	if err != nil {
+		return ledgercore.AccountData{}, fmt.Errorf("Account() err: %w", err)
+	}
+	return accountData, nil
+}
+
+// AssetHoldings is part of idb.LedgerState.
+func (l LedgerState) AssetHoldings(address basics.Address) (map[basics.AssetIndex]basics.AssetHolding, error) {
+	accountData, _, _, err := l.Ledger.LookupLatest(address)
+	if err != nil {
+		return nil, fmt.Errorf("AssetHoldings() err: %w", err)
+	}
+	return accountData.Assets, nil
+}
+
+// AssetHolding is part of idb.LedgerState.
+func (l LedgerState) AssetHolding(address basics.Address, assetID basics.AssetIndex) (*basics.AssetHolding, error) {
+	resource, err := l.Ledger.LookupAsset(l.Ledger.Latest(), address, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("AssetHolding() err: %w", err)
+	}
+	return resource.AssetHolding, nil
+}
+
+// Asset is part of idb.LedgerState.
+func (l LedgerState) Asset(assetID basics.AssetIndex) (basics.Address, *basics.AssetParams, error) {
+	creator, ok, err := l.Ledger.GetCreatorForRound(
+		l.Ledger.Latest(), basics.CreatableIndex(assetID), basics.AssetCreatable)
+	if err != nil {
+		return basics.Address{}, nil, fmt.Errorf("Asset() creator err: %w", err)
+	}
+	if !ok {
+		return basics.Address{}, nil, nil
+	}
+
+	resource, err := l.Ledger.LookupAsset(l.Ledger.Latest(), creator, assetID)
+	if err != nil {
+		return basics.Address{}, nil, fmt.Errorf("Asset() params err: %w", err)
+	}
+	return creator, resource.AssetParams, nil
+}
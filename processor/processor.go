@@ -3,6 +3,8 @@ package processor
 import (
 	"github.com/algorand/go-algorand/ledger/ledgercore"
 	"github.com/algorand/go-algorand/rpcs"
+
+	"github.com/algorand/indexer/idb"
 )
 
 // Processor is the block processor interface
@@ -10,4 +12,7 @@ type Processor interface {
 	Process(cert *rpcs.EncodedBlockCert) error
 	SetHandler(handler func(block *ledgercore.ValidatedBlock) error)
 	NextRoundToProcess() uint64
+	// LedgerState returns the ledger state that the blocks passed to the handler
+	// apply to.
+	LedgerState() idb.LedgerState
 }
@@ -12,6 +12,10 @@ func RegisterPrometheusMetrics() {
 	prometheus.Register(PostgresEvalTimeSeconds)
 	prometheus.Register(GetAlgodRawBlockTimeSeconds)
 	prometheus.Register(ImportedTxns)
+	prometheus.Register(ExecutionMismatches)
+	prometheus.Register(ConfigReloads)
+	prometheus.Register(WebhookDeliveries)
+	prometheus.Register(APIKeyRequests)
 }
 
 // Prometheus metric names broken out for reuse.
@@ -23,6 +27,10 @@ const (
 	PostgresEvalName         = "postgres_eval_time_sec"
 	GetAlgodRawBlockTimeName = "get_algod_raw_block_time_sec"
 	ImportedTxnsName         = "imported_txns"
+	ExecutionMismatchesName  = "execution_mismatches"
+	ConfigReloadsName        = "config_reloads"
+	WebhookDeliveriesName    = "webhook_deliveries"
+	APIKeyRequestsName       = "api_key_requests"
 )
 
 // AllMetricNames is a reference for all the custom metric names.
@@ -33,6 +41,10 @@ var AllMetricNames = []string{
 	ImportedRoundGaugeName,
 	PostgresEvalName,
 	GetAlgodRawBlockTimeName,
+	ExecutionMismatchesName,
+	ConfigReloadsName,
+	WebhookDeliveriesName,
+	APIKeyRequestsName,
 }
 
 // Initialize the prometheus objects.
@@ -88,4 +100,38 @@ var (
 			Name:      GetAlgodRawBlockTimeName,
 			Help:      "Total response time from Algod's raw block endpoint in seconds.",
 		})
+
+	ExecutionMismatches = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: "indexer_daemon",
+			Name:      ExecutionMismatchesName,
+			Help:      "SigmaDAO proposal executions whose payout did not match the proposal terms.",
+		})
+
+	ConfigReloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "indexer_daemon",
+			Name:      ConfigReloadsName,
+			Help:      "Configuration reloads grouped by subsystem: dao-programs, webhooks or api-keys, and result: success or failure.",
+		},
+		[]string{"subsystem", "result"},
+	)
+
+	WebhookDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "indexer_daemon",
+			Name:      WebhookDeliveriesName,
+			Help:      "Webhook delivery attempts grouped by result: delivered, failed or dead.",
+		},
+		[]string{"result"},
+	)
+
+	APIKeyRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "indexer_daemon",
+			Name:      APIKeyRequestsName,
+			Help:      "Authenticated API requests grouped by key name and result: accepted, invalid, expired, forbidden or rate_limited.",
+		},
+		[]string{"key", "result"},
+	)
 )
@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/util/metrics"
+)
+
+// Headers of the webhook requests.
+const (
+	// DeliveryHeader holds the delivery ID, which is the same for every attempt.
+	// Deliveries are at least once, receivers use it to discard repeats.
+	DeliveryHeader = "X-SigmaDAO-Delivery"
+	// EventHeader holds the event kind.
+	EventHeader = "X-SigmaDAO-Event"
+	// SignatureHeader holds "sha256=" followed by the hex encoded HMAC-SHA256 of the
+	// request body, keyed with the webhook secret.
+	SignatureHeader = "X-SigmaDAO-Signature"
+)
+
+// maxErrorBodySize is how much of a failed response body is recorded.
+const maxErrorBodySize = 512
+
+// Options configure the delivery worker.
+type Options struct {
+	// PollInterval is how often the outbox is checked once it is drained.
+	PollInterval time.Duration
+	// BatchSize is the number of deliveries claimed at once.
+	BatchSize uint64
+	// Timeout is the timeout of a delivery request.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts before a delivery is dead-lettered.
+	MaxAttempts uint32
+	// InitialBackoff is the delay before the second attempt. It doubles with every
+	// attempt, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultOptions returns the default worker options. A delivery is attempted for
+// about 18 hours before it is dead-lettered.
+func DefaultOptions() Options {
+	return Options{
+		PollInterval:   time.Second,
+		BatchSize:      20,
+		Timeout:        10 * time.Second,
+		MaxAttempts:    15,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     4 * time.Hour,
+	}
+}
+
+// backoff returns the delay before the attempt following attempt number
+// `attempts`.
+func (o Options) backoff(attempts uint32) time.Duration {
+	res := o.InitialBackoff
+	for i := uint32(1); i < attempts && res < o.MaxBackoff; i++ {
+		res *= 2
+	}
+	if res > o.MaxBackoff {
+		return o.MaxBackoff
+	}
+	return res
+}
+
+// lease is how long a claimed delivery is reserved, it must outlast the request.
+func (o Options) lease() time.Duration {
+	return 2*o.Timeout + time.Minute
+}
+
+// Sign returns the signature header value of `body`.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Worker delivers the webhook outbox. Deliveries are claimed from the database,
+// so a delivery interrupted by a restart is attempted again once its lease expires.
+// Receivers may therefore see a delivery more than once, with the same delivery
+// ID.
+type Worker struct {
+	db     idb.IndexerDb
+	log    *log.Logger
+	opts   Options
+	client *http.Client
+	// now is the clock, replaced by tests.
+	now func() time.Time
+}
+
+// MakeWorker creates a worker delivering the outbox of `db`.
+func MakeWorker(db idb.IndexerDb, log *log.Logger, opts Options) *Worker {
+	return &Worker{
+		db:     db,
+		log:    log,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		now:    time.Now,
+	}
+}
+
+// Run delivers the outbox until `ctx` is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		n, err := w.processBatch(ctx)
+		if err != nil {
+			w.log.WithError(err).Error("webhook delivery failed")
+		}
+		// Keep going while the outbox has due deliveries.
+		if err == nil && n > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+// processBatch claims and attempts a batch of due deliveries and returns their
+// number.
+func (w *Worker) processBatch(ctx context.Context) (int, error) {
+	deliveries, err := w.db.ClaimWebhookDeliveries(ctx, w.opts.BatchSize, w.opts.lease())
+	if err != nil {
+		return 0, fmt.Errorf("processBatch() err: %w", err)
+	}
+
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			// The remaining deliveries are attempted again after their lease.
+			return i, nil
+		}
+
+		update := w.attempt(ctx, &deliveries[i])
+		if update.Status != idb.WebhookDeliveryDelivered && ctx.Err() != nil {
+			// Interrupted, not a failure of the webhook.
+			return i, nil
+		}
+		// A successful delivery is recorded even if `ctx` was canceled meanwhile.
+		err = w.db.UpdateWebhookDelivery(context.Background(), update)
+		if err != nil {
+			return i, fmt.Errorf("processBatch() err: %w", err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt sends `delivery` and returns the resulting update.
+func (w *Worker) attempt(ctx context.Context, delivery *idb.WebhookDelivery) idb.WebhookDeliveryUpdate {
+	update := idb.WebhookDeliveryUpdate{
+		ID:          delivery.ID,
+		Status:      idb.WebhookDeliveryDelivered,
+		NextAttempt: w.now(),
+	}
+
+	err := w.send(ctx, delivery)
+	if err == nil {
+		metrics.WebhookDeliveries.WithLabelValues(string(idb.WebhookDeliveryDelivered)).Inc()
+		return update
+	}
+	update.Error = err.Error()
+	if ctx.Err() != nil {
+		update.Status = idb.WebhookDeliveryPending
+		return update
+	}
+
+	if delivery.Attempts >= w.opts.MaxAttempts {
+		update.Status = idb.WebhookDeliveryDead
+		w.log.WithError(err).Warnf(
+			"webhook %s delivery %d is dead after %d attempts",
+			delivery.Webhook.Name, delivery.ID, delivery.Attempts)
+		metrics.WebhookDeliveries.WithLabelValues(string(idb.WebhookDeliveryDead)).Inc()
+		return update
+	}
+
+	update.Status = idb.WebhookDeliveryPending
+	update.NextAttempt = w.now().Add(w.opts.backoff(delivery.Attempts))
+	w.log.WithError(err).Infof(
+		"webhook %s delivery %d attempt %d failed, retrying at %s",
+		delivery.Webhook.Name, delivery.ID, delivery.Attempts, update.NextAttempt)
+	metrics.WebhookDeliveries.WithLabelValues("failed").Inc()
+	return update
+}
+
+// send posts `delivery` to its webhook. Any 2xx response acknowledges it.
+func (w *Worker) send(ctx context.Context, delivery *idb.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("send() err: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set(EventHeader, string(delivery.Kind))
+	req.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send() err: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("send() status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
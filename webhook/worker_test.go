@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/indexer/idb"
+	"github.com/algorand/indexer/idb/mocks"
+)
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2.
+	assert.Equal(t,
+		"sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Sign("Jefe", []byte("what do ya want for nothing?")))
+}
+
+func TestBackoff(t *testing.T) {
+	opts := Options{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	assert.Equal(t, time.Second, opts.backoff(1))
+	assert.Equal(t, 2*time.Second, opts.backoff(2))
+	assert.Equal(t, 8*time.Second, opts.backoff(4))
+	assert.Equal(t, 10*time.Second, opts.backoff(5))
+	assert.Equal(t, 10*time.Second, opts.backoff(100))
+}
+
+func TestProcessBatch(t *testing.T) {
+	type request struct {
+		delivery  string
+		event     string
+		signature string
+		body      string
+	}
+	var mu sync.Mutex
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, request{
+			delivery:  r.Header.Get(DeliveryHeader),
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      string(body),
+		})
+		if r.URL.Path == "/fail" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	opts := DefaultOptions()
+	ok := idb.Webhook{Name: "ok", URL: server.URL + "/ok", Secret: "secret"}
+	fail := idb.Webhook{Name: "fail", URL: server.URL + "/fail", Secret: "secret"}
+	deliveries := []idb.WebhookDelivery{
+		{ID: 1, Webhook: ok, Kind: idb.DAOEventVoteRegistered, Payload: []byte(`{"round":1}`), Attempts: 1},
+		{ID: 2, Webhook: fail, Kind: idb.DAOEventStatusChanged, Payload: []byte(`{"round":2}`), Attempts: 2},
+		{ID: 3, Webhook: fail, Kind: idb.DAOEventStatusChanged, Payload: []byte(`{"round":3}`), Attempts: opts.MaxAttempts},
+	}
+
+	db := &mocks.IndexerDb{}
+	db.On("ClaimWebhookDeliveries", mock.Anything, opts.BatchSize, opts.lease()).
+		Return(deliveries, nil).Once()
+	var updates []idb.WebhookDeliveryUpdate
+	db.On("UpdateWebhookDelivery", mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) {
+			updates = append(updates, args.Get(1).(idb.WebhookDeliveryUpdate))
+		})
+
+	logger, _ := test.NewNullLogger()
+	w := MakeWorker(db, logger, opts)
+	now := time.Unix(1000, 0)
+	w.now = func() time.Time { return now }
+
+	n, err := w.processBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 3)
+	assert.Equal(t, "1", requests[0].delivery)
+	assert.Equal(t, string(idb.DAOEventVoteRegistered), requests[0].event)
+	assert.Equal(t, Sign("secret", []byte(`{"round":1}`)), requests[0].signature)
+	assert.Equal(t, `{"round":1}`, requests[0].body)
+
+	require.Len(t, updates, 3)
+	assert.Equal(t, idb.WebhookDeliveryUpdate{
+		ID:          1,
+		Status:      idb.WebhookDeliveryDelivered,
+		NextAttempt: now,
+	}, updates[0])
+	assert.Equal(t, uint64(2), updates[1].ID)
+	assert.Equal(t, idb.WebhookDeliveryPending, updates[1].Status)
+	assert.Equal(t, now.Add(opts.backoff(2)), updates[1].NextAttempt)
+	assert.Contains(t, updates[1].Error, "503")
+	assert.Equal(t, uint64(3), updates[2].ID)
+	assert.Equal(t, idb.WebhookDeliveryDead, updates[2].Status)
+	db.AssertExpectations(t)
+}
+
+func TestProcessBatchCanceled(t *testing.T) {
+	db := &mocks.IndexerDb{}
+	db.On("ClaimWebhookDeliveries", mock.Anything, mock.Anything, mock.Anything).Return(
+		[]idb.WebhookDelivery{{ID: 1, Webhook: idb.Webhook{URL: "http://localhost:1"}}}, nil)
+
+	logger, _ := test.NewNullLogger()
+	w := MakeWorker(db, logger, DefaultOptions())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Claimed deliveries are left to their lease.
+	n, err := w.processBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	db.AssertNotCalled(t, "UpdateWebhookDelivery", mock.Anything, mock.Anything)
+}